@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/voice"
+	"github.com/spf13/cobra"
+)
+
+var fakeVapiPort int
+
+var FakeVapiCmd = &cobra.Command{
+	Use:   "fake-vapi",
+	Short: "Run an in-memory fake of the Vapi assistant API for local development",
+	Run: func(cmd *cobra.Command, args []string) {
+		server := voice.NewFakeVapiServer(config.AppConfig.VoicePlatform.Vapi.APIKey)
+		logger.Info(fmt.Sprintf("Fake Vapi API listening on :%d", fakeVapiPort))
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", fakeVapiPort), server); err != nil {
+			logger.Error("Error running the fake Vapi API", err)
+		}
+	},
+}
+
+func init() {
+	FakeVapiCmd.Flags().IntVar(&fakeVapiPort, "port", 3100, "port to listen on")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository/db"
+	"github.com/spf13/cobra"
+)
+
+var syncSpecialitiesDryRun bool
+
+var SyncSpecialitiesCmd = &cobra.Command{
+	Use:   "sync-specialities",
+	Short: "Sync the bundled speciality catalog into the database and report the diff",
+	Run: func(cmd *cobra.Command, args []string) {
+		database := db.OpenDB()
+		if err := database.AutoMigrate(&entity.Speciality{}, &entity.CatalogVersion{}); err != nil {
+			logger.Error("Error migrating speciality tables:", err)
+			return
+		}
+		if !syncSpecialitiesDryRun {
+			if err := db.MigrateMislabelledPeriodontics(database); err != nil {
+				logger.Error("Error migrating specialities:", err)
+				return
+			}
+		}
+
+		catalog, err := db.LoadSpecialityCatalog()
+		if err != nil {
+			logger.Error("Error loading speciality catalog:", err)
+			return
+		}
+
+		report, err := db.SyncSpecialities(database, catalog, syncSpecialitiesDryRun)
+		if err != nil {
+			logger.Error("Error syncing specialities:", err)
+			return
+		}
+
+		output, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	},
+}
+
+func init() {
+	SyncSpecialitiesCmd.Flags().BoolVar(&syncSpecialitiesDryRun, "dry-run", false, "report the changes without applying them")
+}
@@ -9,10 +9,12 @@ import (
 )
 
 type Config struct {
-	Server      ServerConfig
-	Database    DatabaseConfig
-	Auth        AuthConfig
-	Application Application
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Auth          AuthConfig
+	Application   Application
+	Notification  NotificationConfig
+	VoicePlatform VoicePlatformConfig `mapstructure:"voice_platform"`
 }
 
 type ServerConfig struct {
@@ -52,6 +54,65 @@ type Application struct {
 	Profile         string `mapstructure:"profile"`
 }
 
+// NotificationConfig selects the notification channel drivers. The "mailbox"
+// email driver and the "fake" SMS driver write messages to local files
+// instead of sending them.
+type NotificationConfig struct {
+	Email               EmailConfig `mapstructure:"email"`
+	SMS                 SMSConfig   `mapstructure:"sms"`
+	MaxAttempts         int         `mapstructure:"max_attempts"`
+	BackoffSeconds      int         `mapstructure:"backoff_seconds"`
+	PollIntervalSeconds int         `mapstructure:"poll_interval_seconds"`
+}
+
+type EmailConfig struct {
+	Driver     string     `mapstructure:"driver"`
+	From       string     `mapstructure:"from"`
+	MailboxDir string     `mapstructure:"mailbox_dir"`
+	SMTP       SMTPConfig `mapstructure:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+type SMSConfig struct {
+	Driver  string       `mapstructure:"driver"`
+	From    string       `mapstructure:"from"`
+	FakeDir string       `mapstructure:"fake_dir"`
+	Twilio  TwilioConfig `mapstructure:"twilio"`
+}
+
+type TwilioConfig struct {
+	AccountSid string `mapstructure:"account_sid"`
+	AuthToken  string `mapstructure:"auth_token"`
+}
+
+// VoicePlatformConfig selects the platform the agent configuration is synced
+// to. The "none" driver disables syncing. Point the Vapi base URL to the
+// fake-vapi command to work offline.
+type VoicePlatformConfig struct {
+	Driver              string     `mapstructure:"driver"`
+	Vapi                VapiConfig `mapstructure:"vapi"`
+	MaxAttempts         int        `mapstructure:"max_attempts"`
+	BackoffSeconds      int        `mapstructure:"backoff_seconds"`
+	PollIntervalSeconds int        `mapstructure:"poll_interval_seconds"`
+	// WebhookToleranceSeconds is how old the timestamp of a signed webhook
+	// may be before it is rejected as a replay.
+	WebhookToleranceSeconds int `mapstructure:"webhook_tolerance_seconds"`
+	// CallerLookupTimeoutMillis is how long the voice agent waits at most for
+	// what is known about a caller when a call starts.
+	CallerLookupTimeoutMillis int `mapstructure:"caller_lookup_timeout_millis"`
+}
+
+type VapiConfig struct {
+	BaseURL string `mapstructure:"base_url"`
+	APIKey  string `mapstructure:"api_key"`
+}
+
 var AppConfig Config
 
 func InitConfig() {
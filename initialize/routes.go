@@ -1,14 +1,22 @@
 package initialize
 
 import (
+	"context"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/healthcheck"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/notification"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository/db"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/rest"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/voice"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/monitor"
+	"gorm.io/gorm"
 )
 
 func InitializeRoutes(app *fiber.App) {
@@ -18,19 +26,108 @@ func InitializeRoutes(app *fiber.App) {
 	// Create the DAO
 	organizationRepo := repository.NewOrganizationRepo(customGromDb)
 	userRepo := repository.NewUserDAO(customGromDb)
+	locationRepo := repository.NewLocationRepo(customGromDb)
+	scheduleExceptionRepo := repository.NewScheduleExceptionRepo(customGromDb)
+	specialityRepo := repository.NewSpecialityRepo(customGromDb)
+	insuranceRepo := repository.NewInsuranceRepo(customGromDb)
+	notificationRepo := repository.NewNotificationRepo(customGromDb)
+	forwardingRuleRepo := repository.NewForwardingRuleRepo(customGromDb)
+	agentProfileRepo := repository.NewAgentProfileRepo(customGromDb)
+	agentSyncRepo := repository.NewAgentSyncRepo(customGromDb)
+	knowledgeRepo := repository.NewKnowledgeRepo(customGromDb)
+	callRepo := repository.NewCallRepo(customGromDb)
+	analyticsRepo := repository.NewAnalyticsRepo(customGromDb)
+	callReviewRepo := repository.NewCallReviewRepo(customGromDb)
+	callbackRepo := repository.NewCallbackRepo(customGromDb)
+	contactRepo := repository.NewContactRepo(customGromDb)
 
 	// Create the service
 	organizationService := service.NewOrganizationService(customGromDb, organizationRepo)
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(customGromDb, userRepo)
+	locationService := service.NewLocationService(customGromDb, locationRepo)
+	availabilityService := service.NewAvailabilityService(customGromDb)
+	scheduleExceptionService := service.NewScheduleExceptionService(customGromDb, scheduleExceptionRepo, availabilityService)
+	specialityService := service.NewSpecialityService(specialityRepo)
+	insuranceService := service.NewInsuranceService(customGromDb, insuranceRepo)
+	notificationService := newNotificationService(customGromDb, notificationRepo)
+	forwardingService := service.NewForwardingService(customGromDb, forwardingRuleRepo, availabilityService)
+	agentProfileService := service.NewAgentProfileService(customGromDb, agentProfileRepo, availabilityService, insuranceService)
+	knowledgeService := service.NewKnowledgeService(customGromDb, knowledgeRepo)
+	agentConfigService := service.NewAgentConfigService(customGromDb, agentProfileService, availabilityService, insuranceService, knowledgeService)
+	agentSyncService := newAgentSyncService(customGromDb, agentSyncRepo, agentConfigService)
+	agentProfileService.OnPublish(agentSyncService.RequestSync)
+	knowledgeService.OnChange(agentSyncService.ConfigChanged)
+	onboardingService := service.NewOnboardingService(customGromDb, notificationService)
+	callbackService := service.NewCallbackService(customGromDb, callbackRepo, notificationService, availabilityService)
+	contactService := service.NewContactService(customGromDb, contactRepo)
+	callerLookupService := service.NewCallerLookupService(customGromDb, contactRepo, callbackRepo, config.AppConfig.VoicePlatform)
+	callService := service.NewCallService(customGromDb, callRepo, notificationService, availabilityService, contactService, callbackService, config.AppConfig.VoicePlatform)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, availabilityService)
+	callReviewService := service.NewCallReviewService(customGromDb, callReviewRepo)
 	securityService := service.NewSecurityService(customGromDb, userService, organizationService)
 
+	// Create the route guards
+	adminOnly := middleware.RequireRole(securityService, userService, entity.Admin)
+	authenticated := middleware.Authenticated(securityService, userService)
+	organizationMember := middleware.RequireOrganization(securityService, userService)
+	organizationAdmin := middleware.RequireOrganization(securityService, userService, entity.Admin)
+	organizationAgent := middleware.RequireOrganizationOrAgent(callerLookupService, organizationMember)
+
 	//Create the rest API
 	authHandler := rest.NewAuthHandler(*securityService, *userService)
 	organizationHandler := rest.NewOrganizationHandler(*organizationService)
+	userHandler := rest.NewUserHandler(*userService, authenticated)
+	locationHandler := rest.NewLocationHandler(*locationService, organizationMember, organizationAdmin)
+	availabilityHandler := rest.NewAvailabilityHandler(*availabilityService, organizationMember)
+	scheduleExceptionHandler := rest.NewScheduleExceptionHandler(*scheduleExceptionService, organizationMember, organizationAdmin)
+	specialityHandler := rest.NewSpecialityHandler(*specialityService, adminOnly)
+	insuranceHandler := rest.NewInsuranceHandler(*insuranceService, adminOnly, organizationMember, organizationAdmin, organizationAgent)
+	notificationHandler := rest.NewNotificationHandler(*notificationService, authenticated, organizationAdmin)
+	inboxHandler := rest.NewInboxHandler(*notificationService, authenticated)
+	forwardingHandler := rest.NewForwardingHandler(*forwardingService, organizationMember, organizationAdmin, organizationAgent)
+	agentProfileHandler := rest.NewAgentProfileHandler(*agentProfileService, authenticated, organizationMember, organizationAdmin)
+	agentConfigHandler := rest.NewAgentConfigHandler(*agentConfigService, organizationAgent)
+	agentSyncHandler := rest.NewAgentSyncHandler(*agentSyncService, organizationMember, organizationAdmin)
+	onboardingHandler := rest.NewOnboardingHandler(*onboardingService, organizationMember, organizationAdmin)
+	knowledgeHandler := rest.NewKnowledgeHandler(*knowledgeService, organizationMember, organizationAdmin, organizationAgent)
+	callHandler := rest.NewCallHandler(*callService, authenticated, organizationAdmin)
+	analyticsHandler := rest.NewAnalyticsHandler(*analyticsService, authenticated)
+	callReviewHandler := rest.NewCallReviewHandler(*callReviewService, authenticated, organizationMember, organizationAdmin)
+	callbackHandler := rest.NewCallbackHandler(*callbackService, authenticated, organizationMember, organizationAdmin)
+	contactHandler := rest.NewContactHandler(*contactService, authenticated)
+	callerLookupHandler := rest.NewCallerLookupHandler(*callerLookupService, organizationAdmin)
 
 	//Register the handlers
 	authHandler.Register(app)
 	organizationHandler.Register(app)
+	userHandler.Register(app)
+	locationHandler.Register(app)
+	availabilityHandler.Register(app)
+	scheduleExceptionHandler.Register(app)
+	specialityHandler.Register(app)
+	insuranceHandler.Register(app)
+	notificationHandler.Register(app)
+	inboxHandler.Register(app)
+	forwardingHandler.Register(app)
+	agentProfileHandler.Register(app)
+	agentConfigHandler.Register(app)
+	agentSyncHandler.Register(app)
+	onboardingHandler.Register(app)
+	knowledgeHandler.Register(app)
+	callHandler.Register(app)
+	callReviewHandler.Register(app)
+	callbackHandler.Register(app)
+	contactHandler.Register(app)
+	callerLookupHandler.Register(app)
+	analyticsHandler.Register(app)
+
+	//Start the background workers
+	go notificationService.Run(context.Background())
+	go agentSyncService.Run(context.Background())
+	go callService.Run(context.Background())
+	go analyticsService.Run(context.Background())
+	go callbackService.Run(context.Background())
+	go contactService.Run(context.Background())
 
 	//Register specific routes
 	app.Get("/health", healthcheck.Healthcheck())
@@ -38,3 +135,38 @@ func InitializeRoutes(app *fiber.App) {
 
 	logger.Info("Application initialized successfully.")
 }
+
+// newNotificationService creates the notification channels selected by the
+// configuration. A channel that cannot be created is left out, so its
+// deliveries are logged as skipped instead of preventing startup.
+func newNotificationService(db *gorm.DB, dao *repository.NotificationRepository) *service.NotificationService {
+	templates, err := notification.LoadTemplates()
+	if err != nil {
+		panic(err)
+	}
+
+	broker := notification.NewBroker()
+	channels := []notification.Channel{notification.NewInAppChannel(dao, broker)}
+	if email, err := notification.NewEmailChannel(config.AppConfig.Notification.Email); err != nil {
+		logger.Warn("Email notifications are disabled:", err)
+	} else {
+		channels = append(channels, email)
+	}
+	if sms, err := notification.NewSMSChannel(config.AppConfig.Notification.SMS); err != nil {
+		logger.Warn("SMS notifications are disabled:", err)
+	} else {
+		channels = append(channels, sms)
+	}
+	return service.NewNotificationService(db, dao, templates, broker, config.AppConfig.Notification, channels...)
+}
+
+// newAgentSyncService creates the voice platform client selected by the
+// configuration. When it cannot be created, syncing is disabled instead of
+// preventing startup.
+func newAgentSyncService(db *gorm.DB, dao *repository.AgentSyncRepository, configs *service.AgentConfigService) *service.AgentSyncService {
+	platform, err := voice.NewPlatform(config.AppConfig.VoicePlatform)
+	if err != nil {
+		logger.Warn("Voice platform sync is disabled:", err)
+	}
+	return service.NewAgentSyncService(db, dao, configs, platform, config.AppConfig.VoicePlatform)
+}
@@ -0,0 +1,256 @@
+// Package agentconfig defines the configuration document the voice agent is
+// built from. It gathers the organization, its locations, hours, services,
+// insurance carriers, published agent profiles and knowledge base in one
+// versioned schema, rendered as JSON and as a system prompt.
+package agentconfig
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+)
+
+// SchemaVersion is bumped whenever the layout of Config changes in a way
+// consumers must handle.
+const SchemaVersion = 2
+
+// Config is the configuration of the voice agent of an organization. Lists
+// are sorted so that the same data always gives the same document.
+type Config struct {
+	SchemaVersion int          `json:"schema_version"`
+	Organization  Organization `json:"organization"`
+	Agent         Agent        `json:"agent"`
+	Locations     []Location   `json:"locations"`
+	// Knowledge lists the enabled knowledge base entries in order
+	Knowledge []KnowledgeEntry `json:"knowledge"`
+}
+
+type Organization struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	Email            string `json:"email,omitempty"`
+	Website          string `json:"website,omitempty"`
+	PhoneNumber      string `json:"phone_number,omitempty"`
+	ForwardingNumber string `json:"forwarding_number,omitempty"`
+	TimeZone         string `json:"time_zone"`
+}
+
+// Agent is a published agent profile, with its scripts rendered. Language and
+// the scripts are those of the default language; Languages lists every
+// language the agent speaks, the default one first.
+type Agent struct {
+	ProfileId      string           `json:"profile_id"`
+	ProfileVersion int              `json:"profile_version"`
+	Language       string           `json:"language"`
+	VoiceType      string           `json:"voice_type"`
+	InitialScript  string           `json:"initial_script"`
+	ClosingScript  string           `json:"closing_script"`
+	Languages      []LanguageScript `json:"languages"`
+}
+
+// LanguageScript holds the scripts of the agent in one language, and the
+// words telling that a caller speaks it.
+type LanguageScript struct {
+	Language       string   `json:"language"`
+	InitialScript  string   `json:"initial_script"`
+	ClosingScript  string   `json:"closing_script"`
+	DetectionHints []string `json:"detection_hints"`
+	// Timeless, when set, holds the scripts with the variables depending on
+	// the time they were rendered at left as placeholders
+	Timeless *Scripts `json:"-"`
+}
+
+// Scripts are the initial and closing scripts of an agent.
+type Scripts struct {
+	InitialScript string
+	ClosingScript string
+}
+
+type Location struct {
+	Id               string     `json:"id"`
+	Name             string     `json:"name"`
+	IsDefault        bool       `json:"is_default"`
+	Address          string     `json:"address,omitempty"`
+	PhoneNumber      string     `json:"phone_number,omitempty"`
+	ForwardingNumber string     `json:"forwarding_number,omitempty"`
+	TimeZone         string     `json:"time_zone"`
+	WeeklyHours      []DayHours `json:"weekly_hours"`
+	Closures         []Closure  `json:"closures"`
+	Specialities     []string   `json:"specialities"`
+	AcceptedInsurers []string   `json:"accepted_insurers"`
+	DeclinedInsurers []string   `json:"declined_insurers"`
+	// Agent is set when the location has its own published profile
+	Agent *Agent `json:"agent,omitempty"`
+}
+
+// KnowledgeEntry is a question and answer, or an article, with its text in
+// every language it was written in.
+type KnowledgeEntry struct {
+	Id       string          `json:"id"`
+	Kind     string          `json:"kind"`
+	Category string          `json:"category,omitempty"`
+	Texts    []KnowledgeText `json:"texts"`
+}
+
+type KnowledgeText struct {
+	Language string   `json:"language"`
+	Title    string   `json:"title"`
+	Body     string   `json:"body"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// Text returns the text of an entry in a language, or its first text when it
+// was not written in that language.
+func (e KnowledgeEntry) Text(language string) KnowledgeText {
+	for _, text := range e.Texts {
+		if text.Language == language {
+			return text
+		}
+	}
+	if len(e.Texts) == 0 {
+		return KnowledgeText{}
+	}
+	return e.Texts[0]
+}
+
+// IsFAQ tells whether an entry is a question and answer.
+func (e KnowledgeEntry) IsFAQ() bool {
+	return e.Kind == entity.KnowledgeFAQ
+}
+
+// DayHours lists the opening hours of a weekday as "09:00-17:00" ranges. A
+// day without hours is closed.
+type DayHours struct {
+	Day   string   `json:"day"`
+	Hours []string `json:"hours"`
+}
+
+// Closure is a day whose hours differ from the weekly ones. Without hours,
+// the location is closed all day.
+type Closure struct {
+	Date   string   `json:"date"`
+	Reason string   `json:"reason,omitempty"`
+	Hours  []string `json:"hours"`
+}
+
+// Document is the compiled configuration served to the voice platform.
+// TimeDependent tells whether its scripts use variables depending on the time
+// they were rendered at, such as today's hours.
+type Document struct {
+	SchemaVersion int    `json:"schema_version"`
+	Checksum      string `json:"checksum"`
+	Config        Config `json:"config"`
+	Prompt        string `json:"prompt"`
+	TimeDependent bool   `json:"time_dependent"`
+}
+
+// Checksum identifies the content of a configuration, so the platform can
+// tell whether it changed since it last synced. Scripts count with their
+// time dependent variables unrendered, so that the checksum stays the same
+// as the day goes by.
+func Checksum(config Config) (string, error) {
+	data, err := json.Marshal(timeless(config))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Compile checksums and renders a configuration.
+func Compile(config Config) (*Document, error) {
+	config.SchemaVersion = SchemaVersion
+	checksum, err := Checksum(config)
+	if err != nil {
+		return nil, err
+	}
+	prompt, err := Render(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		SchemaVersion: SchemaVersion,
+		Checksum:      checksum,
+		Config:        config,
+		Prompt:        prompt,
+		TimeDependent: timeDependent(config),
+	}, nil
+}
+
+// timeless returns a copy of a configuration whose scripts are the timeless
+// ones, where known.
+func timeless(config Config) Config {
+	config.Agent = timelessAgent(config.Agent)
+	config.Locations = slices.Clone(config.Locations)
+	for i, location := range config.Locations {
+		if location.Agent != nil {
+			agent := timelessAgent(*location.Agent)
+			config.Locations[i].Agent = &agent
+		}
+	}
+	return config
+}
+
+func timelessAgent(agent Agent) Agent {
+	agent.Languages = slices.Clone(agent.Languages)
+	for i, language := range agent.Languages {
+		if language.Timeless == nil {
+			continue
+		}
+		agent.Languages[i].InitialScript = language.Timeless.InitialScript
+		agent.Languages[i].ClosingScript = language.Timeless.ClosingScript
+		if i == 0 {
+			agent.InitialScript = language.Timeless.InitialScript
+			agent.ClosingScript = language.Timeless.ClosingScript
+		}
+	}
+	return agent
+}
+
+// timeDependent tells whether a rendered script of a configuration differs
+// from its timeless one.
+func timeDependent(config Config) bool {
+	agents := []Agent{config.Agent}
+	for _, location := range config.Locations {
+		if location.Agent != nil {
+			agents = append(agents, *location.Agent)
+		}
+	}
+	for _, agent := range agents {
+		for _, language := range agent.Languages {
+			if language.Timeless != nil && *language.Timeless != (Scripts{InitialScript: language.InitialScript, ClosingScript: language.ClosingScript}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WeeklyHours lists the hours of every weekday, Monday first.
+func WeeklyHours(schedules []entity.DailySchedule) []DayHours {
+	days := make([]DayHours, 0, 7)
+	for i := 1; i <= 7; i++ {
+		weekday := time.Weekday(i % 7)
+		var ranges []entity.DailySchedule
+		for _, schedule := range schedules {
+			if schedule.DayOfWeek == int(weekday) {
+				ranges = append(ranges, schedule)
+			}
+		}
+		slices.SortFunc(ranges, func(a, b entity.DailySchedule) int {
+			return cmp.Compare(a.FromTime, b.FromTime)
+		})
+		hours := make([]string, 0, len(ranges))
+		for _, schedule := range ranges {
+			hours = append(hours, fmt.Sprintf("%s-%s", schedule.FromTime, schedule.ToTime))
+		}
+		days = append(days, DayHours{Day: weekday.String(), Hours: hours})
+	}
+	return days
+}
@@ -0,0 +1,280 @@
+package agentconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+var weekdays = []DayHours{
+	{Day: "Monday", Hours: []string{"08:00-12:00", "13:00-17:00"}},
+	{Day: "Tuesday", Hours: []string{"08:00-12:00", "13:00-17:00"}},
+	{Day: "Wednesday", Hours: []string{"08:00-12:00", "13:00-19:00"}},
+	{Day: "Thursday", Hours: []string{"08:00-12:00", "13:00-17:00"}},
+	{Day: "Friday", Hours: []string{"08:00-15:00"}},
+	{Day: "Saturday", Hours: []string{}},
+	{Day: "Sunday", Hours: []string{}},
+}
+
+// clinics are representative configurations, each with hours, special hours,
+// insurers, specialities, a multilingual profile and forwarding numbers.
+var clinics = map[string]Config{
+	"dental_single_location": {
+		Organization: Organization{
+			Id:               "7d1f3f5e-2f4b-4f0e-9a55-0c1f1a8f0001",
+			Name:             "Maple Dental",
+			Email:            "hello@mapledental.ca",
+			Website:          "https://mapledental.ca",
+			PhoneNumber:      "+15145550100",
+			ForwardingNumber: "+15145550199",
+			TimeZone:         "America/Toronto",
+		},
+		Agent: Agent{
+			ProfileId:      "7d1f3f5e-2f4b-4f0e-9a55-0c1f1a8f0002",
+			ProfileVersion: 3,
+			Language:       "english",
+			VoiceType:      "friendly",
+			InitialScript:  "Hi, you reached Maple Dental. We are open today from 8:00 AM to 12:00 PM and 1:00 PM to 5:00 PM.",
+			ClosingScript:  "Thank you for calling Maple Dental.",
+			Languages: []LanguageScript{
+				{
+					Language:       "english",
+					InitialScript:  "Hi, you reached Maple Dental. We are open today from 8:00 AM to 12:00 PM and 1:00 PM to 5:00 PM.",
+					ClosingScript:  "Thank you for calling Maple Dental.",
+					DetectionHints: []string{"hello", "hi", "english", "yes"},
+					Timeless: &Scripts{
+						InitialScript: "Hi, you reached Maple Dental. We are open today from {{today_hours}}.",
+						ClosingScript: "Thank you for calling Maple Dental.",
+					},
+				},
+				{
+					Language:       "french",
+					InitialScript:  "Bonjour, vous avez joint Maple Dental. Nous sommes ouverts aujourd'hui de 8 h à 12 h et 13 h à 17 h.",
+					ClosingScript:  "Merci d'avoir appelé Maple Dental.",
+					DetectionHints: []string{"bonjour", "allô", "français", "oui"},
+					Timeless: &Scripts{
+						InitialScript: "Bonjour, vous avez joint Maple Dental. Nous sommes ouverts aujourd'hui de {{today_hours}}.",
+						ClosingScript: "Merci d'avoir appelé Maple Dental.",
+					},
+				},
+			},
+		},
+		Locations: []Location{
+			{
+				Id:               "7d1f3f5e-2f4b-4f0e-9a55-0c1f1a8f0003",
+				Name:             "Plateau",
+				IsDefault:        true,
+				Address:          "4200 Saint-Denis St, Montreal, QC, H2J 2K8, Canada",
+				PhoneNumber:      "+15145550101",
+				ForwardingNumber: "+15145550102",
+				TimeZone:         "America/Toronto",
+				WeeklyHours:      weekdays,
+				Closures: []Closure{
+					{Date: "2026-12-24", Reason: "Christmas Eve", Hours: []string{"08:00-12:00"}},
+					{Date: "2026-12-25", Reason: "Christmas Day", Hours: []string{}},
+				},
+				Specialities:     []string{"Cleaning", "Orthodontics", "Root canal"},
+				AcceptedInsurers: []string{"Manulife", "Sun Life"},
+				DeclinedInsurers: []string{"Desjardins"},
+			},
+		},
+		Knowledge: []KnowledgeEntry{
+			{
+				Id:       "7d1f3f5e-2f4b-4f0e-9a55-0c1f1a8f0004",
+				Kind:     entity.KnowledgeFAQ,
+				Category: "parking",
+				Texts: []KnowledgeText{
+					{Language: "english", Title: "Is there parking?", Body: "Free parking is behind the building.", Keywords: []string{"parking", "car"}},
+					{Language: "french", Title: "Y a-t-il un stationnement ?", Body: "Le stationnement gratuit est derrière l'immeuble."},
+				},
+			},
+			{
+				Id:   "7d1f3f5e-2f4b-4f0e-9a55-0c1f1a8f0005",
+				Kind: entity.KnowledgeArticle,
+				Texts: []KnowledgeText{
+					{Language: "english", Title: "New patients", Body: "Bring your health card and your insurance details to your first visit."},
+				},
+			},
+		},
+	},
+	"physio_two_locations": {
+		Organization: Organization{
+			Id:          "9b0c4a1d-6e1a-4d3c-8f3e-5b2a7c9d0001",
+			Name:        "Riverside Physio",
+			PhoneNumber: "+16135550100",
+			TimeZone:    "America/Vancouver",
+		},
+		Agent: Agent{
+			ProfileId:      "9b0c4a1d-6e1a-4d3c-8f3e-5b2a7c9d0002",
+			ProfileVersion: 1,
+			Language:       "english",
+			VoiceType:      "professional",
+			InitialScript:  "Riverside Physio, how can I help you?",
+			ClosingScript:  "Goodbye.",
+			Languages: []LanguageScript{
+				{
+					Language:       "english",
+					InitialScript:  "Riverside Physio, how can I help you?",
+					ClosingScript:  "Goodbye.",
+					DetectionHints: []string{"hello", "hi", "english", "yes"},
+				},
+				{
+					Language:       "spanish",
+					InitialScript:  "Riverside Physio, ¿en qué puedo ayudarle?",
+					ClosingScript:  "Adiós.",
+					DetectionHints: []string{"hola", "buenos días", "español", "sí", "gracias"},
+				},
+			},
+		},
+		Locations: []Location{
+			{
+				Id:               "9b0c4a1d-6e1a-4d3c-8f3e-5b2a7c9d0003",
+				Name:             "Downtown",
+				IsDefault:        true,
+				Address:          "100 Main St, Vancouver, BC",
+				PhoneNumber:      "+16135550101",
+				ForwardingNumber: "+16135550109",
+				TimeZone:         "America/Vancouver",
+				WeeklyHours:      weekdays,
+				Closures: []Closure{
+					{Date: "2026-11-11", Reason: "Remembrance Day", Hours: []string{}},
+				},
+				Specialities:     []string{"Massage therapy", "Sports injuries"},
+				AcceptedInsurers: []string{"Blue Cross", "Green Shield"},
+				DeclinedInsurers: []string{},
+			},
+			{
+				Id:               "9b0c4a1d-6e1a-4d3c-8f3e-5b2a7c9d0004",
+				Name:             "Lonsdale",
+				Address:          "250 Lonsdale Ave, North Vancouver, BC",
+				TimeZone:         "America/Vancouver",
+				WeeklyHours:      weekdays,
+				Closures:         []Closure{{Date: "2026-11-13", Hours: []string{"10:00-14:00"}}},
+				Specialities:     []string{"Pelvic health"},
+				AcceptedInsurers: []string{},
+				DeclinedInsurers: []string{"Canada Life"},
+				Agent: &Agent{
+					ProfileId:      "9b0c4a1d-6e1a-4d3c-8f3e-5b2a7c9d0005",
+					ProfileVersion: 2,
+					Language:       "french",
+					VoiceType:      "casual",
+					InitialScript:  "Riverside Physio Lonsdale, bonjour !",
+					ClosingScript:  "Au revoir.",
+					Languages: []LanguageScript{
+						{
+							Language:       "french",
+							InitialScript:  "Riverside Physio Lonsdale, bonjour !",
+							ClosingScript:  "Au revoir.",
+							DetectionHints: []string{"bonjour", "allô", "français", "oui"},
+						},
+						{
+							Language:       "english",
+							InitialScript:  "Riverside Physio Lonsdale, hello!",
+							ClosingScript:  "Goodbye.",
+							DetectionHints: []string{"hello", "hi", "english", "yes"},
+						},
+					},
+				},
+			},
+		},
+		Knowledge: []KnowledgeEntry{},
+	},
+}
+
+func TestCompileGolden(t *testing.T) {
+	for name, config := range clinics {
+		t.Run(name, func(t *testing.T) {
+			document, err := Compile(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.MarshalIndent(document, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, filepath.Join("testdata", name+".json"), append(data, '\n'))
+			assertGolden(t, filepath.Join("testdata", name+".prompt.txt"), []byte(document.Prompt))
+		})
+	}
+}
+
+func assertGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs, run go test ./internal/agentconfig -update and review the diff\ngot:\n%s", path, got)
+	}
+}
+
+func TestChecksumIgnoresTimeVariables(t *testing.T) {
+	config := clinics["dental_single_location"]
+	before, err := Compile(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !before.TimeDependent {
+		t.Error("TimeDependent = false, want true")
+	}
+
+	later := config
+	later.Agent.InitialScript = "Hi, you reached Maple Dental. We are open today from 8:00 AM to 3:00 PM."
+	later.Agent.Languages = append([]LanguageScript(nil), config.Agent.Languages...)
+	later.Agent.Languages[0].InitialScript = later.Agent.InitialScript
+	after, err := Compile(later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Checksum != before.Checksum {
+		t.Errorf("checksum changed with the time scripts were rendered at: %s, %s", before.Checksum, after.Checksum)
+	}
+	if after.Prompt == before.Prompt {
+		t.Error("prompt did not change with the rendered scripts")
+	}
+
+	changed := later
+	changed.Locations = append([]Location(nil), config.Locations...)
+	changed.Locations[0].Specialities = []string{"Cleaning"}
+	moved, err := Compile(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Checksum == before.Checksum {
+		t.Error("checksum did not change with the specialities")
+	}
+}
+
+func TestChecksumWithoutTimeless(t *testing.T) {
+	config := clinics["physio_two_locations"]
+	document, err := Compile(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if document.TimeDependent {
+		t.Error("TimeDependent = true, want false")
+	}
+
+	config.Agent.ClosingScript = "Bye."
+	changed, err := Compile(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.Checksum == document.Checksum {
+		t.Error("checksum did not change with a script without timeless version")
+	}
+}
@@ -0,0 +1,30 @@
+package agentconfig
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/prompt.tmpl
+var promptFile embed.FS
+
+var prompt = template.Must(template.New("prompt.tmpl").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"title": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}).ParseFS(promptFile, "templates/prompt.tmpl"))
+
+// Render writes a configuration as the system prompt of the voice agent.
+func Render(config Config) (string, error) {
+	var buffer bytes.Buffer
+	if err := prompt.Execute(&buffer, config); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
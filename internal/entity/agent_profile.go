@@ -1,6 +1,15 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
 
 type LanguageType int
 
@@ -10,6 +19,26 @@ const (
 	Spanish
 )
 
+var languageNames = []string{"english", "french", "spanish"}
+
+// String returns the API name of a language, e.g. "english".
+func (l LanguageType) String() string {
+	if l < 0 || int(l) >= len(languageNames) {
+		return fmt.Sprintf("LanguageType(%d)", int(l))
+	}
+	return languageNames[l]
+}
+
+// ParseLanguageType parses the API name of a language.
+func ParseLanguageType(name string) (LanguageType, error) {
+	for i, languageName := range languageNames {
+		if name == languageName {
+			return LanguageType(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid language %q", name)
+}
+
 type VoiceType int
 
 const (
@@ -18,10 +47,176 @@ const (
 	Casual
 )
 
+var voiceNames = []string{"professional", "friendly", "casual"}
+
+// String returns the API name of a voice type, e.g. "friendly".
+func (v VoiceType) String() string {
+	if v < 0 || int(v) >= len(voiceNames) {
+		return fmt.Sprintf("VoiceType(%d)", int(v))
+	}
+	return voiceNames[v]
+}
+
+// ParseVoiceType parses the API name of a voice type.
+func ParseVoiceType(name string) (VoiceType, error) {
+	for i, voiceName := range voiceNames {
+		if name == voiceName {
+			return VoiceType(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid voice type %q", name)
+}
+
+// AgentScript holds the scripts of an agent profile in one language, and
+// words telling that a caller speaks it.
+type AgentScript struct {
+	Language       LanguageType `json:"language"`
+	InitialScript  string       `json:"initialScript"`
+	ClosingScript  string       `json:"closingScript"`
+	DetectionHints []string     `json:"detectionHints,omitempty"`
+}
+
+// Complete tells whether both scripts are written.
+func (s AgentScript) Complete() bool {
+	return s.InitialScript != "" && s.ClosingScript != ""
+}
+
+func (s AgentScript) equal(other AgentScript) bool {
+	return s.Language == other.Language && s.InitialScript == other.InitialScript &&
+		s.ClosingScript == other.ClosingScript && slices.Equal(s.DetectionHints, other.DetectionHints)
+}
+
+// AgentScripts is stored as a JSON array.
+type AgentScripts []AgentScript
+
+func (s AgentScripts) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+func (s *AgentScripts) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(data, s)
+	case string:
+		return json.Unmarshal([]byte(data), s)
+	}
+	return fmt.Errorf("cannot scan %T into AgentScripts", value)
+}
+
+// AgentProfile configures how the voice agent of an organization speaks. The
+// profile without location is the organization default; a location may have
+// its own profile replacing it.
+//
+// Language is the default language, with the scripts of the profile. The
+// agent also speaks every language of Translations.
+//
+// Its fields hold the draft being edited. The agent only uses what has been
+// published, kept as AgentProfileVersion snapshots.
 type AgentProfile struct {
-	Id            uuid.UUID
-	Language      LanguageType
-	InitialScript string
-	ClosingScript string
-	VoiceType     string
+	Base
+	OrganizationID   uuid.UUID      `gorm:"type:uuid;index;not null" json:"organizationId"`
+	LocationID       *uuid.UUID     `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	Language         LanguageType   `gorm:"not null;default:0" json:"language"`
+	InitialScript    string         `json:"initialScript"`
+	ClosingScript    string         `json:"closingScript"`
+	DetectionHints   pq.StringArray `gorm:"type:text[]" json:"detectionHints"`
+	Translations     AgentScripts   `gorm:"type:jsonb;not null;default:'[]'" json:"translations"`
+	VoiceType        VoiceType      `gorm:"not null;default:0" json:"voiceType"`
+	PublishedVersion int            `gorm:"not null;default:0" json:"publishedVersion"` // 0 until first published
+}
+
+// DefaultScript returns the scripts of the default language.
+func (p AgentProfile) DefaultScript() AgentScript {
+	return AgentScript{
+		Language:       p.Language,
+		InitialScript:  p.InitialScript,
+		ClosingScript:  p.ClosingScript,
+		DetectionHints: p.DetectionHints,
+	}
+}
+
+// Scripts returns the scripts of every language, the default one first.
+func (p AgentProfile) Scripts() []AgentScript {
+	return append([]AgentScript{p.DefaultScript()}, p.Translations...)
+}
+
+// ScriptFor returns the scripts of a language. A script missing in that
+// language falls back to the default language, which is then reported.
+func (p AgentProfile) ScriptFor(language LanguageType) (AgentScript, bool) {
+	fallback := p.DefaultScript()
+	if language == p.Language {
+		return fallback, false
+	}
+	for _, translation := range p.Translations {
+		if translation.Language != language {
+			continue
+		}
+		usedFallback := false
+		if translation.InitialScript == "" {
+			translation.InitialScript = fallback.InitialScript
+			usedFallback = true
+		}
+		if translation.ClosingScript == "" {
+			translation.ClosingScript = fallback.ClosingScript
+			usedFallback = true
+		}
+		return translation, usedFallback
+	}
+	fallback.DetectionHints = nil
+	return fallback, true
+}
+
+// SameContent tells whether two profiles make the agent speak the same way.
+func (p AgentProfile) SameContent(other AgentProfile) bool {
+	return p.VoiceType == other.VoiceType && p.DefaultScript().equal(other.DefaultScript()) &&
+		slices.EqualFunc(p.Translations, other.Translations, AgentScript.equal)
+}
+
+type VersionStatus string
+
+const (
+	VersionPending    VersionStatus = "pending_approval"
+	VersionPublished  VersionStatus = "published"
+	VersionSuperseded VersionStatus = "superseded"
+	VersionRejected   VersionStatus = "rejected"
+)
+
+// AgentProfileVersion is an immutable snapshot of an agent profile submitted
+// for publication. Versions are numbered per profile; at most one is
+// published and at most one awaits approval.
+type AgentProfileVersion struct {
+	Base
+	ProfileID      uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_agent_profile_version" json:"profileId"`
+	Version        int            `gorm:"not null;uniqueIndex:idx_agent_profile_version" json:"version"`
+	Status         VersionStatus  `gorm:"not null;index" json:"status"`
+	Language       LanguageType   `gorm:"not null" json:"language"`
+	VoiceType      VoiceType      `gorm:"not null" json:"voiceType"`
+	InitialScript  string         `json:"initialScript"`
+	ClosingScript  string         `json:"closingScript"`
+	DetectionHints pq.StringArray `gorm:"type:text[]" json:"detectionHints"`
+	Translations   AgentScripts   `gorm:"type:jsonb;not null;default:'[]'" json:"translations"`
+	Note           string         `json:"note"`
+	RolledBackFrom *int           `json:"rolledBackFrom,omitempty"`
+	SubmittedByID  *uuid.UUID     `gorm:"type:uuid" json:"submittedById,omitempty"`
+	ReviewedByID   *uuid.UUID     `gorm:"type:uuid" json:"reviewedById,omitempty"`
+	ReviewedAt     *time.Time     `json:"reviewedAt,omitempty"`
+	PublishedAt    *time.Time     `json:"publishedAt,omitempty"`
+}
+
+// Content copies the published content of a version into a profile.
+func (v AgentProfileVersion) Content(profile AgentProfile) AgentProfile {
+	profile.Language = v.Language
+	profile.VoiceType = v.VoiceType
+	profile.InitialScript = v.InitialScript
+	profile.ClosingScript = v.ClosingScript
+	profile.DetectionHints = v.DetectionHints
+	profile.Translations = v.Translations
+	return profile
 }
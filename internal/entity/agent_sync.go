@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SyncStatus string
+
+const (
+	SyncPending SyncStatus = "pending"
+	SyncSynced  SyncStatus = "synced"
+	SyncFailed  SyncStatus = "failed"
+)
+
+// AgentSync tracks the sync of the agent configuration of an organization to
+// the voice platform. A pending sync is retried with a backoff until it
+// succeeds or runs out of attempts.
+type AgentSync struct {
+	Base
+	OrganizationID uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null" json:"organizationId"`
+	Platform       string     `gorm:"not null" json:"platform"`
+	Status         SyncStatus `gorm:"not null;index" json:"status"`
+	Checksum       string     `json:"checksum"` // of the configuration last synced
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	LastError      string     `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time `gorm:"index" json:"nextAttemptAt,omitempty"`
+	RequestedAt    time.Time  `json:"requestedAt"`
+	SyncedAt       *time.Time `json:"syncedAt,omitempty"`
+}
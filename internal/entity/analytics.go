@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// RollupBucket is the span of a rollup row. Every time zone offset is a
+// multiple of it, so local hours, days and weeks are made of whole buckets.
+const RollupBucket = 15 * time.Minute
+
+// CallDurationBins are the lower bounds in seconds of the bins of a duration
+// histogram. The last bin is unbounded.
+var CallDurationBins = []int{0, 15, 30, 60, 120, 180, 300, 600, 900, 1800}
+
+// CallRollup aggregates the calls of an organization and location started
+// within a bucket. It is derived from the calls and rebuilt whenever one of
+// them changes.
+type CallRollup struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index:idx_call_rollup_bucket" json:"organizationId"`
+	LocationID     *uuid.UUID `gorm:"type:uuid" json:"locationId,omitempty"`
+	BucketStart    time.Time  `gorm:"not null;index:idx_call_rollup_bucket" json:"bucketStart"`
+	Calls          int        `gorm:"not null" json:"calls"`
+	Completed      int        `gorm:"not null" json:"completed"`
+	Missed         int        `gorm:"not null" json:"missed"`
+	Forwarded      int        `gorm:"not null" json:"forwarded"`
+	Voicemail      int        `gorm:"not null" json:"voicemail"`
+	Transferred    int        `gorm:"not null" json:"transferred"`
+	AfterHours     int        `gorm:"not null" json:"afterHours"`
+	// NewCallers counts the first calls of callers to the organization, and
+	// ReturningCalls the calls of callers who called before
+	NewCallers     int   `gorm:"not null" json:"newCallers"`
+	ReturningCalls int   `gorm:"not null" json:"returningCalls"`
+	TotalDuration  int64 `gorm:"not null" json:"totalDuration"`
+	MaxDuration    int   `gorm:"not null" json:"maxDuration"`
+	// DurationHistogram counts the calls per bin of CallDurationBins
+	DurationHistogram pq.Int64Array `gorm:"type:bigint[];not null" json:"durationHistogram"`
+}
+
+// CallTagRollup counts the calls with a tag of an organization and location
+// started within a bucket.
+type CallTagRollup struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index:idx_call_tag_rollup_bucket" json:"organizationId"`
+	LocationID     *uuid.UUID `gorm:"type:uuid" json:"locationId,omitempty"`
+	BucketStart    time.Time  `gorm:"not null;index:idx_call_tag_rollup_bucket" json:"bucketStart"`
+	Tag            string     `gorm:"not null" json:"tag"`
+	Calls          int        `gorm:"not null" json:"calls"`
+}
+
+// CallDispositionRollup counts the calls with a disposition of an
+// organization and location started within a bucket.
+type CallDispositionRollup struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null;index:idx_call_disposition_rollup_bucket" json:"organizationId"`
+	LocationID     *uuid.UUID `gorm:"type:uuid" json:"locationId,omitempty"`
+	BucketStart    time.Time  `gorm:"not null;index:idx_call_disposition_rollup_bucket" json:"bucketStart"`
+	Disposition    string     `gorm:"size:50;not null" json:"disposition"`
+	Calls          int        `gorm:"not null" json:"calls"`
+}
+
+// MovedCall is where a call was before its start or caller number changed. It
+// is kept until the next refresh of the rollups rebuilds the ones the call
+// left.
+type MovedCall struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CallerNumber   string    `gorm:"primaryKey"`
+	StartedAt      time.Time `gorm:"primaryKey"`
+}
+
+// RollupWatermark records up to when the changes of a source table were
+// rolled up.
+type RollupWatermark struct {
+	Name             string    `gorm:"primaryKey;size:100"`
+	RefreshedThrough time.Time `gorm:"not null"`
+}
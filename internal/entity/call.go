@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Outcomes of a call.
+const (
+	CallCompleted = "completed"
+	CallMissed    = "missed"
+	CallForwarded = "forwarded"
+	CallVoicemail = "voicemail"
+)
+
+// Speakers of a transcript turn.
+const (
+	SpeakerAgent  = "agent"
+	SpeakerCaller = "caller"
+)
+
+// Call is a call handled by the voice agent, as reported by the voice
+// platform. ExternalCallID is the id of the call on the platform; reports
+// about the same call update it. StartedAt falls back to when the call was
+// reported, so that every call can be sorted by it. AfterHours tells
+// whether it started outside of the opening hours of the location called.
+// Disposition, the code of a CallDisposition, Tags and Notes are set by the
+// staff. ContactID is the Contact of the caller number.
+type Call struct {
+	Base
+	OrganizationID  uuid.UUID        `gorm:"type:uuid;index;not null" json:"organizationId"`
+	LocationID      *uuid.UUID       `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	ExternalCallID  string           `gorm:"uniqueIndex;not null" json:"externalCallId"`
+	Platform        string           `gorm:"not null" json:"platform"`
+	CallerNumber    string           `gorm:"index" json:"callerNumber"`
+	ContactID       *uuid.UUID       `gorm:"type:uuid;index" json:"contactId,omitempty"`
+	Contact         *Contact         `gorm:"foreignKey:ContactID" json:"contact,omitempty"`
+	CalledNumber    string           `json:"calledNumber"`
+	Status          string           `gorm:"size:20;not null;index" json:"status"`
+	EndedReason     string           `json:"endedReason"`
+	StartedAt       time.Time        `gorm:"index;not null" json:"startedAt"`
+	EndedAt         *time.Time       `json:"endedAt,omitempty"`
+	DurationSeconds int              `gorm:"not null;default:0" json:"durationSeconds"`
+	Summary         string           `json:"summary"`
+	TransferredTo   string           `json:"transferredTo"`
+	AfterHours      bool             `gorm:"not null;default:false" json:"afterHours"`
+	ReportedAt      time.Time        `json:"reportedAt"` // of the report the call was last updated from
+	Disposition     string           `gorm:"size:50;not null;default:'';index" json:"disposition"`
+	Tags            pq.StringArray   `gorm:"type:text[];not null;default:'{}'" json:"tags"`
+	Transcript      []TranscriptTurn `gorm:"foreignKey:CallID" json:"transcript,omitempty"`
+	Notes           []CallNote       `gorm:"foreignKey:CallID" json:"notes,omitempty"`
+}
+
+// Transferred tells whether the agent handed the call over to a phone number.
+// A call forwarded without a number to hand it over to is not transferred.
+func (c Call) Transferred() bool {
+	return c.TransferredTo != ""
+}
+
+// TranscriptTurn is what the agent or the caller said during a call.
+type TranscriptTurn struct {
+	Base
+	CallID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_transcript_turn" json:"callId"`
+	Position int       `gorm:"not null;uniqueIndex:idx_transcript_turn" json:"position"`
+	Speaker  string    `gorm:"size:10;not null" json:"speaker"`
+	Text     string    `gorm:"not null" json:"text"`
+	// OffsetMillis is the time elapsed since the call started
+	OffsetMillis int64 `gorm:"not null;default:0" json:"offsetMillis"`
+	// SearchVector indexes Text for full-text search in English and French.
+	// It is computed by the database when the turn is stored.
+	SearchVector string `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('english', text) || to_tsvector('french', text)) STORED;index:idx_transcript_turn_search,type:gin" json:"-"`
+}
+
+type CallEventStatus string
+
+const (
+	CallEventPending   CallEventStatus = "pending"
+	CallEventProcessed CallEventStatus = "processed"
+	CallEventFailed    CallEventStatus = "failed"
+)
+
+// CallEvent is a report received from the voice platform, kept until it is
+// processed in the background. Digest identifies its content, so a replayed
+// report is only stored once.
+type CallEvent struct {
+	Base
+	OrganizationID uuid.UUID       `gorm:"type:uuid;index;not null" json:"organizationId"`
+	Digest         string          `gorm:"uniqueIndex;not null" json:"digest"`
+	Type           string          `gorm:"not null" json:"type"`
+	ExternalCallID string          `gorm:"index;not null" json:"externalCallId"`
+	OccurredAt     time.Time       `json:"occurredAt"`
+	Payload        string          `gorm:"type:jsonb;not null" json:"payload"`
+	Status         CallEventStatus `gorm:"not null;index" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	LastError      string          `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time      `gorm:"index" json:"nextAttemptAt,omitempty"`
+	ProcessedAt    *time.Time      `json:"processedAt,omitempty"`
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCallDispositions are given to an organization that has none.
+var DefaultCallDispositions = []CallDisposition{
+	{Code: "booked", Label: "Booked"},
+	{Code: "callback_needed", Label: "Callback needed"},
+	{Code: "spam", Label: "Spam"},
+	{Code: "resolved", Label: "Resolved"},
+}
+
+// CallDisposition is what can happen after a call, as configured by an
+// organization. Calls refer to it by Code, which never changes; an archived
+// disposition stays on the calls it was set on but can no longer be set.
+type CallDisposition struct {
+	Base
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_call_disposition" json:"organizationId"`
+	Code           string    `gorm:"size:50;not null;uniqueIndex:idx_call_disposition" json:"code"`
+	Label          string    `gorm:"not null" json:"label"`
+	Position       int       `gorm:"not null;default:0" json:"position"`
+	Archived       bool      `gorm:"not null;default:false" json:"archived"`
+}
+
+// CallTag is a tag an organization can set on its calls. Calls refer to it by
+// Name.
+type CallTag struct {
+	Base
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_call_tag" json:"organizationId"`
+	Name           string    `gorm:"size:50;not null;uniqueIndex:idx_call_tag" json:"name"`
+}
+
+// CallNote is a note left by a staff member on a call.
+type CallNote struct {
+	Base
+	CallID   uuid.UUID  `gorm:"type:uuid;index;not null" json:"callId"`
+	AuthorID uuid.UUID  `gorm:"type:uuid;not null" json:"authorId"`
+	Author   *User      `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Body     string     `gorm:"not null" json:"body"`
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+}
+
+// Fields of a call the staff edit.
+const (
+	CallFieldDisposition = "disposition"
+	CallFieldTags        = "tags"
+	CallFieldNote        = "note"
+)
+
+// CallChange is an edit of a call by a staff member. Previous and Current are
+// the values before and after it; a note added has no previous value and a
+// note deleted no current one.
+type CallChange struct {
+	Base
+	CallID   uuid.UUID  `gorm:"type:uuid;index;not null" json:"callId"`
+	UserID   uuid.UUID  `gorm:"type:uuid;not null" json:"userId"`
+	User     *User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Field    string     `gorm:"size:20;not null" json:"field"`
+	NoteID   *uuid.UUID `gorm:"type:uuid" json:"noteId,omitempty"`
+	Previous string     `json:"previous"`
+	Current  string     `json:"current"`
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// DefaultCallbackRules are given to an organization that has none.
+var DefaultCallbackRules = []CallbackRule{
+	{
+		Name:       "Missed call",
+		Enabled:    true,
+		Statuses:   pq.StringArray{CallMissed, CallVoicemail},
+		Reason:     "The call could not be completed",
+		DueMinutes: 60,
+	},
+	{
+		Name:       "Asked for a person",
+		Position:   1,
+		Enabled:    true,
+		Keywords:   pq.StringArray{"call me back", "call back", "speak to someone", "real person", "human"},
+		Reason:     "The caller asked to speak to someone",
+		DueMinutes: 120,
+	},
+}
+
+// CallbackRule tells which ingested calls need a callback. A call matches
+// when it has one of Statuses, ended for one of EndReasons (part of the
+// reason of the platform), and the caller or the summary says one of
+// Keywords; empty lists match any call. AfterHours and Transferred, when
+// set, narrow it further. The first enabled rule matching a call, by
+// Position, creates its task, due DueMinutes of opening hours after the
+// call, and assigned to AssigneeID when set. Names are unique within an
+// organization.
+type CallbackRule struct {
+	Base
+	OrganizationID uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_callback_rule" json:"organizationId"`
+	Name           string         `gorm:"not null;uniqueIndex:idx_callback_rule" json:"name"`
+	Position       int            `gorm:"not null;default:0" json:"position"`
+	Enabled        bool           `gorm:"not null" json:"enabled"`
+	Statuses       pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"statuses"`
+	EndReasons     pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"endReasons"`
+	Keywords       pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"keywords"`
+	AfterHours     *bool          `json:"afterHours,omitempty"`
+	Transferred    *bool          `json:"transferred,omitempty"`
+	Reason         string         `gorm:"not null;default:''" json:"reason"`
+	DueMinutes     int            `gorm:"not null" json:"dueMinutes"`
+	AssigneeID     *uuid.UUID     `gorm:"type:uuid" json:"assigneeId,omitempty"`
+}
+
+type CallbackStatus string
+
+const (
+	CallbackOpen       CallbackStatus = "open"
+	CallbackInProgress CallbackStatus = "in_progress"
+	CallbackCompleted  CallbackStatus = "completed"
+	CallbackCancelled  CallbackStatus = "cancelled"
+)
+
+// CallbackTransitions lists the statuses a task can move to from each status.
+var CallbackTransitions = map[CallbackStatus][]CallbackStatus{
+	CallbackOpen:       {CallbackInProgress, CallbackCompleted, CallbackCancelled},
+	CallbackInProgress: {CallbackOpen, CallbackCompleted, CallbackCancelled},
+	CallbackCompleted:  {CallbackOpen},
+	CallbackCancelled:  {CallbackOpen},
+}
+
+// Active tells whether a task still has to be done.
+func (s CallbackStatus) Active() bool {
+	return s == CallbackOpen || s == CallbackInProgress
+}
+
+// CallbackTask is a call the staff have to return. There is at most one per
+// call. BreachedAt is when it was found still active past DueAt; it stays
+// set once the task is closed.
+type CallbackTask struct {
+	Base
+	OrganizationID uuid.UUID      `gorm:"type:uuid;index;not null" json:"organizationId"`
+	LocationID     *uuid.UUID     `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	CallID         uuid.UUID      `gorm:"type:uuid;uniqueIndex;not null" json:"callId"`
+	RuleID         *uuid.UUID     `gorm:"type:uuid" json:"ruleId,omitempty"`
+	CallerNumber   string         `gorm:"index;not null" json:"callerNumber"`
+	Reason         string         `gorm:"not null;default:''" json:"reason"`
+	Status         CallbackStatus `gorm:"size:20;not null;index" json:"status"`
+	AssigneeID     *uuid.UUID     `gorm:"type:uuid;index" json:"assigneeId,omitempty"`
+	Assignee       *User          `gorm:"foreignKey:AssigneeID" json:"assignee,omitempty"`
+	DueAt          time.Time      `gorm:"index;not null" json:"dueAt"`
+	BreachedAt     *time.Time     `json:"breachedAt,omitempty"`
+	CompletedAt    *time.Time     `json:"completedAt,omitempty"`
+	CompletedByID  *uuid.UUID     `gorm:"type:uuid" json:"completedById,omitempty"`
+	Resolution     string         `gorm:"not null;default:''" json:"resolution"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// CatalogVersion records the version of a bundled reference data file last
+// synchronized into the database.
+type CatalogVersion struct {
+	Name      string    `gorm:"primaryKey;size:100"`
+	Version   int       `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
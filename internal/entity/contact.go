@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// Contact is a caller of an organization, known by the phone numbers they
+// called from. Calls are linked to the contact of their normalized caller
+// number; staff can merge contacts that are the same person and split off
+// numbers that are not. VIP callers get special care from the voice agent,
+// while Blocked ones are not served.
+type Contact struct {
+	Base
+	OrganizationID    uuid.UUID      `gorm:"type:uuid;index;not null" json:"organizationId"`
+	Name              string         `gorm:"not null;default:''" json:"name"`
+	Notes             string         `gorm:"not null;default:''" json:"notes"`
+	PreferredLanguage *LanguageType  `json:"preferredLanguage,omitempty"`
+	VIP               bool           `gorm:"not null;default:false" json:"vip"`
+	Blocked           bool           `gorm:"not null;default:false" json:"blocked"`
+	Phones            []ContactPhone `gorm:"foreignKey:ContactID" json:"phones,omitempty"`
+}
+
+// ContactPhone is a phone number of a contact, normalized to E.164 when it
+// has enough digits. A number belongs to a single contact per organization.
+type ContactPhone struct {
+	Base
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_contact_phone" json:"organizationId"`
+	ContactID      uuid.UUID `gorm:"type:uuid;index;not null" json:"contactId"`
+	Number         string    `gorm:"size:20;not null;uniqueIndex:idx_contact_phone" json:"number"`
+}
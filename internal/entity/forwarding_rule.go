@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Caller intents a forwarding rule can match.
+const (
+	IntentEmergency       = "emergency"
+	IntentBilling         = "billing"
+	IntentNewPatient      = "new_patient"
+	IntentExistingPatient = "existing_patient"
+	IntentAppointment     = "appointment"
+	IntentGeneral         = "general"
+)
+
+// When a forwarding rule applies relative to the opening hours.
+const (
+	ForwardingAnyTime = "any"
+	ForwardingOpen    = "open"
+	ForwardingClosed  = "closed"
+)
+
+// What a forwarding rule does with a call.
+const (
+	ForwardingActionForward   = "forward"
+	ForwardingActionVoicemail = "voicemail"
+)
+
+// ForwardingRule decides where the voice agent transfers a call. Rules are
+// evaluated by ascending Priority and the first one matching the call wins.
+// Empty conditions match every call.
+type ForwardingRule struct {
+	Base
+	OrganizationID uuid.UUID  `gorm:"type:uuid;index;not null" json:"organizationId"`
+	LocationID     *uuid.UUID `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	Name           string     `json:"name"`
+	Priority       int        `gorm:"not null;default:0" json:"priority"`
+	Enabled        bool       `gorm:"not null;default:true" json:"enabled"`
+	// Intents restricts the rule to calls with one of these intents.
+	Intents pq.StringArray `gorm:"type:text[]" json:"intents"`
+	// Schedule is "any", "open" or "closed", against the opening hours of
+	// the location, or of the organization for calls without location.
+	Schedule string `gorm:"size:10;not null;default:'any'" json:"schedule"`
+	// Weekdays, FromTime and ToTime further restrict the rule to a local
+	// wall-clock window.
+	Weekdays pq.Int64Array `gorm:"type:integer[]" json:"weekdays"`
+	FromTime string        `gorm:"type:varchar(5)" json:"fromTime"`
+	ToTime   string        `gorm:"type:varchar(5)" json:"toTime"`
+	Action   string        `gorm:"size:20;not null" json:"action"`
+	// TargetUserID forwards to a staff member's phone while they are working;
+	// TargetNumber forwards to a fixed number.
+	TargetNumber string     `json:"targetNumber"`
+	TargetUserID *uuid.UUID `gorm:"type:uuid" json:"targetUserId,omitempty"`
+	// FallbackNumber is used when the staff member is not available. Without
+	// it, evaluation moves on to the next rule.
+	FallbackNumber string `json:"fallbackNumber"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// InsuranceCarrier is an entry of the insurance carrier catalog. Aliases hold
+// the other names callers use for the carrier, e.g. "Great-West Life" for
+// Canada Life.
+type InsuranceCarrier struct {
+	ID        int            `json:"id"`
+	Code      string         `gorm:"uniqueIndex;size:100" json:"code"`
+	Name      string         `gorm:"not null" json:"name"`
+	Aliases   pq.StringArray `gorm:"type:text[]" json:"aliases"`
+	PlanTypes pq.StringArray `gorm:"type:text[]" json:"planTypes"`
+	Retired   bool           `gorm:"not null;default:false" json:"retired"`
+}
+
+// OrganizationInsurance tells whether an organization, or one of its
+// locations when LocationID is set, accepts an insurance carrier. An empty
+// PlanTypes means every plan type of the carrier.
+type OrganizationInsurance struct {
+	Base
+	OrganizationID uuid.UUID        `gorm:"type:uuid;index;not null" json:"organizationId"`
+	LocationID     *uuid.UUID       `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	CarrierID      int              `gorm:"index;not null" json:"carrierId"`
+	Carrier        InsuranceCarrier `gorm:"foreignKey:CarrierID" json:"carrier"`
+	Accepted       bool             `gorm:"not null;default:true" json:"accepted"`
+	PlanTypes      pq.StringArray   `gorm:"type:text[]" json:"planTypes"`
+	Notes          string           `json:"notes"`
+}
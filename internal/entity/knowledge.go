@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Kinds of knowledge base entries.
+const (
+	KnowledgeFAQ     = "faq"
+	KnowledgeArticle = "article"
+)
+
+// KnowledgeEntry is a question and answer, or a free-form article, the voice
+// agent may use to answer callers, e.g. about parking or payment plans.
+// Entries are listed by ascending Position; disabled ones are hidden from the
+// agent.
+type KnowledgeEntry struct {
+	Base
+	OrganizationID uuid.UUID          `gorm:"type:uuid;index;not null" json:"organizationId"`
+	Kind           string             `gorm:"size:10;not null" json:"kind"`
+	Category       string             `gorm:"index" json:"category"`
+	Enabled        bool               `gorm:"not null;default:true" json:"enabled"`
+	Position       int                `gorm:"not null;default:0" json:"position"`
+	Variants       []KnowledgeVariant `gorm:"foreignKey:EntryID" json:"variants,omitempty"`
+}
+
+// Variant returns the variant of an entry in a language. Without one, it
+// falls back to the first variant, which is then reported.
+func (e KnowledgeEntry) Variant(language LanguageType) (KnowledgeVariant, bool) {
+	for _, variant := range e.Variants {
+		if variant.Language == language {
+			return variant, false
+		}
+	}
+	if len(e.Variants) == 0 {
+		return KnowledgeVariant{}, true
+	}
+	return e.Variants[0], true
+}
+
+// KnowledgeVariant is the text of a knowledge base entry in one language. For
+// a question and answer, Title is the question and Body the answer.
+type KnowledgeVariant struct {
+	Base
+	EntryID  uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_knowledge_variant" json:"entryId"`
+	Language LanguageType   `gorm:"not null;uniqueIndex:idx_knowledge_variant" json:"language"`
+	Title    string         `gorm:"not null" json:"title"`
+	Body     string         `gorm:"not null" json:"body"`
+	Keywords pq.StringArray `gorm:"type:text[]" json:"keywords"`
+}
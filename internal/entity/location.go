@@ -0,0 +1,27 @@
+package entity
+
+import "github.com/google/uuid"
+
+// DefaultLocationName is used for the location created alongside a new organization.
+const DefaultLocationName = "Main Office"
+
+// Location is a physical office of an organization. Dental groups usually run
+// several offices, each with its own phone line, hours and services.
+type Location struct {
+	Base
+	OrganizationID       uuid.UUID       `gorm:"type:uuid;index;not null" json:"organizationId"`
+	Name                 string          `json:"name"`
+	IsDefault            bool            `gorm:"not null;default:false" json:"isDefault"`
+	AddressLine1         string          `json:"addressLine1"`
+	AddressLine2         string          `json:"addressLine2"`
+	City                 string          `json:"city"`
+	Province             string          `json:"province"`
+	PostalCode           string          `json:"postalCode"`
+	Country              string          `json:"country"`
+	PhoneNumber          string          `json:"phoneNumber"`
+	CallForwardingNumber string          `json:"callForwardingNumber"`
+	TimeZone             string          `json:"timeZone"`
+	Specialities         []Speciality    `gorm:"many2many:location_specialities;" json:"specialities,omitempty"`
+	DailySchedules       []DailySchedule `gorm:"many2many:location_daily_schedules;" json:"dailySchedules,omitempty"`
+	Users                []User          `gorm:"many2many:user_locations;" json:"users,omitempty"`
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// String returns the name of a notification channel.
+func (t NotificationType) String() string {
+	switch t {
+	case Email:
+		return "email"
+	case SMS:
+		return "sms"
+	case InApp:
+		return "in_app"
+	}
+	return "unknown"
+}
+
+// NotificationTypes lists every notification channel.
+var NotificationTypes = []NotificationType{Email, SMS, InApp}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliveryFailed  DeliveryStatus = "failed"
+	DeliverySkipped DeliveryStatus = "skipped"
+)
+
+// Notification is a message shown in the in-app inbox of a user.
+type Notification struct {
+	Base
+	OrganizationID uuid.UUID  `gorm:"type:uuid;index;not null" json:"organizationId"`
+	UserID         uuid.UUID  `gorm:"type:uuid;index;not null" json:"userId"`
+	Event          string     `gorm:"size:100" json:"event"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	ReadAt         *time.Time `json:"readAt,omitempty"`
+}
+
+// NotificationPreference overrides the organization notification channels for
+// a user. An empty Event applies to every event; a nil channel inherits the
+// less specific setting.
+type NotificationPreference struct {
+	Base
+	UserID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_notification_preference;not null" json:"userId"`
+	Event  string    `gorm:"size:100;uniqueIndex:idx_notification_preference" json:"event"`
+	Email  *bool     `json:"email"`
+	SMS    *bool     `json:"sms"`
+	InApp  *bool     `json:"inApp"`
+}
+
+// Enabled tells whether the preference turns a channel on or off, and whether
+// it says anything about it at all.
+func (p NotificationPreference) Enabled(channel NotificationType) (bool, bool) {
+	var value *bool
+	switch channel {
+	case Email:
+		value = p.Email
+	case SMS:
+		value = p.SMS
+	case InApp:
+		value = p.InApp
+	}
+	if value == nil {
+		return false, false
+	}
+	return *value, true
+}
+
+// NotificationDelivery logs the delivery of a notification to one recipient
+// on one channel, and drives its retries.
+type NotificationDelivery struct {
+	Base
+	OrganizationID uuid.UUID        `gorm:"type:uuid;index;not null" json:"organizationId"`
+	UserID         *uuid.UUID       `gorm:"type:uuid;index" json:"userId,omitempty"`
+	Event          string           `gorm:"size:100" json:"event"`
+	Channel        NotificationType `gorm:"not null" json:"channel"`
+	Recipient      string           `json:"recipient"`
+	Subject        string           `json:"subject"`
+	Body           string           `json:"body"`
+	Status         DeliveryStatus   `gorm:"size:20;index;not null" json:"status"`
+	Attempts       int              `gorm:"not null;default:0" json:"attempts"`
+	LastError      string           `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time       `gorm:"index" json:"nextAttemptAt,omitempty"`
+	SentAt         *time.Time       `json:"sentAt,omitempty"`
+}
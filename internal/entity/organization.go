@@ -1,5 +1,8 @@
 package entity
 
+// DefaultTimeZone is used for organizations that did not configure one.
+const DefaultTimeZone = "America/Toronto"
+
 type NotificationType int
 
 const (
@@ -10,14 +13,20 @@ const (
 
 type Organization struct {
 	Base
-	Email                string
-	Name                 string
-	Website              string
-	PhoneNumber          string
-	InsuranceCompany     string             `json:"insuranceCompany"`
-	Specialities         []Speciality       `gorm:"many2many:organization_specialities;" json:"specialities,omitempty"`
-	DailySchedules       []DailySchedule    `gorm:"many2many:organization_dailey_schedules;" json:"daileySchedules,omitempty"`
-	Notifications        []NotificationType `gorm:"type:integer[]" json:"notifications,omitempty"`
-	CallForwardingNumber string
-	Users                []User `gorm:"foreignKey:OrganizationID" json:"users,omitempty"`
+	Email                 string
+	Name                  string
+	Website               string
+	PhoneNumber           string
+	TimeZone              string             `gorm:"not null;default:'America/Toronto'" json:"timeZone"`
+	InsuranceCompany      string             `json:"insuranceCompany"` // Deprecated: superseded by OrganizationInsurance
+	Specialities          []Speciality       `gorm:"many2many:organization_specialities;" json:"specialities,omitempty"`
+	DailySchedules        []DailySchedule    `gorm:"many2many:organization_dailey_schedules;" json:"daileySchedules,omitempty"`
+	Notifications         []NotificationType `gorm:"type:integer[]" json:"notifications,omitempty"`
+	CallForwardingNumber  string
+	AgentApprovalRequired bool       `gorm:"not null;default:false" json:"agentApprovalRequired"` // publishing agent profiles needs an Admin approval
+	VoiceAssistantID      string     `json:"voiceAssistantId"`                                    // assistant of the organization on the voice platform
+	WebhookSecret         string     `json:"-"`                                                   // signs the call reports of the voice platform
+	AgentKeyHash          string     `json:"-"`                                                   // SHA-256 of the API key of the voice agent
+	Users                 []User     `gorm:"foreignKey:OrganizationID" json:"users,omitempty"`
+	Locations             []Location `gorm:"foreignKey:OrganizationID" json:"locations,omitempty"`
 }
@@ -1,14 +1,15 @@
 package entity
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 )
 
+// DailySchedule is an opening range on a given day of the week. FromTime and
+// ToTime are local wall-clock times ("15:04") interpreted in the time zone of
+// the organization or location owning the schedule.
 type DailySchedule struct {
-	ID        uuid.UUID
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
 	DayOfWeek int
-	FromTime  time.Time
-	ToTime    time.Time
+	FromTime  string `gorm:"type:varchar(5)"`
+	ToTime    string `gorm:"type:varchar(5)"`
 }
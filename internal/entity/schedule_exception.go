@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleException overrides the weekly schedule on a single date, either
+// closing all day or replacing the opening hours. Exceptions without location
+// and user apply to the whole organization.
+type ScheduleException struct {
+	Base
+	OrganizationID uuid.UUID  `gorm:"type:uuid;index;not null" json:"organizationId"`
+	LocationID     *uuid.UUID `gorm:"type:uuid;index" json:"locationId,omitempty"`
+	UserID         *uuid.UUID `gorm:"type:uuid;index" json:"userId,omitempty"`
+	Date           time.Time  `gorm:"type:date;index;not null" json:"date"`
+	Closed         bool       `gorm:"not null;default:false" json:"closed"`
+	FromTime       string     `gorm:"type:varchar(5)" json:"fromTime,omitempty"`
+	ToTime         string     `gorm:"type:varchar(5)" json:"toTime,omitempty"`
+	Reason         string     `json:"reason"`
+	HolidayCode    string     `gorm:"index" json:"holidayCode,omitempty"`
+}
@@ -1,9 +1,14 @@
 package entity
 
+// Speciality is a node of the speciality taxonomy. Code is stable across
+// environments, unlike the serial ID. Retired specialities are hidden from
+// pickers but kept so organizations referencing them are not broken.
 type Speciality struct {
 	ID              int          `json:"id"`
+	Code            string       `gorm:"uniqueIndex;size:100" json:"code"`
 	Name            string       `json:"name"`
 	ParentID        *int         `gorm:"index" json:"parent_id,omitempty"`
 	Parent          *Speciality  `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
 	SubSpecialities []Speciality `gorm:"foreignKey:ParentID" json:"subSpecialities,omitempty"`
+	Retired         bool         `gorm:"not null;default:false" json:"retired"`
 }
@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
 
 type UserRole int
 
@@ -20,4 +24,19 @@ type User struct {
 	DailySchedules []DailySchedule `gorm:"many2many:user_dailey_schedules;" json:"daileySchedules,omitempty"`
 	OrganizationID uuid.UUID       `gorm:"type:uuid"`
 	Organization   Organization
+	Locations      []Location `gorm:"many2many:user_locations;" json:"locations,omitempty"`
+}
+
+// DisplayName is the full name of a user, or their email when they have none.
+func (u User) DisplayName() string {
+	var parts []string
+	for _, part := range []*string{u.Name, u.Family} {
+		if part != nil && strings.TrimSpace(*part) != "" {
+			parts = append(parts, strings.TrimSpace(*part))
+		}
+	}
+	if len(parts) == 0 && u.Email != nil {
+		return *u.Email
+	}
+	return strings.Join(parts, " ")
 }
@@ -1,7 +1,12 @@
 package errors
 
 import (
+	stderrors "errors"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // ErrorResponse is the response that represents an error.
@@ -32,6 +37,17 @@ func InternalServerError(msg string) ErrorResponse {
 	}
 }
 
+// ServiceUnavailable creates a new error response representing a temporarily unavailable service (HTTP 503)
+func ServiceUnavailable(msg string) ErrorResponse {
+	if msg == "" {
+		msg = "The service is temporarily unavailable."
+	}
+	return ErrorResponse{
+		Status:  http.StatusServiceUnavailable,
+		Message: msg,
+	}
+}
+
 // NotFound creates a new error response representing a resource-not-found error (HTTP 404)
 func NotFound(msg string) ErrorResponse {
 	if msg == "" {
@@ -80,3 +96,66 @@ type invalidField struct {
 	Field string `json:"field"`
 	Error string `json:"error"`
 }
+
+// InvalidInput creates a new error response representing a data validation error (HTTP 400),
+// listing every invalid field in the details.
+func InvalidInput(err error) ErrorResponse {
+	var validationErrors validator.ValidationErrors
+	if !stderrors.As(err, &validationErrors) {
+		return BadRequest(err.Error())
+	}
+
+	details := make([]invalidField, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		details = append(details, invalidField{
+			Field: fieldPath(fieldError.Namespace()),
+			Error: fieldErrorMessage(fieldError),
+		})
+	}
+	return ErrorResponse{
+		Status:  http.StatusBadRequest,
+		Message: "There is some problem with the data you submitted.",
+		Details: details,
+	}
+}
+
+// fieldPath drops the struct name from a namespace such as
+// "OrganizationRequest.dailySchedules[0].to_time".
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func fieldErrorMessage(fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fieldError.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fieldError.Param())
+	case "len":
+		return fmt.Sprintf("must have a length of %s", fieldError.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fieldError.Param())
+	case "timezone":
+		return "must be an IANA time zone such as America/Toronto"
+	case "datetime":
+		return fmt.Sprintf("must be a date in the %s format", fieldError.Param())
+	case "weekday":
+		return "must be a weekday between 0 (Sunday) and 6 (Saturday)"
+	case "clock":
+		return "must be a time in the HH:MM format"
+	case "clockafter":
+		return fmt.Sprintf("must be after %s", fieldError.Param())
+	case "nooverlap":
+		return "must not contain overlapping time ranges on the same day"
+	case "script":
+		return "must only use known variables, see /api/v1/agent-scripts/variables"
+	}
+	return fmt.Sprintf("failed on the %q rule", fieldError.Tag())
+}
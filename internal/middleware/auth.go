@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"strings"
 )
 
@@ -43,3 +46,135 @@ func AuthMiddleware(securityService *service.SecurityService) fiber.Handler {
 		return c.Next()
 	}
 }
+
+// currentUserKey is the fiber.Ctx locals key holding the authenticated user.
+const currentUserKey = "currentUser"
+
+// Authenticated validates the bearer token and loads the matching user, which
+// handlers can then read with CurrentUser.
+func Authenticated(securityService *service.SecurityService, userService *service.UserService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, err := authenticate(c, securityService, userService); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// RequireRole only lets authenticated users having one of the given roles through.
+func RequireRole(securityService *service.SecurityService, userService *service.UserService, roles ...entity.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := authenticate(c, securityService, userService)
+		if err != nil {
+			return err
+		}
+		if !hasRole(user, roles) {
+			return errors.Forbidden("")
+		}
+		return c.Next()
+	}
+}
+
+// RequireOrganization only lets through authenticated users of the
+// organization of the organizationId path parameter, having one of the given
+// roles when any is given.
+func RequireOrganization(securityService *service.SecurityService, userService *service.UserService, roles ...entity.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := authenticate(c, securityService, userService)
+		if err != nil {
+			return err
+		}
+		organizationId, err := uuid.Parse(c.Params("organizationId"))
+		if err != nil {
+			return errors.BadRequest("Invalid organization ID")
+		}
+		if user.OrganizationID != organizationId {
+			return errors.Forbidden("")
+		}
+		if len(roles) > 0 && !hasRole(user, roles) {
+			return errors.Forbidden("")
+		}
+		return c.Next()
+	}
+}
+
+// RequireOrganizationOrAgent lets through the voice agent of the organization
+// of the organizationId path parameter, identified by its API key, and the
+// users organizationMember lets through.
+func RequireOrganizationOrAgent(callerLookupService *service.CallerLookupService, organizationMember fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(service.AgentKeyHeader)
+		if key == "" {
+			return organizationMember(c)
+		}
+		organizationId, err := uuid.Parse(c.Params("organizationId"))
+		if err != nil {
+			return errors.BadRequest("Invalid organization ID")
+		}
+		if err := callerLookupService.AuthorizeAgent(c.UserContext(), organizationId, key); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func hasRole(user *entity.User, roles []entity.UserRole) bool {
+	for _, role := range roles {
+		if user.Role != nil && *user.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentUser returns the user loaded by Authenticated or RequireRole.
+func CurrentUser(c *fiber.Ctx) (*entity.User, bool) {
+	user, ok := c.Locals(currentUserKey).(*entity.User)
+	return user, ok
+}
+
+func authenticate(c *fiber.Ctx, securityService *service.SecurityService, userService *service.UserService) (*entity.User, error) {
+	if securityService == nil {
+		return nil, errors.Unauthorized("Authentication is not available")
+	}
+
+	tokenString := strings.TrimSpace(strings.TrimPrefix(c.Get("Authorization"), "Bearer "))
+	if tokenString == "" && strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream") {
+		// EventSource cannot set headers, event streams may pass the token in the query
+		tokenString = c.Query("access_token")
+	}
+	if tokenString == "" {
+		return nil, errors.Unauthorized("Missing or invalid token")
+	}
+
+	token, err := securityService.ValidateJWT(tokenString)
+	if err != nil || !token.Valid {
+		return nil, errors.Unauthorized("Invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.Unauthorized("Invalid token")
+	}
+	email := claimString(claims, "email", "user_name", "username")
+	if email == "" {
+		return nil, errors.Unauthorized("Invalid token")
+	}
+
+	user, err := userService.GetUserByEmail(email)
+	if err != nil {
+		return nil, errors.Unauthorized("Unknown user")
+	}
+	c.Locals(currentUserKey, &user)
+	return &user, nil
+}
+
+// claimString returns the first non-empty string claim among the given names.
+func claimString(claims jwt.MapClaims, names ...string) string {
+	for _, name := range names {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
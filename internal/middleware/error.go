@@ -22,6 +22,9 @@ func ErrorHandler() fiber.Handler {
 		if err != nil {
 			logger.Error("Error encountered: ", err)
 			errorResponse := buildErrorResponse(err)
+			if errorResponse.Details != nil {
+				return c.Status(errorResponse.Status).JSON(fiber.Map{"error": errorResponse.Message, "details": errorResponse.Details})
+			}
 			return c.Status(errorResponse.Status).JSON(fiber.Map{"error": errorResponse.Message})
 		}
 
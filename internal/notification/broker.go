@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"sync"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many notifications a slow subscriber may lag behind
+// before new ones are dropped for it; it catches up from the inbox on reconnect.
+const subscriberBuffer = 16
+
+// Broker fans in-app notifications out to the live streams of their users.
+// It is in-process: each instance only reaches the streams connected to it.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan entity.Notification]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: map[uuid.UUID]map[chan entity.Notification]struct{}{}}
+}
+
+// Subscribe returns the notifications of a user as they are published, and a
+// function to call once the subscriber goes away.
+func (b *Broker) Subscribe(userID uuid.UUID) (<-chan entity.Notification, func()) {
+	ch := make(chan entity.Notification, subscriberBuffer)
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[chan entity.Notification]struct{}{}
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[userID], ch)
+			if len(b.subscribers[userID]) == 0 {
+				delete(b.subscribers, userID)
+			}
+		})
+	}
+}
+
+// Publish sends a notification to the streams of its user without blocking.
+func (b *Broker) Publish(notification entity.Notification) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers[notification.UserID] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+)
+
+// NewEmailChannel returns the email channel selected by the configuration.
+func NewEmailChannel(cfg config.EmailConfig) (Channel, error) {
+	switch cfg.Driver {
+	case "smtp":
+		if cfg.SMTP.Host == "" {
+			return nil, fmt.Errorf("smtp host is not configured")
+		}
+		return &SMTPChannel{config: cfg}, nil
+	case "mailbox", "":
+		dir := cfg.MailboxDir
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "comvoca-mailbox")
+		}
+		return &MailboxChannel{from: cfg.From, dir: dir}, nil
+	}
+	return nil, fmt.Errorf("unknown email driver %q", cfg.Driver)
+}
+
+// SMTPChannel sends emails through an SMTP relay.
+type SMTPChannel struct {
+	config config.EmailConfig
+}
+
+func (c *SMTPChannel) Type() entity.NotificationType {
+	return entity.Email
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if c.config.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", c.config.SMTP.Username, c.config.SMTP.Password, c.config.SMTP.Host)
+	}
+	addr := fmt.Sprintf("%s:%d", c.config.SMTP.Host, c.config.SMTP.Port)
+	return smtp.SendMail(addr, auth, senderAddress(c.config.From), []string{message.Recipient}, formatEmail(c.config.From, message))
+}
+
+// MailboxChannel writes every email as an .eml file in a directory, where it
+// can be opened with any mail client.
+type MailboxChannel struct {
+	from string
+	dir  string
+}
+
+func (c *MailboxChannel) Type() entity.NotificationType {
+	return entity.Email
+}
+
+func (c *MailboxChannel) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000"), uuid.NewString())
+	return os.WriteFile(filepath.Join(c.dir, name), formatEmail(c.from, message), 0o644)
+}
+
+func formatEmail(from string, message Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.Recipient)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// senderAddress extracts the address of a "Name <address>" sender.
+func senderAddress(from string) string {
+	if start, end := strings.LastIndex(from, "<"), strings.LastIndex(from, ">"); start >= 0 && end > start {
+		return from[start+1 : end]
+	}
+	return from
+}
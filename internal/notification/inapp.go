@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+)
+
+// InAppStore persists in-app notifications.
+type InAppStore interface {
+	SaveNotification(notification *entity.Notification) error
+}
+
+// InAppChannel stores messages in the inbox of the recipient user and pushes
+// them to the user's live streams.
+type InAppChannel struct {
+	store  InAppStore
+	broker *Broker
+}
+
+func NewInAppChannel(store InAppStore, broker *Broker) *InAppChannel {
+	return &InAppChannel{store: store, broker: broker}
+}
+
+func (c *InAppChannel) Type() entity.NotificationType {
+	return entity.InApp
+}
+
+func (c *InAppChannel) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if message.UserID == nil {
+		return errNoUser
+	}
+	notification := entity.Notification{
+		OrganizationID: message.OrganizationID,
+		UserID:         *message.UserID,
+		Event:          message.Event,
+		Title:          message.Subject,
+		Body:           message.Body,
+	}
+	if err := c.store.SaveNotification(&notification); err != nil {
+		return err
+	}
+	if c.broker != nil {
+		c.broker.Publish(notification)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// Package notification delivers rendered messages over email, SMS and the
+// in-app inbox. Each channel has a real implementation and a local stand-in
+// so the whole flow can be exercised without external services.
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Message is a rendered notification addressed to one recipient.
+type Message struct {
+	OrganizationID uuid.UUID
+	UserID         *uuid.UUID
+	Event          string
+	// Recipient is an email address or a phone number, depending on the channel.
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// Channel sends messages over one notification type.
+type Channel interface {
+	Type() entity.NotificationType
+	Send(ctx context.Context, message Message) error
+}
+
+var errNoUser = errors.New("in-app notifications need a recipient user")
@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+)
+
+// NewSMSChannel returns the SMS channel selected by the configuration.
+func NewSMSChannel(cfg config.SMSConfig) (Channel, error) {
+	switch cfg.Driver {
+	case "twilio":
+		if cfg.Twilio.AccountSid == "" || cfg.Twilio.AuthToken == "" {
+			return nil, fmt.Errorf("twilio credentials are not configured")
+		}
+		return &TwilioChannel{config: cfg, client: &http.Client{Timeout: 10 * time.Second}, baseURL: "https://api.twilio.com"}, nil
+	case "fake", "":
+		dir := cfg.FakeDir
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "comvoca-sms")
+		}
+		return &FakeSMSChannel{from: cfg.From, dir: dir}, nil
+	}
+	return nil, fmt.Errorf("unknown sms driver %q", cfg.Driver)
+}
+
+// TwilioChannel sends text messages through the Twilio REST API.
+type TwilioChannel struct {
+	config  config.SMSConfig
+	client  *http.Client
+	baseURL string
+}
+
+func (c *TwilioChannel) Type() entity.NotificationType {
+	return entity.SMS
+}
+
+func (c *TwilioChannel) Send(ctx context.Context, message Message) error {
+	form := url.Values{}
+	form.Set("From", c.config.From)
+	form.Set("To", message.Recipient)
+	form.Set("Body", message.Body)
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", c.baseURL, c.config.Twilio.AccountSid)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.config.Twilio.AccountSid, c.config.Twilio.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("twilio returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// FakeSMSChannel appends every text message as a JSON line to outbox.jsonl
+// in a directory instead of sending it.
+type FakeSMSChannel struct {
+	from string
+	dir  string
+	mu   sync.Mutex
+}
+
+func (c *FakeSMSChannel) Type() entity.NotificationType {
+	return entity.SMS
+}
+
+func (c *FakeSMSChannel) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	line, err := json.Marshal(map[string]string{
+		"from":    c.from,
+		"to":      message.Recipient,
+		"body":    message.Body,
+		"sent_at": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(c.dir, "outbox.jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
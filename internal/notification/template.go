@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+)
+
+// Events with a bundled template.
+const (
+	EventTest              = "test"
+	EventMissedCall        = "missed_call"
+	EventCallbackRequested = "callback_requested"
+	EventNewCall           = "new_call"
+	EventCallbackOverdue   = "callback_overdue"
+	EventOnboarding        = "onboarding_reminder"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Templates renders the bundled message templates. Each event has a file
+// defining a "subject" and a "body" template, and optionally a shorter "sms"
+// one used for text messages and the in-app inbox.
+type Templates struct {
+	events map[string]*template.Template
+}
+
+// LoadTemplates parses the bundled templates.
+func LoadTemplates() (*Templates, error) {
+	files, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	templates := &Templates{events: map[string]*template.Template{}}
+	for _, file := range files {
+		event := strings.TrimSuffix(file.Name(), ".tmpl")
+		tmpl, err := template.New(event).Option("missingkey=zero").ParseFS(templateFiles, "templates/"+file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", file.Name(), err)
+		}
+		for _, name := range []string{"subject", "body"} {
+			if tmpl.Lookup(name) == nil {
+				return nil, fmt.Errorf("template %s does not define %q", file.Name(), name)
+			}
+		}
+		templates.events[event] = tmpl
+	}
+	return templates, nil
+}
+
+// Has tells whether an event has a template.
+func (t *Templates) Has(event string) bool {
+	_, ok := t.events[event]
+	return ok
+}
+
+// Render returns the subject and body of an event for a channel.
+func (t *Templates) Render(event string, channel entity.NotificationType, data map[string]interface{}) (string, string, error) {
+	tmpl, ok := t.events[event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for event %q", event)
+	}
+	subject, err := execute(tmpl, "subject", data)
+	if err != nil {
+		return "", "", err
+	}
+	bodyName := "body"
+	if channel != entity.Email && tmpl.Lookup("sms") != nil {
+		bodyName = "sms"
+	}
+	body, err := execute(tmpl, bodyName, data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+func execute(tmpl *template.Template, name string, data map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s of %s: %w", name, tmpl.Name(), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
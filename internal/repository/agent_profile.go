@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AgentProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewAgentProfileRepo(db *gorm.DB) *AgentProfileRepository {
+	return &AgentProfileRepository{db: db}
+}
+
+func (dao *AgentProfileRepository) GetById(organizationId string, id string) (entity.AgentProfile, error) {
+	var profile entity.AgentProfile
+
+	tx := dao.db.First(&profile, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return profile, fmt.Errorf("agent profile not found")
+		}
+	}
+	return profile, tx.Error
+}
+
+// GetByOrganization returns the profiles of an organization, the default one
+// first.
+func (dao *AgentProfileRepository) GetByOrganization(organizationId string) ([]entity.AgentProfile, error) {
+	var profiles []entity.AgentProfile
+	err := dao.db.Where("organization_id = ?", organizationId).
+		Order("location_id NULLS FIRST, created_at").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+// GetByScope returns the profile of a location, or the organization default
+// when locationId is empty.
+func (dao *AgentProfileRepository) GetByScope(organizationId string, locationId string) (entity.AgentProfile, error) {
+	var profile entity.AgentProfile
+	query := dao.db.Where("organization_id = ?", organizationId)
+	if locationId != "" {
+		query = query.Where("location_id = ?", locationId)
+	} else {
+		query = query.Where("location_id IS NULL")
+	}
+	tx := query.First(&profile)
+	if tx.Error == gorm.ErrRecordNotFound {
+		return profile, fmt.Errorf("agent profile not found")
+	}
+	return profile, tx.Error
+}
+
+func (dao *AgentProfileRepository) Save(profile *entity.AgentProfile) error {
+	return dao.db.Create(profile).Error
+}
+
+func (dao *AgentProfileRepository) Update(profile *entity.AgentProfile) error {
+	return dao.db.Save(profile).Error
+}
+
+// DeleteWithVersions deletes a profile and its version history.
+func (dao *AgentProfileRepository) DeleteWithVersions(tx *gorm.DB, profile *entity.AgentProfile) error {
+	if err := tx.Where("profile_id = ?", profile.ID).Delete(&entity.AgentProfileVersion{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(profile).Error
+}
+
+// GetPublished returns the published profiles of an organization, the default
+// one first.
+func (dao *AgentProfileRepository) GetPublished(organizationId string) ([]entity.AgentProfile, error) {
+	var profiles []entity.AgentProfile
+	err := dao.db.Where("organization_id = ? AND published_version > 0", organizationId).
+		Order("location_id NULLS FIRST, created_at").
+		Find(&profiles).Error
+	return profiles, err
+}
+
+func (dao *AgentProfileRepository) GetVersions(profileId string) ([]entity.AgentProfileVersion, error) {
+	var versions []entity.AgentProfileVersion
+	err := dao.db.Where("profile_id = ?", profileId).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+func (dao *AgentProfileRepository) GetVersion(profileId string, version int) (entity.AgentProfileVersion, error) {
+	var profileVersion entity.AgentProfileVersion
+
+	tx := dao.db.First(&profileVersion, "profile_id = ? AND version = ?", profileId, version)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return profileVersion, fmt.Errorf("version %d not found", version)
+		}
+	}
+	return profileVersion, tx.Error
+}
+
+// GetPendingVersion returns the version of a profile awaiting approval, if any.
+func (dao *AgentProfileRepository) GetPendingVersion(profileId string) (*entity.AgentProfileVersion, error) {
+	var versions []entity.AgentProfileVersion
+	err := dao.db.Where("profile_id = ? AND status = ?", profileId, entity.VersionPending).
+		Limit(1).Find(&versions).Error
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	return &versions[0], nil
+}
+
+// NextVersion returns the number of the next version of a profile. The
+// profile row is locked so concurrent submissions get distinct numbers.
+func (dao *AgentProfileRepository) NextVersion(tx *gorm.DB, profileId uuid.UUID) (int, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&entity.AgentProfile{}, "id = ?", profileId).Error; err != nil {
+		return 0, err
+	}
+	var last int
+	err := tx.Model(&entity.AgentProfileVersion{}).Where("profile_id = ?", profileId).
+		Select("COALESCE(MAX(version), 0)").Scan(&last).Error
+	return last + 1, err
+}
+
+// SaveVersion stores a new version.
+func (dao *AgentProfileRepository) SaveVersion(tx *gorm.DB, version *entity.AgentProfileVersion) error {
+	return tx.Create(version).Error
+}
+
+func (dao *AgentProfileRepository) UpdateVersion(tx *gorm.DB, version *entity.AgentProfileVersion) error {
+	return tx.Save(version).Error
+}
+
+// Publish makes a version the live one of its profile. The version published
+// before is superseded.
+func (dao *AgentProfileRepository) Publish(tx *gorm.DB, profile *entity.AgentProfile, version *entity.AgentProfileVersion) error {
+	err := tx.Model(&entity.AgentProfileVersion{}).
+		Where("profile_id = ? AND status = ? AND id <> ?", profile.ID, entity.VersionPublished, version.ID).
+		Update("status", entity.VersionSuperseded).Error
+	if err != nil {
+		return err
+	}
+	version.Status = entity.VersionPublished
+	if err := tx.Save(version).Error; err != nil {
+		return err
+	}
+	profile.PublishedVersion = version.Version
+	return tx.Model(profile).Update("published_version", version.Version).Error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AgentSyncRepository struct {
+	db *gorm.DB
+}
+
+func NewAgentSyncRepo(db *gorm.DB) *AgentSyncRepository {
+	return &AgentSyncRepository{db: db}
+}
+
+func (dao *AgentSyncRepository) GetByOrganization(organizationId string) (entity.AgentSync, error) {
+	var sync entity.AgentSync
+
+	tx := dao.db.First(&sync, "organization_id = ?", organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return sync, fmt.Errorf("agent sync not found")
+		}
+	}
+	return sync, tx.Error
+}
+
+// Request marks the configuration of an organization as due for a sync,
+// resetting the attempts of a previous one.
+func (dao *AgentSyncRepository) Request(organizationId uuid.UUID, platform string, now time.Time) error {
+	sync := entity.AgentSync{
+		OrganizationID: organizationId,
+		Platform:       platform,
+		Status:         entity.SyncPending,
+		NextAttemptAt:  &now,
+		RequestedAt:    now,
+	}
+	return dao.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"platform":        platform,
+			"status":          entity.SyncPending,
+			"attempts":        0,
+			"last_error":      "",
+			"next_attempt_at": now,
+			"requested_at":    now,
+			"updated_at":      now,
+		}),
+	}).Create(&sync).Error
+}
+
+// ClaimDue returns the syncs due and pushes their next attempt back by the
+// lease, so that other workers skip them meanwhile.
+func (dao *AgentSyncRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]entity.AgentSync, error) {
+	var syncs []entity.AgentSync
+	err := dao.db.Raw(`
+		UPDATE agent_syncs SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM agent_syncs
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), entity.SyncPending, now, limit).
+		Scan(&syncs).Error
+	return syncs, err
+}
+
+// Update saves the outcome of an attempt, unless a new sync was requested
+// while it ran.
+func (dao *AgentSyncRepository) Update(sync *entity.AgentSync) error {
+	return dao.db.Model(&entity.AgentSync{}).
+		Where("id = ? AND requested_at = ?", sync.ID, sync.RequestedAt).
+		Select("status", "checksum", "attempts", "last_error", "next_attempt_at", "synced_at").
+		Updates(sync).Error
+}
+
+// SetAssistantID stores the assistant created for an organization.
+func (dao *AgentSyncRepository) SetAssistantID(organizationId uuid.UUID, assistantId string) error {
+	return dao.db.Model(&entity.Organization{}).Where("id = ?", organizationId).
+		Update("voice_assistant_id", assistantId).Error
+}
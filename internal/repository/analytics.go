@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// callRollupWatermark names the watermark of the call rollups.
+const callRollupWatermark = "calls"
+
+// changedCalls collects the calls changed within a period.
+const changedCalls = `
+CREATE TEMP TABLE changed_calls ON COMMIT DROP AS
+SELECT organization_id, caller_number, started_at
+FROM calls
+WHERE updated_at > ? AND updated_at <= ?`
+
+// takeMovedCalls adds where the moved calls were to the changed calls, and
+// forgets them.
+const takeMovedCalls = `
+WITH moved AS (DELETE FROM moved_calls RETURNING organization_id, caller_number, started_at)
+INSERT INTO changed_calls SELECT organization_id, caller_number, started_at FROM moved`
+
+// dirtyCallBuckets collects the buckets of the changed calls, and the buckets
+// of the later calls of their callers, whose calls may have become new or
+// returning ones.
+var dirtyCallBuckets = `
+CREATE TEMP TABLE dirty_call_buckets ON COMMIT DROP AS
+SELECT organization_id, ` + callBucket("started_at") + ` AS bucket_start
+FROM changed_calls
+UNION
+SELECT c.organization_id, ` + callBucket("c.started_at") + `
+FROM calls c
+JOIN (
+	SELECT organization_id, caller_number, min(started_at) AS since
+	FROM changed_calls
+	WHERE caller_number <> ''
+	GROUP BY organization_id, caller_number
+) f ON c.organization_id = f.organization_id AND c.caller_number = f.caller_number AND c.started_at > f.since`
+
+// callsInDirtyBuckets joins the calls of the dirty buckets.
+var callsInDirtyBuckets = fmt.Sprintf(`
+FROM dirty_call_buckets d
+JOIN calls c ON c.organization_id = d.organization_id
+	AND c.started_at >= d.bucket_start AND c.started_at < d.bucket_start + interval '%d seconds'`,
+	int(entity.RollupBucket.Seconds()))
+
+// insertCallRollups rolls up the calls of the dirty buckets, flagging those of
+// callers who called the organization before.
+var insertCallRollups = fmt.Sprintf(`
+INSERT INTO call_rollups (organization_id, location_id, bucket_start, calls, completed, missed, forwarded, voicemail,
+	transferred, after_hours, new_callers, returning_calls, total_duration, max_duration, duration_histogram)
+SELECT c.organization_id, c.location_id, d.bucket_start, count(*),
+	count(*) FILTER (WHERE c.status = '%s'),
+	count(*) FILTER (WHERE c.status = '%s'),
+	count(*) FILTER (WHERE c.status = '%s'),
+	count(*) FILTER (WHERE c.status = '%s'),
+	count(*) FILTER (WHERE c.%s),
+	count(*) FILTER (WHERE c.after_hours),
+	count(*) FILTER (WHERE c.caller_number <> '' AND NOT r.returning),
+	count(*) FILTER (WHERE r.returning),
+	sum(c.duration_seconds), max(c.duration_seconds),
+	%s
+%s
+CROSS JOIN LATERAL (
+	SELECT c.caller_number <> '' AND EXISTS (
+		SELECT 1 FROM calls e
+		WHERE e.organization_id = c.organization_id AND e.caller_number = c.caller_number
+			AND (e.started_at, e.id) < (c.started_at, c.id)
+	) AS returning
+) r
+GROUP BY c.organization_id, c.location_id, d.bucket_start`,
+	entity.CallCompleted, entity.CallMissed, entity.CallForwarded, entity.CallVoicemail,
+	transferredCall, durationHistogram(), callsInDirtyBuckets)
+
+var insertCallTagRollups = `
+INSERT INTO call_tag_rollups (organization_id, location_id, bucket_start, tag, calls)
+SELECT c.organization_id, c.location_id, d.bucket_start, t.tag, count(DISTINCT c.id)` + callsInDirtyBuckets + `
+CROSS JOIN unnest(c.tags) AS t(tag)
+GROUP BY c.organization_id, c.location_id, d.bucket_start, t.tag`
+
+var insertCallDispositionRollups = `
+INSERT INTO call_disposition_rollups (organization_id, location_id, bucket_start, disposition, calls)
+SELECT c.organization_id, c.location_id, d.bucket_start, c.disposition, count(*)` + callsInDirtyBuckets + `
+WHERE c.disposition <> ''
+GROUP BY c.organization_id, c.location_id, d.bucket_start, c.disposition`
+
+// callBucket is the start of the rollup bucket of a time column.
+func callBucket(column string) string {
+	seconds := int(entity.RollupBucket.Seconds())
+	return fmt.Sprintf("to_timestamp(floor(extract(epoch FROM %s) / %d) * %d)", column, seconds, seconds)
+}
+
+// durationHistogram counts the calls per bin of entity.CallDurationBins.
+func durationHistogram() string {
+	bins := make([]string, len(entity.CallDurationBins))
+	for i, lower := range entity.CallDurationBins {
+		condition := fmt.Sprintf("c.duration_seconds >= %d", lower)
+		if i+1 < len(entity.CallDurationBins) {
+			condition += fmt.Sprintf(" AND c.duration_seconds < %d", entity.CallDurationBins[i+1])
+		}
+		bins[i] = fmt.Sprintf("count(*) FILTER (WHERE %s)", condition)
+	}
+	return "ARRAY[" + strings.Join(bins, ", ") + "]"
+}
+
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepo(db *gorm.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// RollupScope selects the rollups of an organization, optionally narrowed to
+// a location, within [From, To).
+type RollupScope struct {
+	OrganizationId string
+	LocationId     *uuid.UUID
+	From           time.Time
+	To             time.Time
+}
+
+// CallTotals sums the call rollups of a scope.
+type CallTotals struct {
+	Calls          int
+	Completed      int
+	Missed         int
+	Forwarded      int
+	Voicemail      int
+	Transferred    int
+	AfterHours     int
+	NewCallers     int
+	ReturningCalls int
+	TotalDuration  int64
+	MaxDuration    int
+}
+
+// CallVolume sums the call rollups of a local period. LocalStart is the wall
+// clock time the period starts at, in the time zone of the query.
+type CallVolume struct {
+	LocalStart    time.Time
+	Calls         int
+	Completed     int
+	Missed        int
+	Forwarded     int
+	Voicemail     int
+	Transferred   int
+	AfterHours    int
+	TotalDuration int64
+}
+
+// TagCount is the number of calls with a tag.
+type TagCount struct {
+	Tag   string
+	Calls int
+}
+
+// DispositionCount is the number of calls with a disposition. Label is empty
+// when the disposition was deleted.
+type DispositionCount struct {
+	Disposition string
+	Label       string
+	Calls       int
+}
+
+// RefreshCallRollups rebuilds the rollups of the buckets with calls changed
+// since the watermark, less overlap to catch changes committed late, of the
+// buckets moved calls left and of the later buckets of their callers, and
+// moves the watermark to until. It returns the number of buckets rebuilt;
+// none are when another refresh is running.
+func (dao *AnalyticsRepository) RefreshCallRollups(until time.Time, overlap time.Duration) (int64, error) {
+	var rebuilt int64
+	err := dao.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext('call_rollups'))").Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var watermark entity.RollupWatermark
+		err := tx.Where("name = ?", callRollupWatermark).Limit(1).Find(&watermark).Error
+		if err != nil {
+			return err
+		}
+		since := watermark.RefreshedThrough
+		if !since.IsZero() {
+			since = since.Add(-overlap)
+		}
+
+		if err := tx.Exec(changedCalls, since, until).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(takeMovedCalls).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(dirtyCallBuckets).Error; err != nil {
+			return err
+		}
+		if err := tx.Raw("SELECT count(*) FROM dirty_call_buckets").Scan(&rebuilt).Error; err != nil {
+			return err
+		}
+		if rebuilt > 0 {
+			for _, statement := range []string{
+				"DELETE FROM call_rollups r USING dirty_call_buckets d WHERE r.organization_id = d.organization_id AND r.bucket_start = d.bucket_start",
+				"DELETE FROM call_tag_rollups r USING dirty_call_buckets d WHERE r.organization_id = d.organization_id AND r.bucket_start = d.bucket_start",
+				"DELETE FROM call_disposition_rollups r USING dirty_call_buckets d WHERE r.organization_id = d.organization_id AND r.bucket_start = d.bucket_start",
+				insertCallRollups,
+				insertCallTagRollups,
+				insertCallDispositionRollups,
+			} {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entity.RollupWatermark{
+			Name:             callRollupWatermark,
+			RefreshedThrough: until,
+		}).Error
+	})
+	return rebuilt, err
+}
+
+// SumCallRollups sums the call rollups of a scope.
+func (dao *AnalyticsRepository) SumCallRollups(scope RollupScope) (CallTotals, error) {
+	var totals CallTotals
+	err := dao.scoped(&entity.CallRollup{}, scope).Select(`
+		COALESCE(sum(calls), 0) AS calls, COALESCE(sum(completed), 0) AS completed,
+		COALESCE(sum(missed), 0) AS missed, COALESCE(sum(forwarded), 0) AS forwarded,
+		COALESCE(sum(voicemail), 0) AS voicemail, COALESCE(sum(transferred), 0) AS transferred,
+		COALESCE(sum(after_hours), 0) AS after_hours, COALESCE(sum(new_callers), 0) AS new_callers,
+		COALESCE(sum(returning_calls), 0) AS returning_calls, COALESCE(sum(total_duration), 0) AS total_duration,
+		COALESCE(max(max_duration), 0) AS max_duration`).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// DurationHistogram sums the duration histograms of the call rollups of a
+// scope, by bin of entity.CallDurationBins.
+func (dao *AnalyticsRepository) DurationHistogram(scope RollupScope) ([]int64, error) {
+	var bins []struct {
+		Bin   int
+		Calls int64
+	}
+	err := dao.scoped(&entity.CallRollup{}, scope).
+		Joins("CROSS JOIN unnest(call_rollups.duration_histogram) WITH ORDINALITY AS h(calls, bin)").
+		Select("h.bin, sum(h.calls) AS calls").
+		Group("h.bin").
+		Scan(&bins).Error
+	if err != nil {
+		return nil, err
+	}
+	histogram := make([]int64, len(entity.CallDurationBins))
+	for _, bin := range bins {
+		if bin.Bin >= 1 && bin.Bin <= len(histogram) {
+			histogram[bin.Bin-1] = bin.Calls
+		}
+	}
+	return histogram, nil
+}
+
+// CallVolumes sums the call rollups of a scope by local hour, day or week,
+// in order. Weeks start on Monday.
+func (dao *AnalyticsRepository) CallVolumes(scope RollupScope, unit string, timeZone string) ([]CallVolume, error) {
+	var volumes []CallVolume
+	err := dao.scoped(&entity.CallRollup{}, scope).Select(`
+		date_trunc(?, bucket_start AT TIME ZONE ?) AS local_start,
+		sum(calls) AS calls, sum(completed) AS completed, sum(missed) AS missed,
+		sum(forwarded) AS forwarded, sum(voicemail) AS voicemail, sum(transferred) AS transferred,
+		sum(after_hours) AS after_hours, sum(total_duration) AS total_duration`, unit, timeZone).
+		Group("local_start").
+		Order("local_start").
+		Scan(&volumes).Error
+	return volumes, err
+}
+
+// TopTags returns the tags set on the most calls of a scope.
+func (dao *AnalyticsRepository) TopTags(scope RollupScope, limit int) ([]TagCount, error) {
+	var tags []TagCount
+	err := dao.scoped(&entity.CallTagRollup{}, scope).
+		Select("tag, sum(calls) AS calls").
+		Group("tag").
+		Order("calls DESC, tag").
+		Limit(limit).
+		Scan(&tags).Error
+	return tags, err
+}
+
+// DispositionCounts returns the number of calls per disposition of a scope,
+// most set first.
+func (dao *AnalyticsRepository) DispositionCounts(scope RollupScope) ([]DispositionCount, error) {
+	var counts []DispositionCount
+	err := dao.scoped(&entity.CallDispositionRollup{}, scope).
+		Joins("LEFT JOIN call_dispositions ON call_dispositions.organization_id = call_disposition_rollups.organization_id AND call_dispositions.code = call_disposition_rollups.disposition").
+		Select("call_disposition_rollups.disposition, COALESCE(max(call_dispositions.label), '') AS label, sum(call_disposition_rollups.calls) AS calls").
+		Group("call_disposition_rollups.disposition").
+		Order("calls DESC, call_disposition_rollups.disposition").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// scoped restricts a query on a rollup table to a scope. Columns are
+// qualified, so that other tables can be joined.
+func (dao *AnalyticsRepository) scoped(model interface{}, scope RollupScope) *gorm.DB {
+	column := func(name string) clause.Column {
+		return clause.Column{Table: clause.CurrentTable, Name: name}
+	}
+	tx := dao.db.Model(model).Where(clause.And(
+		clause.Eq{Column: column("organization_id"), Value: scope.OrganizationId},
+		clause.Gte{Column: column("bucket_start"), Value: scope.From},
+		clause.Lt{Column: column("bucket_start"), Value: scope.To},
+	))
+	if scope.LocationId != nil {
+		tx = tx.Where(clause.Eq{Column: column("location_id"), Value: *scope.LocationId})
+	}
+	return tx
+}
@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CallRepository struct {
+	db *gorm.DB
+}
+
+func NewCallRepo(db *gorm.DB) *CallRepository {
+	return &CallRepository{db: db}
+}
+
+// CallConditions selects calls of an organization.
+type CallConditions struct {
+	OrganizationId string
+	From           *time.Time
+	To             *time.Time
+	CallerDigits   string
+	MinDuration    *int
+	MaxDuration    *int
+	Transferred    *bool
+	Tags           []string
+	Statuses       []string
+	Dispositions   []string
+	LocationId     *uuid.UUID
+	ContactId      *uuid.UUID
+}
+
+// CallQuery selects a page of calls. SortColumn is started_at or
+// duration_seconds; calls with the same value are ordered by id. With AfterID
+// set, the page starts after the call with that id and AfterValue as sort
+// value.
+type CallQuery struct {
+	CallConditions
+	SortColumn string
+	Descending bool
+	AfterValue any
+	AfterID    *uuid.UUID
+	Limit      int
+}
+
+// CallMatch is a call whose transcript matches a search, with the relevance
+// of its best matching turn.
+type CallMatch struct {
+	CallID uuid.UUID
+	Rank   float64
+}
+
+// TranscriptMatch is a turn matching a search, with the matching words of
+// Snippet highlighted.
+type TranscriptMatch struct {
+	CallID       uuid.UUID
+	Position     int
+	Speaker      string
+	Snippet      string
+	OffsetMillis int64
+}
+
+// Enqueue stores a report of the voice platform for processing. It tells
+// whether the report is new, a report with the same digest being ignored.
+func (dao *CallRepository) Enqueue(event *entity.CallEvent) (bool, error) {
+	tx := dao.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "digest"}},
+		DoNothing: true,
+	}).Create(event)
+	return tx.RowsAffected == 1, tx.Error
+}
+
+// ClaimDue returns the reports due, oldest first, and pushes their next
+// attempt back by the lease, so that other workers skip them meanwhile.
+func (dao *CallRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]entity.CallEvent, error) {
+	var events []entity.CallEvent
+	err := dao.db.Raw(`
+		UPDATE call_events SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM call_events
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY occurred_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), entity.CallEventPending, now, limit).
+		Scan(&events).Error
+	return events, err
+}
+
+// UpdateEvent saves the outcome of processing a report.
+func (dao *CallRepository) UpdateEvent(event *entity.CallEvent) error {
+	return dao.db.Model(&entity.CallEvent{}).Where("id = ?", event.ID).
+		Select("status", "attempts", "last_error", "next_attempt_at", "processed_at").
+		Updates(event).Error
+}
+
+// Insert creates a call unless one with the same external id exists. It
+// tells whether the call was created.
+func (dao *CallRepository) Insert(tx *gorm.DB, call *entity.Call) (bool, error) {
+	result := tx.Omit("Transcript", "Notes", "Contact").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "external_call_id"}},
+		DoNothing: true,
+	}).Create(call)
+	return result.RowsAffected == 1, result.Error
+}
+
+// LockByExternalID returns a call by its id on the voice platform, locked
+// until the end of the transaction.
+func (dao *CallRepository) LockByExternalID(tx *gorm.DB, externalCallId string) (entity.Call, error) {
+	var call entity.Call
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&call, "external_call_id = ?", externalCallId)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return call, fmt.Errorf("call not found")
+		}
+	}
+	return call, result.Error
+}
+
+// RecordMove records where a call was before its start or caller number
+// changed, so that the rollups it left are rebuilt.
+func (dao *CallRepository) RecordMove(tx *gorm.DB, previous entity.Call) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.MovedCall{
+		OrganizationID: previous.OrganizationID,
+		CallerNumber:   previous.CallerNumber,
+		StartedAt:      previous.StartedAt,
+	}).Error
+}
+
+func (dao *CallRepository) Update(tx *gorm.DB, call *entity.Call) error {
+	return tx.Omit("Transcript", "Notes", "Contact").Save(call).Error
+}
+
+// ReplaceTranscript replaces the transcript of a call.
+func (dao *CallRepository) ReplaceTranscript(tx *gorm.DB, callId uuid.UUID, turns []entity.TranscriptTurn) error {
+	if err := tx.Where("call_id = ?", callId).Delete(&entity.TranscriptTurn{}).Error; err != nil {
+		return err
+	}
+	if len(turns) == 0 {
+		return nil
+	}
+	for i := range turns {
+		turns[i].CallID = callId
+	}
+	return tx.Create(&turns).Error
+}
+
+// LocationByNumber returns the id of the location of an organization called
+// at a phone number, or nil when none is.
+func (dao *CallRepository) LocationByNumber(tx *gorm.DB, organizationId uuid.UUID, number string) (*uuid.UUID, error) {
+	if number == "" {
+		return nil, nil
+	}
+	var ids []uuid.UUID
+	err := tx.Model(&entity.Location{}).
+		Where("organization_id = ? AND phone_number = ?", organizationId, number).
+		Limit(1).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return &ids[0], nil
+}
+
+// SetWebhookSecret stores the secret signing the reports of an organization.
+func (dao *CallRepository) SetWebhookSecret(organizationId uuid.UUID, secret string) error {
+	return dao.db.Model(&entity.Organization{}).Where("id = ?", organizationId).
+		Update("webhook_secret", secret).Error
+}
+
+func (dao *CallRepository) GetById(organizationId string, id string) (entity.Call, error) {
+	var call entity.Call
+
+	tx := dao.db.Preload("Transcript", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Notes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).Preload("Notes.Author").Preload("Contact").First(&call, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return call, fmt.Errorf("call not found")
+		}
+	}
+	return call, tx.Error
+}
+
+// List returns the calls matching a query, in the order of the query.
+func (dao *CallRepository) List(query CallQuery) ([]entity.Call, error) {
+	tx := query.apply(dao.db.Model(&entity.Call{}))
+
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if query.AfterID != nil {
+		tx = tx.Where(fmt.Sprintf("(%s, id) %s (?, ?)", query.SortColumn, comparison), query.AfterValue, *query.AfterID)
+	}
+
+	var calls []entity.Call
+	err := tx.Order(fmt.Sprintf("%s %s, id %s", query.SortColumn, direction, direction)).
+		Limit(query.Limit).Preload("Contact").Find(&calls).Error
+	return calls, err
+}
+
+// GetByIds returns calls of an organization, without their transcript.
+func (dao *CallRepository) GetByIds(organizationId string, ids []uuid.UUID) ([]entity.Call, error) {
+	var calls []entity.Call
+	err := dao.db.Preload("Contact").Where("organization_id = ? AND id IN ?", organizationId, ids).Find(&calls).Error
+	return calls, err
+}
+
+// Search returns a page of the calls whose transcript matches a web search
+// query in English or French, most relevant first, with their total number.
+func (dao *CallRepository) Search(conditions CallConditions, query string, limit int, offset int) ([]CallMatch, int64, error) {
+	matching := dao.db.Table("transcript_turns AS t").
+		Where("t.call_id IN (?)", conditions.apply(dao.db.Model(&entity.Call{}).Select("id"))).
+		Where("t.search_vector @@ ("+transcriptQuery+")", query, query)
+
+	var total int64
+	if err := matching.Session(&gorm.Session{}).Distinct("t.call_id").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	var matches []CallMatch
+	err := matching.Session(&gorm.Session{}).
+		Select("t.call_id, MAX(ts_rank(t.search_vector, "+transcriptQuery+")) AS rank", query, query).
+		Joins("JOIN calls c ON c.id = t.call_id").
+		Group("t.call_id, c.started_at").
+		Order("rank DESC, c.started_at DESC").
+		Limit(limit).Offset(offset).
+		Scan(&matches).Error
+	return matches, total, err
+}
+
+// GetTranscriptMatches returns the turns of calls matching a web search query,
+// by call and position. Snippets are HTML escaped.
+func (dao *CallRepository) GetTranscriptMatches(callIds []uuid.UUID, query string) ([]TranscriptMatch, error) {
+	var matches []TranscriptMatch
+	err := dao.db.Raw(`
+		SELECT t.call_id, t.position, t.speaker, t.offset_millis,
+			CASE WHEN to_tsvector('english', t.text) @@ q.english
+				THEN ts_headline('english', `+escapedTurnText+`, q.english, @options)
+				ELSE ts_headline('french', `+escapedTurnText+`, q.french, @options)
+			END AS snippet
+		FROM transcript_turns t,
+			(SELECT websearch_to_tsquery('english', @query) AS english, websearch_to_tsquery('french', @query) AS french) q
+		WHERE t.call_id IN @calls AND t.search_vector @@ (q.english || q.french)
+		ORDER BY t.call_id, t.position`, map[string]interface{}{
+		"query":   query,
+		"calls":   callIds,
+		"options": transcriptHeadline,
+	}).Scan(&matches).Error
+	return matches, err
+}
+
+// transcriptQuery parses a web search query with the configurations the
+// transcripts are indexed with. It takes the query twice.
+const transcriptQuery = "websearch_to_tsquery('english', ?) || websearch_to_tsquery('french', ?)"
+
+// escapedTurnText is the text of a turn with the HTML special characters
+// escaped, so that the only markup of a snippet is the highlighting.
+const escapedTurnText = "replace(replace(replace(t.text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;')"
+
+// transcriptHeadline highlights the matching words of a turn, keeping short
+// turns whole.
+const transcriptHeadline = "StartSel=<mark>, StopSel=</mark>, MinWords=8, MaxWords=30, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// transferredCall is the condition of the transferred calls, as told by
+// entity.Call.Transferred.
+const transferredCall = "transferred_to <> ''"
+
+// apply restricts a query on calls to the conditions.
+func (conditions CallConditions) apply(tx *gorm.DB) *gorm.DB {
+	tx = tx.Where("organization_id = ?", conditions.OrganizationId)
+	if conditions.From != nil {
+		tx = tx.Where("started_at >= ?", *conditions.From)
+	}
+	if conditions.To != nil {
+		tx = tx.Where("started_at < ?", *conditions.To)
+	}
+	if conditions.CallerDigits != "" {
+		tx = tx.Where("regexp_replace(caller_number, '\\D', '', 'g') LIKE ?", "%"+conditions.CallerDigits+"%")
+	}
+	if conditions.MinDuration != nil {
+		tx = tx.Where("duration_seconds >= ?", *conditions.MinDuration)
+	}
+	if conditions.MaxDuration != nil {
+		tx = tx.Where("duration_seconds <= ?", *conditions.MaxDuration)
+	}
+	if conditions.Transferred != nil {
+		if *conditions.Transferred {
+			tx = tx.Where(transferredCall)
+		} else {
+			tx = tx.Where("NOT (" + transferredCall + ")")
+		}
+	}
+	if len(conditions.Tags) > 0 {
+		tx = tx.Where("tags @> ?", pq.StringArray(conditions.Tags))
+	}
+	if len(conditions.Statuses) > 0 {
+		tx = tx.Where("status IN ?", conditions.Statuses)
+	}
+	if len(conditions.Dispositions) > 0 {
+		tx = tx.Where("disposition IN ?", conditions.Dispositions)
+	}
+	if conditions.LocationId != nil {
+		tx = tx.Where("location_id = ?", *conditions.LocationId)
+	}
+	if conditions.ContactId != nil {
+		tx = tx.Where("contact_id = ?", *conditions.ContactId)
+	}
+	return tx
+}
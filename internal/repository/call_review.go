@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CallReviewRepository stores what the staff record about calls: the
+// dispositions and tags of organizations, and the notes and edits of calls.
+type CallReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewCallReviewRepo(db *gorm.DB) *CallReviewRepository {
+	return &CallReviewRepository{db: db}
+}
+
+// GetDispositions returns the dispositions of an organization in order,
+// archived ones included.
+func (dao *CallReviewRepository) GetDispositions(organizationId string) ([]entity.CallDisposition, error) {
+	var dispositions []entity.CallDisposition
+	err := dao.db.Where("organization_id = ?", organizationId).Order("position, code").Find(&dispositions).Error
+	return dispositions, err
+}
+
+func (dao *CallReviewRepository) GetDispositionById(organizationId string, id string) (entity.CallDisposition, error) {
+	var disposition entity.CallDisposition
+
+	tx := dao.db.First(&disposition, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return disposition, fmt.Errorf("disposition not found")
+		}
+	}
+	return disposition, tx.Error
+}
+
+// CreateDispositions adds dispositions to an organization, skipping those
+// whose code it already has.
+func (dao *CallReviewRepository) CreateDispositions(dispositions []entity.CallDisposition) error {
+	return dao.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&dispositions).Error
+}
+
+func (dao *CallReviewRepository) SaveDisposition(disposition *entity.CallDisposition) error {
+	return dao.db.Save(disposition).Error
+}
+
+func (dao *CallReviewRepository) DeleteDisposition(disposition *entity.CallDisposition) error {
+	return dao.db.Delete(disposition).Error
+}
+
+// DispositionInUse tells whether a disposition is set on a call.
+func (dao *CallReviewRepository) DispositionInUse(organizationId uuid.UUID, code string) (bool, error) {
+	var count int64
+	err := dao.db.Model(&entity.Call{}).
+		Where("organization_id = ? AND disposition = ?", organizationId, code).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// GetTags returns the tags of an organization by name.
+func (dao *CallReviewRepository) GetTags(organizationId string) ([]entity.CallTag, error) {
+	var tags []entity.CallTag
+	err := dao.db.Where("organization_id = ?", organizationId).Order("name").Find(&tags).Error
+	return tags, err
+}
+
+func (dao *CallReviewRepository) GetTagById(organizationId string, id string) (entity.CallTag, error) {
+	var tag entity.CallTag
+
+	tx := dao.db.First(&tag, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return tag, fmt.Errorf("tag not found")
+		}
+	}
+	return tag, tx.Error
+}
+
+// CountTags returns how many of names are tags of an organization.
+func (dao *CallReviewRepository) CountTags(organizationId uuid.UUID, names []string) (int64, error) {
+	var count int64
+	err := dao.db.Model(&entity.CallTag{}).
+		Where("organization_id = ? AND name IN ?", organizationId, names).
+		Count(&count).Error
+	return count, err
+}
+
+// SaveTag saves a tag, renaming it on the calls it is set on when its name
+// changed.
+func (dao *CallReviewRepository) SaveTag(tx *gorm.DB, tag *entity.CallTag, previousName string) error {
+	if previousName != "" && previousName != tag.Name {
+		err := tx.Model(&entity.Call{}).
+			Where("organization_id = ? AND ? = ANY(tags)", tag.OrganizationID, previousName).
+			Update("tags", gorm.Expr("array_replace(tags, ?, ?)", previousName, tag.Name)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Save(tag).Error
+}
+
+// DeleteTag deletes a tag and removes it from the calls it is set on.
+func (dao *CallReviewRepository) DeleteTag(tx *gorm.DB, tag *entity.CallTag) error {
+	err := tx.Model(&entity.Call{}).
+		Where("organization_id = ? AND ? = ANY(tags)", tag.OrganizationID, tag.Name).
+		Update("tags", gorm.Expr("array_remove(tags, ?)", tag.Name)).Error
+	if err != nil {
+		return err
+	}
+	return tx.Delete(tag).Error
+}
+
+// LockCall returns a call of an organization with its contact, locked until
+// the end of the transaction.
+func (dao *CallReviewRepository) LockCall(tx *gorm.DB, organizationId string, id string) (entity.Call, error) {
+	var call entity.Call
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Contact").
+		First(&call, "id = ? AND organization_id = ?", id, organizationId)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return call, fmt.Errorf("call not found")
+		}
+	}
+	return call, result.Error
+}
+
+// UpdateCall saves the disposition and tags of a call.
+func (dao *CallReviewRepository) UpdateCall(tx *gorm.DB, call *entity.Call) error {
+	return tx.Model(call).Select("disposition", "tags", "updated_at").Updates(call).Error
+}
+
+// AddChange records an edit of a call.
+func (dao *CallReviewRepository) AddChange(tx *gorm.DB, change *entity.CallChange) error {
+	return tx.Create(change).Error
+}
+
+// GetChanges returns the edits of a call, newest first.
+func (dao *CallReviewRepository) GetChanges(callId uuid.UUID) ([]entity.CallChange, error) {
+	var changes []entity.CallChange
+	err := dao.db.Preload("User").Where("call_id = ?", callId).Order("created_at DESC").Find(&changes).Error
+	return changes, err
+}
+
+// GetNote returns a note of a call with its author.
+func (dao *CallReviewRepository) GetNote(callId uuid.UUID, id string) (entity.CallNote, error) {
+	var note entity.CallNote
+
+	tx := dao.db.Preload("Author").First(&note, "id = ? AND call_id = ?", id, callId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return note, fmt.Errorf("note not found")
+		}
+	}
+	return note, tx.Error
+}
+
+func (dao *CallReviewRepository) SaveNote(tx *gorm.DB, note *entity.CallNote) error {
+	return tx.Omit("Author").Save(note).Error
+}
+
+func (dao *CallReviewRepository) DeleteNote(tx *gorm.DB, note *entity.CallNote) error {
+	return tx.Delete(note).Error
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CallbackRepository stores the callback rules of organizations and the
+// callback tasks they create.
+type CallbackRepository struct {
+	db *gorm.DB
+}
+
+func NewCallbackRepo(db *gorm.DB) *CallbackRepository {
+	return &CallbackRepository{db: db}
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// CallbackQuery selects the tasks of an organization. Overdue tasks are the
+// active ones due before Now.
+type CallbackQuery struct {
+	OrganizationID uuid.UUID
+	AssigneeID     *uuid.UUID
+	Unassigned     bool
+	Statuses       []entity.CallbackStatus
+	Overdue        bool
+	Now            time.Time
+	Limit          int
+	Offset         int
+}
+
+// GetRules returns the callback rules of an organization in order.
+func (dao *CallbackRepository) GetRules(organizationId string) ([]entity.CallbackRule, error) {
+	var rules []entity.CallbackRule
+	err := dao.db.Where("organization_id = ?", organizationId).Order("position, created_at").Find(&rules).Error
+	return rules, err
+}
+
+func (dao *CallbackRepository) GetRuleById(organizationId string, id string) (entity.CallbackRule, error) {
+	var rule entity.CallbackRule
+
+	tx := dao.db.First(&rule, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return rule, fmt.Errorf("callback rule not found")
+		}
+	}
+	return rule, tx.Error
+}
+
+// CreateRules creates rules, skipping those whose name an organization already
+// has, e.g. when the default rules are given to it concurrently.
+func (dao *CallbackRepository) CreateRules(rules []entity.CallbackRule) error {
+	return dao.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rules).Error
+}
+
+func (dao *CallbackRepository) SaveRule(rule *entity.CallbackRule) error {
+	return dao.db.Save(rule).Error
+}
+
+func (dao *CallbackRepository) DeleteRule(rule *entity.CallbackRule) error {
+	return dao.db.Delete(rule).Error
+}
+
+// CallerSaid tells whether the caller said one of keywords during a call,
+// ignoring case.
+func (dao *CallbackRepository) CallerSaid(callId uuid.UUID, keywords []string) (bool, error) {
+	patterns := make(pq.StringArray, 0, len(keywords))
+	for _, keyword := range keywords {
+		patterns = append(patterns, "%"+likeEscaper.Replace(keyword)+"%")
+	}
+	var count int64
+	err := dao.db.Model(&entity.TranscriptTurn{}).
+		Where("call_id = ? AND speaker = ? AND text ILIKE ANY (?)", callId, entity.SpeakerCaller, patterns).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// CallerName returns the name of the contact of a call, empty when unknown.
+func (dao *CallbackRepository) CallerName(callId uuid.UUID) (string, error) {
+	var names []string
+	err := dao.db.Model(&entity.Call{}).
+		Joins("JOIN contacts ON contacts.id = calls.contact_id").
+		Where("calls.id = ?", callId).
+		Pluck("contacts.name", &names).Error
+	if err != nil || len(names) == 0 {
+		return "", err
+	}
+	return names[0], nil
+}
+
+// GetActiveTasksOfContact returns the open and in-progress tasks created from
+// the calls of a contact, the soonest due first.
+func (dao *CallbackRepository) GetActiveTasksOfContact(ctx context.Context, contactId uuid.UUID, limit int) ([]entity.CallbackTask, error) {
+	var tasks []entity.CallbackTask
+	err := dao.db.WithContext(ctx).
+		Joins("JOIN calls ON calls.id = callback_tasks.call_id").
+		Where("calls.contact_id = ? AND callback_tasks.status IN ?", contactId,
+			[]entity.CallbackStatus{entity.CallbackOpen, entity.CallbackInProgress}).
+		Order("callback_tasks.due_at, callback_tasks.id").Limit(limit).Find(&tasks).Error
+	return tasks, err
+}
+
+// HasActiveTask tells whether a caller already has a task to be done by an
+// organization.
+func (dao *CallbackRepository) HasActiveTask(organizationId uuid.UUID, callerNumber string) (bool, error) {
+	var count int64
+	err := dao.db.Model(&entity.CallbackTask{}).
+		Where("organization_id = ? AND caller_number = ? AND status IN ?", organizationId, callerNumber,
+			[]entity.CallbackStatus{entity.CallbackOpen, entity.CallbackInProgress}).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// InsertTask creates a task unless its call already has one. It tells
+// whether the task was created.
+func (dao *CallbackRepository) InsertTask(task *entity.CallbackTask) (bool, error) {
+	result := dao.db.Omit("Assignee").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "call_id"}},
+		DoNothing: true,
+	}).Create(task)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ListTasks returns a page of tasks, the soonest due first, with their
+// assignee, and how many tasks match in total.
+func (dao *CallbackRepository) ListTasks(query CallbackQuery) ([]entity.CallbackTask, int64, error) {
+	tx := dao.db.Model(&entity.CallbackTask{}).Where("organization_id = ?", query.OrganizationID)
+	if query.AssigneeID != nil {
+		tx = tx.Where("assignee_id = ?", *query.AssigneeID)
+	}
+	if query.Unassigned {
+		tx = tx.Where("assignee_id IS NULL")
+	}
+	if len(query.Statuses) > 0 {
+		tx = tx.Where("status IN ?", query.Statuses)
+	}
+	if query.Overdue {
+		tx = tx.Where("status IN ? AND due_at < ?",
+			[]entity.CallbackStatus{entity.CallbackOpen, entity.CallbackInProgress}, query.Now)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var tasks []entity.CallbackTask
+	err := tx.Preload("Assignee").Order("due_at, id").Limit(query.Limit).Offset(query.Offset).Find(&tasks).Error
+	return tasks, total, err
+}
+
+func (dao *CallbackRepository) GetTaskById(organizationId string, id string) (entity.CallbackTask, error) {
+	var task entity.CallbackTask
+
+	tx := dao.db.Preload("Assignee").First(&task, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return task, fmt.Errorf("callback task not found")
+		}
+	}
+	return task, tx.Error
+}
+
+// LockTask returns a task of an organization, locked until the end of the
+// transaction.
+func (dao *CallbackRepository) LockTask(tx *gorm.DB, organizationId string, id string) (entity.CallbackTask, error) {
+	var task entity.CallbackTask
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&task, "id = ? AND organization_id = ?", id, organizationId)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return task, fmt.Errorf("callback task not found")
+		}
+	}
+	return task, result.Error
+}
+
+func (dao *CallbackRepository) SaveTask(tx *gorm.DB, task *entity.CallbackTask) error {
+	return tx.Omit("Assignee").Save(task).Error
+}
+
+// ClaimBreached marks the active tasks due before now that were not marked
+// yet as breached, and returns them. Concurrent workers claim different tasks.
+func (dao *CallbackRepository) ClaimBreached(now time.Time, limit int) ([]entity.CallbackTask, error) {
+	var tasks []entity.CallbackTask
+	err := dao.db.Raw(`
+		UPDATE callback_tasks SET breached_at = ?
+		WHERE id IN (
+			SELECT id FROM callback_tasks
+			WHERE status IN ? AND due_at <= ? AND breached_at IS NULL
+			ORDER BY due_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now, []entity.CallbackStatus{entity.CallbackOpen, entity.CallbackInProgress}, now, limit).
+		Scan(&tasks).Error
+	return tasks, err
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ContactRepository stores the callers of organizations and links their
+// calls to them.
+type ContactRepository struct {
+	db *gorm.DB
+}
+
+func NewContactRepo(db *gorm.DB) *ContactRepository {
+	return &ContactRepository{db: db}
+}
+
+// ContactSummary is a contact with its phone numbers and the number of calls
+// linked to it, and when the first and last ones started.
+type ContactSummary struct {
+	entity.Contact
+	Calls       int
+	FirstCallAt *time.Time
+	LastCallAt  *time.Time
+}
+
+// ContactQuery selects a page of the contacts of an organization. Name is
+// part of their name and Digits part of one of their numbers; either
+// matches.
+type ContactQuery struct {
+	OrganizationID uuid.UUID
+	Name           string
+	Digits         string
+	Limit          int
+	Offset         int
+}
+
+// CallNumber is the caller number of a call.
+type CallNumber struct {
+	ID           uuid.UUID
+	CallerNumber string
+}
+
+// ContactForNumber returns the contact of a normalized number in an
+// organization, creating it when the number is new.
+func (dao *ContactRepository) ContactForNumber(tx *gorm.DB, organizationId uuid.UUID, number string) (entity.Contact, error) {
+	contact, err := dao.contactOf(tx, organizationId, number)
+	if err != gorm.ErrRecordNotFound {
+		return contact, err
+	}
+
+	contact = entity.Contact{OrganizationID: organizationId}
+	err = tx.Transaction(func(inner *gorm.DB) error {
+		if err := inner.Omit("Phones").Create(&contact).Error; err != nil {
+			return err
+		}
+		return inner.Create(&entity.ContactPhone{OrganizationID: organizationId, ContactID: contact.ID, Number: number}).Error
+	})
+	if err != nil {
+		// the number was added concurrently
+		return dao.contactOf(tx, organizationId, number)
+	}
+	return contact, nil
+}
+
+// FindContact returns the contact of a normalized number in an organization,
+// or gorm.ErrRecordNotFound when the number is new.
+func (dao *ContactRepository) FindContact(ctx context.Context, organizationId uuid.UUID, number string) (entity.Contact, error) {
+	return dao.contactOf(dao.db.WithContext(ctx), organizationId, number)
+}
+
+func (dao *ContactRepository) contactOf(tx *gorm.DB, organizationId uuid.UUID, number string) (entity.Contact, error) {
+	var contact entity.Contact
+	err := tx.Joins("JOIN contact_phones p ON p.contact_id = contacts.id").
+		Where("p.organization_id = ? AND p.number = ?", organizationId, number).
+		Take(&contact).Error
+	return contact, err
+}
+
+// ListContacts returns a page of contacts, the most recent callers first,
+// and how many contacts match in total.
+func (dao *ContactRepository) ListContacts(query ContactQuery) ([]ContactSummary, int64, error) {
+	tx := dao.db.Model(&entity.Contact{}).Where("contacts.organization_id = ?", query.OrganizationID)
+	switch {
+	case query.Name != "" && query.Digits != "":
+		tx = tx.Where("contacts.name ILIKE ? OR "+phoneMatch, "%"+likeEscaper.Replace(query.Name)+"%", "%"+query.Digits+"%")
+	case query.Name != "":
+		tx = tx.Where("contacts.name ILIKE ?", "%"+likeEscaper.Replace(query.Name)+"%")
+	case query.Digits != "":
+		tx = tx.Where(phoneMatch, "%"+query.Digits+"%")
+	}
+
+	var total int64
+	if err := tx.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var contacts []ContactSummary
+	err := dao.withStats(tx, query.OrganizationID).
+		Order("stats.last_call_at DESC NULLS LAST, contacts.id").
+		Limit(query.Limit).Offset(query.Offset).
+		Scan(&contacts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return contacts, total, dao.loadPhones(contacts)
+}
+
+// phoneMatch matches the contacts with a number containing digits.
+const phoneMatch = "EXISTS (SELECT 1 FROM contact_phones p WHERE p.contact_id = contacts.id AND p.number LIKE ?)"
+
+func (dao *ContactRepository) GetContactById(organizationId string, id string) (ContactSummary, error) {
+	var contacts []ContactSummary
+
+	err := dao.withStats(dao.db.Model(&entity.Contact{}), organizationId).
+		Where("contacts.id = ? AND contacts.organization_id = ?", id, organizationId).
+		Scan(&contacts).Error
+
+	if err != nil {
+		return ContactSummary{}, err
+	}
+	if len(contacts) == 0 {
+		return ContactSummary{}, fmt.Errorf("contact not found")
+	}
+	return contacts[0], dao.loadPhones(contacts)
+}
+
+// withStats adds the call statistics of the contacts of an organization to a
+// query.
+func (dao *ContactRepository) withStats(tx *gorm.DB, organizationId interface{}) *gorm.DB {
+	return tx.Select("contacts.*, COALESCE(stats.calls, 0) AS calls, stats.first_call_at, stats.last_call_at").
+		Joins(`LEFT JOIN (
+			SELECT contact_id, count(*) AS calls, min(started_at) AS first_call_at, max(started_at) AS last_call_at
+			FROM calls WHERE organization_id = ? AND contact_id IS NOT NULL GROUP BY contact_id
+		) stats ON stats.contact_id = contacts.id`, organizationId)
+}
+
+// loadPhones sets the phone numbers of contacts, in the order they were
+// added.
+func (dao *ContactRepository) loadPhones(contacts []ContactSummary) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(contacts))
+	for i, contact := range contacts {
+		ids[i] = contact.ID
+	}
+	var phones []entity.ContactPhone
+	if err := dao.db.Where("contact_id IN ?", ids).Order("created_at, number").Find(&phones).Error; err != nil {
+		return err
+	}
+	for i := range contacts {
+		for _, phone := range phones {
+			if phone.ContactID == contacts[i].ID {
+				contacts[i].Phones = append(contacts[i].Phones, phone)
+			}
+		}
+	}
+	return nil
+}
+
+// GetCalls returns the latest calls of a contact, newest first.
+func (dao *ContactRepository) GetCalls(contactId uuid.UUID, limit int) ([]entity.Call, error) {
+	var calls []entity.Call
+	err := dao.db.Where("contact_id = ?", contactId).Order("started_at DESC, id DESC").Limit(limit).Find(&calls).Error
+	return calls, err
+}
+
+// GetRecentCalls returns when the latest calls of a contact started, how they
+// ended and their summary, newest first.
+func (dao *ContactRepository) GetRecentCalls(ctx context.Context, contactId uuid.UUID, limit int) ([]entity.Call, error) {
+	var calls []entity.Call
+	err := dao.db.WithContext(ctx).Select("id, started_at, status, summary").
+		Where("contact_id = ?", contactId).Order("started_at DESC, id DESC").Limit(limit).Find(&calls).Error
+	return calls, err
+}
+
+// LockContacts returns contacts of an organization, locked until the end of
+// the transaction, with their phone numbers.
+func (dao *ContactRepository) LockContacts(tx *gorm.DB, organizationId string, ids []uuid.UUID) ([]entity.Contact, error) {
+	var contacts []entity.Contact
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Phones").
+		Where("organization_id = ? AND id IN ?", organizationId, ids).
+		Order("id").Find(&contacts).Error
+	return contacts, err
+}
+
+func (dao *ContactRepository) SaveContact(tx *gorm.DB, contact *entity.Contact) error {
+	return tx.Omit("Phones").Save(contact).Error
+}
+
+// Merge moves the numbers and calls of contacts to another one and deletes
+// them.
+func (dao *ContactRepository) Merge(tx *gorm.DB, target uuid.UUID, sources []uuid.UUID) error {
+	if err := tx.Model(&entity.ContactPhone{}).Where("contact_id IN ?", sources).Update("contact_id", target).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&entity.Call{}).Where("contact_id IN ?", sources).Update("contact_id", target).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN ?", sources).Delete(&entity.Contact{}).Error
+}
+
+// CreateContact creates a contact without its numbers.
+func (dao *ContactRepository) CreateContact(tx *gorm.DB, contact *entity.Contact) error {
+	return tx.Omit("Phones").Create(contact).Error
+}
+
+// MovePhones moves numbers of a contact to another one.
+func (dao *ContactRepository) MovePhones(tx *gorm.DB, from uuid.UUID, to uuid.UUID, numbers []string) error {
+	return tx.Model(&entity.ContactPhone{}).Where("contact_id = ? AND number IN ?", from, numbers).Update("contact_id", to).Error
+}
+
+// GetCallNumbers returns the caller numbers of the calls of a contact.
+func (dao *ContactRepository) GetCallNumbers(tx *gorm.DB, contactId uuid.UUID) ([]CallNumber, error) {
+	var numbers []CallNumber
+	err := tx.Model(&entity.Call{}).Select("id, caller_number").Where("contact_id = ?", contactId).Scan(&numbers).Error
+	return numbers, err
+}
+
+// LinkCalls links calls to a contact.
+func (dao *ContactRepository) LinkCalls(tx *gorm.DB, callIds []uuid.UUID, contactId uuid.UUID) error {
+	if len(callIds) == 0 {
+		return nil
+	}
+	return tx.Model(&entity.Call{}).Where("id IN ?", callIds).Update("contact_id", contactId).Error
+}
+
+// GetUnlinkedCalls returns the calls after an id, in id order, with a caller
+// number but no contact.
+func (dao *ContactRepository) GetUnlinkedCalls(afterId uuid.UUID, limit int) ([]entity.Call, error) {
+	var calls []entity.Call
+	err := dao.db.Select("id, organization_id, caller_number").
+		Where("id > ? AND contact_id IS NULL AND caller_number ~ '[0-9]'", afterId).
+		Order("id").Limit(limit).Find(&calls).Error
+	return calls, err
+}
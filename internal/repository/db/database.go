@@ -1,7 +1,6 @@
 package db
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -16,7 +15,81 @@ import (
 
 var db *gorm.DB
 
+// ConnectDB opens the database and runs the migrations when enabled.
 func ConnectDB() *gorm.DB {
+	db := OpenDB()
+
+	//migrations.RunMigrations(sqlDB)
+	if config.AppConfig.Database.RunMigrations {
+		fmt.Println("Starting migrations...")
+		if err := MigrateDailyScheduleClockTimes(db); err != nil {
+			fmt.Printf("Migration failed: %v\n", err)
+			panic(err)
+		}
+		err := db.AutoMigrate(
+			&entity.User{},
+			&entity.Organization{},
+			&entity.DailySchedule{},
+			&entity.Speciality{},
+			&entity.CatalogVersion{},
+			&entity.Location{},
+			&entity.ScheduleException{},
+			&entity.InsuranceCarrier{},
+			&entity.OrganizationInsurance{},
+			&entity.Notification{},
+			&entity.NotificationPreference{},
+			&entity.NotificationDelivery{},
+			&entity.ForwardingRule{},
+			&entity.AgentProfile{},
+			&entity.AgentProfileVersion{},
+			&entity.AgentSync{},
+			&entity.KnowledgeEntry{},
+			&entity.KnowledgeVariant{},
+			&entity.Contact{},
+			&entity.ContactPhone{},
+			&entity.Call{},
+			&entity.TranscriptTurn{},
+			&entity.CallEvent{},
+			&entity.CallDisposition{},
+			&entity.CallTag{},
+			&entity.CallNote{},
+			&entity.CallChange{},
+			&entity.CallbackRule{},
+			&entity.CallbackTask{},
+			&entity.CallRollup{},
+			&entity.CallTagRollup{},
+			&entity.CallDispositionRollup{},
+			&entity.MovedCall{},
+			&entity.RollupWatermark{},
+		)
+
+		if err == nil {
+			err = MigrateMislabelledPeriodontics(db)
+		}
+		if err == nil {
+			err = SyncSpecialitiesIfOutdated(db)
+		}
+		if err == nil {
+			err = MigrateDefaultLocations(db)
+		}
+		if err == nil {
+			err = SyncInsuranceCarriersIfOutdated(db)
+		}
+		if err == nil {
+			err = MigrateInsuranceCompanies(db)
+		}
+		if err != nil {
+			fmt.Printf("Migration failed: %v\n", err)
+			panic(err)
+		}
+		fmt.Println("Migrations completed successfully.")
+	}
+
+	return db
+}
+
+// OpenDB opens the database connection pool without running migrations.
+func OpenDB() *gorm.DB {
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		config.AppConfig.Database.Host, config.AppConfig.Database.Port, config.AppConfig.Database.Username, config.AppConfig.Database.Password, config.AppConfig.Database.DBName)
 
@@ -44,27 +117,5 @@ func ConnectDB() *gorm.DB {
 		panic("Failed to enable uuid-ossp extension")
 	}
 
-	//migrations.RunMigrations(sqlDB)
-	if config.AppConfig.Database.RunMigrations {
-		fmt.Println("Starting migrations...")
-		err := db.AutoMigrate(
-			&entity.User{},
-			&entity.Organization{},
-			&entity.DailySchedule{},
-			&entity.Speciality{},
-		)
-
-		if err == nil && db.Migrator().HasTable(&entity.Speciality{}) {
-			if err := db.First(&entity.Speciality{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
-				SeedSpecialities(db)
-			}
-		}
-		if err != nil {
-			fmt.Printf("Migration failed: %v\n", err)
-			panic(err)
-		}
-		fmt.Println("Migrations completed successfully.")
-	}
-
 	return db
 }
@@ -0,0 +1,173 @@
+package db
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/textmatch"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsuranceCatalogName identifies the insurance carrier catalog in catalog_versions.
+const InsuranceCatalogName = "insurance_carriers"
+
+// customCarrierPrefix prefixes the codes of carriers created from free text.
+const customCarrierPrefix = "custom."
+
+//go:embed data/insurance_carriers.json
+var insuranceCatalogData []byte
+
+type insuranceCatalogEntry struct {
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	PlanTypes []string `json:"plan_types"`
+	Retired   bool     `json:"retired"`
+}
+
+// InsuranceCatalog is the versioned insurance carrier list bundled with the
+// application.
+type InsuranceCatalog struct {
+	Version  int                     `json:"version"`
+	Carriers []insuranceCatalogEntry `json:"carriers"`
+}
+
+// LoadInsuranceCatalog reads the bundled insurance carrier catalog.
+func LoadInsuranceCatalog() (*InsuranceCatalog, error) {
+	var catalog InsuranceCatalog
+	if err := json.Unmarshal(insuranceCatalogData, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to read insurance catalog: %w", err)
+	}
+	return &catalog, nil
+}
+
+// SyncInsuranceCarriersIfOutdated upserts the bundled carriers by code when
+// the catalog version is newer than the one last applied. Carriers added by
+// admins or migrated from free text are left untouched.
+func SyncInsuranceCarriersIfOutdated(db *gorm.DB) error {
+	catalog, err := LoadInsuranceCatalog()
+	if err != nil {
+		return err
+	}
+	applied, err := appliedCatalogVersion(db, InsuranceCatalogName)
+	if err != nil {
+		return err
+	}
+	if applied >= catalog.Version {
+		return nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range catalog.Carriers {
+			carrier := entity.InsuranceCarrier{
+				Code:      entry.Code,
+				Name:      entry.Name,
+				Aliases:   entry.Aliases,
+				PlanTypes: entry.PlanTypes,
+				Retired:   entry.Retired,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "code"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "aliases", "plan_types", "retired"}),
+			}).Create(&carrier).Error
+			if err != nil {
+				return fmt.Errorf("failed to upsert %s: %w", entry.Code, err)
+			}
+		}
+		version := entity.CatalogVersion{Name: InsuranceCatalogName, Version: catalog.Version, AppliedAt: time.Now()}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync insurance catalog: %w", err)
+	}
+	fmt.Printf("Insurance catalog synced to version %d: %d carriers\n", catalog.Version, len(catalog.Carriers))
+	return nil
+}
+
+// MigrateInsuranceCompanies turns the free-text insurance field of
+// organizations into accepted carriers. Each value is matched on the names and
+// aliases of the catalog; unknown values become custom carriers so nothing is
+// lost. Organizations that already have carriers are skipped.
+func MigrateInsuranceCompanies(db *gorm.DB) error {
+	var organizations []entity.Organization
+	err := db.Where("insurance_company <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM organization_insurances WHERE organization_insurances.organization_id = organizations.id)").
+		Find(&organizations).Error
+	if err != nil {
+		return fmt.Errorf("failed to load organizations with insurance companies: %w", err)
+	}
+	if len(organizations) == 0 {
+		return nil
+	}
+
+	var carriers []entity.InsuranceCarrier
+	if err := db.Find(&carriers).Error; err != nil {
+		return fmt.Errorf("failed to load insurance carriers: %w", err)
+	}
+	byName := map[string]*entity.InsuranceCarrier{}
+	for i := range carriers {
+		for _, name := range append([]string{carriers[i].Name}, carriers[i].Aliases...) {
+			byName[textmatch.Normalize(name)] = &carriers[i]
+		}
+	}
+
+	for _, org := range organizations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			linked := map[int]bool{}
+			for _, value := range splitInsuranceCompanies(org.InsuranceCompany) {
+				key := textmatch.Normalize(value)
+				carrier, ok := byName[key]
+				if !ok {
+					carrier = &entity.InsuranceCarrier{Code: customCarrierPrefix + strings.ReplaceAll(key, " ", "_"), Name: value}
+					err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(carrier).Error
+					if err == nil && carrier.ID == 0 {
+						err = tx.Where("code = ?", carrier.Code).First(carrier).Error
+					}
+					if err != nil {
+						return err
+					}
+					byName[key] = carrier
+				}
+				if linked[carrier.ID] {
+					continue
+				}
+				linked[carrier.ID] = true
+				insurance := entity.OrganizationInsurance{
+					OrganizationID: org.ID,
+					CarrierID:      carrier.ID,
+					Accepted:       true,
+					Notes:          fmt.Sprintf("Migrated from %q", value),
+				}
+				if err := tx.Create(&insurance).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate insurance companies of organization %s: %w", org.ID, err)
+		}
+	}
+	return nil
+}
+
+// splitInsuranceCompanies splits a free-text list such as
+// "Sun Life, Manulife and Blue Cross" into its values.
+func splitInsuranceCompanies(text string) []string {
+	replacer := strings.NewReplacer(" and ", ",", " et ", ",", " & ", ",", ";", ",", "/", ",", "\n", ",")
+	var values []string
+	for _, value := range strings.Split(replacer.Replace(text), ",") {
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(value) {
+		case "", "-", "n/a", "na", "none", "all", "all major", "all insurances", "all major insurances":
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"gorm.io/gorm"
+)
+
+// MigrateDefaultLocations moves the single-office data of organizations that
+// have no location yet into a default location. Organization fields are left
+// untouched so existing clients keep working.
+func MigrateDefaultLocations(db *gorm.DB) error {
+	var organizations []entity.Organization
+	err := db.Preload("Specialities").Preload("DailySchedules").Preload("Users").
+		Where("NOT EXISTS (SELECT 1 FROM locations WHERE locations.organization_id = organizations.id)").
+		Find(&organizations).Error
+	if err != nil {
+		return fmt.Errorf("failed to load organizations without location: %w", err)
+	}
+
+	for _, org := range organizations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			location := entity.Location{
+				OrganizationID:       org.ID,
+				Name:                 entity.DefaultLocationName,
+				IsDefault:            true,
+				PhoneNumber:          org.PhoneNumber,
+				CallForwardingNumber: org.CallForwardingNumber,
+			}
+			if org.Name != "" {
+				location.Name = org.Name
+			}
+			if err := tx.Create(&location).Error; err != nil {
+				return err
+			}
+			if len(org.Specialities) > 0 {
+				if err := tx.Model(&location).Association("Specialities").Append(org.Specialities); err != nil {
+					return err
+				}
+			}
+			if len(org.DailySchedules) > 0 {
+				if err := tx.Model(&location).Association("DailySchedules").Append(org.DailySchedules); err != nil {
+					return err
+				}
+			}
+			if len(org.Users) > 0 {
+				return tx.Model(&location).Association("Users").Append(org.Users)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create default location for organization %s: %w", org.ID, err)
+		}
+	}
+	return nil
+}
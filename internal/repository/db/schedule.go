@@ -0,0 +1,94 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"gorm.io/gorm"
+)
+
+// MigrateDailyScheduleClockTimes converts the legacy timestamp columns of
+// daily_schedules into "HH:MM" wall-clock strings. Each schedule keeps the
+// hours it showed in the time zone of its owner: its location, or the
+// organization of its location, user or organization, or
+// entity.DefaultTimeZone. It must run before AutoMigrate so the conversion
+// keeps the stored hours.
+func MigrateDailyScheduleClockTimes(db *gorm.DB) error {
+	if !db.Migrator().HasTable("daily_schedules") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		timeZone := scheduleTimeZone(tx)
+		for _, column := range []string{"from_time", "to_time"} {
+			var dataType string
+			err := tx.Raw(`SELECT data_type FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'daily_schedules' AND column_name = ?`, column).
+				Scan(&dataType).Error
+			if err != nil {
+				return fmt.Errorf("failed to inspect daily_schedules.%s: %w", column, err)
+			}
+
+			// ALTER COLUMN ... USING cannot look up the owners, so the clock
+			// times go through a new column.
+			var local string
+			switch dataType {
+			case "timestamp with time zone":
+				local = fmt.Sprintf("s.%s AT TIME ZONE %s", column, timeZone)
+			case "timestamp without time zone":
+				local = fmt.Sprintf("(s.%s AT TIME ZONE 'UTC') AT TIME ZONE %s", column, timeZone)
+			default:
+				continue
+			}
+			statements := []string{
+				fmt.Sprintf(`ALTER TABLE daily_schedules ADD COLUMN %s_clock varchar(5)`, column),
+				fmt.Sprintf(`UPDATE daily_schedules s SET %s_clock = to_char(%s, 'HH24:MI')`, column, local),
+				fmt.Sprintf(`ALTER TABLE daily_schedules DROP COLUMN %s`, column),
+				fmt.Sprintf(`ALTER TABLE daily_schedules RENAME COLUMN %[1]s_clock TO %[1]s`, column),
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return fmt.Errorf("failed to convert daily_schedules.%s: %w", column, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// scheduleTimeZone returns the SQL expression giving the time zone of the
+// owner of the daily schedule s. The legacy tables predate the time zones of
+// organizations and locations, so it only uses the ones that exist.
+func scheduleTimeZone(tx *gorm.DB) string {
+	migrator := tx.Migrator()
+	organizationZone := "NULL"
+	if migrator.HasColumn("organizations", "time_zone") {
+		organizationZone = "NULLIF(o.time_zone, '')"
+	}
+
+	var owners []string
+	if migrator.HasTable("location_daily_schedules") && migrator.HasTable("locations") {
+		locationZone := organizationZone
+		if migrator.HasColumn("locations", "time_zone") {
+			locationZone = fmt.Sprintf("COALESCE(NULLIF(l.time_zone, ''), %s)", organizationZone)
+		}
+		owners = append(owners, fmt.Sprintf(`(SELECT %s FROM location_daily_schedules ls
+			JOIN locations l ON l.id = ls.location_id
+			LEFT JOIN organizations o ON o.id = l.organization_id
+			WHERE ls.daily_schedule_id = s.id LIMIT 1)`, locationZone))
+	}
+	if migrator.HasTable("user_dailey_schedules") {
+		owners = append(owners, fmt.Sprintf(`(SELECT %s FROM user_dailey_schedules us
+			JOIN users u ON u.id = us.user_id
+			JOIN organizations o ON o.id = u.organization_id
+			WHERE us.daily_schedule_id = s.id LIMIT 1)`, organizationZone))
+	}
+	if migrator.HasTable("organization_dailey_schedules") {
+		owners = append(owners, fmt.Sprintf(`(SELECT %s FROM organization_dailey_schedules os
+			JOIN organizations o ON o.id = os.organization_id
+			WHERE os.daily_schedule_id = s.id LIMIT 1)`, organizationZone))
+	}
+	owners = append(owners, fmt.Sprintf("'%s'", entity.DefaultTimeZone))
+	return "COALESCE(" + strings.Join(owners, ", ") + ")"
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// openTestDB connects to the Postgres database of COMVOCA_TEST_DATABASE_URL in
+// a schema of its own, dropped at the end of the test.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("COMVOCA_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("COMVOCA_TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the search path is set per connection
+	sqlDB.SetMaxOpenConns(1)
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if err := db.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE")
+		sqlDB.Close()
+	})
+	if err := db.Exec("SET search_path TO " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestMigrateDailyScheduleClockTimes(t *testing.T) {
+	// every schedule opened at 17:00 UTC and closed at 01:30 UTC the next day
+	// in January, that is 09:00-17:30 in Vancouver, 12:00-20:30 in Toronto and
+	// 13:00-21:30 in Halifax
+	tests := []struct {
+		name             string
+		columnType       string
+		from             string
+		to               string
+		organizationZone *string
+		locationZone     string
+		want             map[string]string
+	}{
+		{
+			name:             "organization in Vancouver and location in Halifax",
+			columnType:       "timestamp with time zone",
+			from:             "2024-01-08 17:00:00+00",
+			to:               "2024-01-09 01:30:00+00",
+			organizationZone: ptr("America/Vancouver"),
+			locationZone:     "America/Halifax",
+			want:             map[string]string{"organization": "09:00-17:30", "user": "09:00-17:30", "location": "13:00-21:30", "none": "12:00-20:30"},
+		},
+		{
+			name:             "location without time zone",
+			columnType:       "timestamp with time zone",
+			from:             "2024-01-08 17:00:00+00",
+			to:               "2024-01-09 01:30:00+00",
+			organizationZone: ptr("America/Vancouver"),
+			want:             map[string]string{"organization": "09:00-17:30", "user": "09:00-17:30", "location": "09:00-17:30", "none": "12:00-20:30"},
+		},
+		{
+			name:       "before organizations had a time zone",
+			columnType: "timestamp with time zone",
+			from:       "2024-01-08 17:00:00+00",
+			to:         "2024-01-09 01:30:00+00",
+			want:       map[string]string{"organization": "12:00-20:30", "user": "12:00-20:30", "location": "12:00-20:30", "none": "12:00-20:30"},
+		},
+		{
+			name:             "timestamps without time zone in UTC",
+			columnType:       "timestamp without time zone",
+			from:             "2024-01-08 17:00:00",
+			to:               "2024-01-09 01:30:00",
+			organizationZone: ptr("America/Vancouver"),
+			locationZone:     "America/Halifax",
+			want:             map[string]string{"organization": "09:00-17:30", "user": "09:00-17:30", "location": "13:00-21:30", "none": "12:00-20:30"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			zoneColumn := ""
+			if tt.organizationZone != nil {
+				zoneColumn = ", time_zone text"
+			}
+			exec(t, db, fmt.Sprintf("CREATE TABLE daily_schedules (id uuid PRIMARY KEY, day_of_week int, from_time %[1]s, to_time %[1]s)", tt.columnType))
+			exec(t, db, "CREATE TABLE organizations (id uuid PRIMARY KEY"+zoneColumn+")")
+			exec(t, db, "CREATE TABLE users (id uuid PRIMARY KEY, organization_id uuid)")
+			exec(t, db, "CREATE TABLE locations (id uuid PRIMARY KEY, organization_id uuid"+zoneColumn+")")
+			exec(t, db, "CREATE TABLE organization_dailey_schedules (organization_id uuid, daily_schedule_id uuid)")
+			exec(t, db, "CREATE TABLE user_dailey_schedules (user_id uuid, daily_schedule_id uuid)")
+			exec(t, db, "CREATE TABLE location_daily_schedules (location_id uuid, daily_schedule_id uuid)")
+
+			organizationId, userId, locationId := uuid.New(), uuid.New(), uuid.New()
+			if tt.organizationZone != nil {
+				exec(t, db, "INSERT INTO organizations VALUES (?, ?)", organizationId, *tt.organizationZone)
+				exec(t, db, "INSERT INTO locations VALUES (?, ?, ?)", locationId, organizationId, tt.locationZone)
+			} else {
+				exec(t, db, "INSERT INTO organizations VALUES (?)", organizationId)
+				exec(t, db, "INSERT INTO locations VALUES (?, ?)", locationId, organizationId)
+			}
+			exec(t, db, "INSERT INTO users VALUES (?, ?)", userId, organizationId)
+
+			schedules := map[string]uuid.UUID{}
+			for owner := range tt.want {
+				schedules[owner] = uuid.New()
+				exec(t, db, "INSERT INTO daily_schedules VALUES (?, 1, ?, ?)", schedules[owner], tt.from, tt.to)
+			}
+			exec(t, db, "INSERT INTO organization_dailey_schedules VALUES (?, ?)", organizationId, schedules["organization"])
+			exec(t, db, "INSERT INTO user_dailey_schedules VALUES (?, ?)", userId, schedules["user"])
+			exec(t, db, "INSERT INTO location_daily_schedules VALUES (?, ?)", locationId, schedules["location"])
+
+			if err := MigrateDailyScheduleClockTimes(db); err != nil {
+				t.Fatal(err)
+			}
+			// a second run leaves the converted columns alone
+			if err := MigrateDailyScheduleClockTimes(db); err != nil {
+				t.Fatal(err)
+			}
+
+			for owner, want := range tt.want {
+				var got struct{ FromTime, ToTime string }
+				if err := db.Raw("SELECT from_time, to_time FROM daily_schedules WHERE id = ?", schedules[owner]).Scan(&got).Error; err != nil {
+					t.Fatal(err)
+				}
+				if got.FromTime+"-"+got.ToTime != want {
+					t.Errorf("%s schedule = %s-%s, want %s", owner, got.FromTime, got.ToTime, want)
+				}
+			}
+		})
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
+
+func exec(t *testing.T, db *gorm.DB, sql string, values ...interface{}) {
+	t.Helper()
+	if err := db.Exec(sql, values...).Error; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,56 +1,291 @@
 package db
 
 import (
+	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func SeedSpecialities(db *gorm.DB) error {
-	specialities := map[string][]string{
-		"General Dentistry": {
-			"Dental Cleaning", "Dental Examination", "Dentail X-rays", "Deep Cleaning",
-			"Dental Filings", "Root scaling and planing", "Fluoride Treatments", "Dental Sealants",
-			"Cosmetic Dentistry", "Oral Surgery",
-		},
-		"Orthodontics Office": {
-			"Diagnosis and Assessment", "Oral Examination", "Dental X-rays", "Diagnostic Models",
-		},
-		"Paediatrics Office": {
-			"Gum Disease Treatment", "Scaling and Root Planing", "Soft Tissue Surgery",
-			"Laser Therapy", "Bone Grafting", "Dental Implant Placement", "Implant Surgery",
-			"Bone Grafting (for implant placement)", "Cosmetic Periodental Procedures",
-			"Gum Contouring", "Gummy Smile Correction",
-		},
-		"Endodontics Office": {
-			"Root Canal Therapy", "Root Canal Treatment", "Retreatment", "Apicoectomy",
-			"Trauma Treatment", "Treatment of Dental Injuries",
-		},
-		"Prosthodontics Offices": {
-			"Dental Implants", "Implant placement surgery", "Bone grating to prepare for implant placement",
-			"Abutment placement", "Crown placement", "Dentures", "Crown and Bridges",
-		},
-		"Oral and Maxillofacial Surgery": {
-			"Dental Procedures", "Wisdom Tooth Extraction", "Dental Implant Surgery", "Bone Grafting",
-			"Soft Tissue Grafting", "Tooth Extractions", "Facial Trauma Surgery", "Facial Fracture Repair",
-			"Soft Tissue Repair", "Orthognathic Surgery", "Jaw Surgery", "Cleft Lip and Palate Repair",
-			"Cosmetic Procedures", "Facial Cosmetic Surgery", "Dental Implants for Cosmetic Purposes",
-		},
-	}
-
-	for parentName, childNames := range specialities {
-		parent := entity.Speciality{Name: parentName}
-		if err := db.Create(&parent).Error; err != nil {
-			return fmt.Errorf("failed to insert parent %s: %w", parentName, err)
-		}
-
-		for _, childName := range childNames {
-			child := entity.Speciality{Name: childName, ParentID: &parent.ID}
-			if err := db.Create(&child).Error; err != nil {
-				return fmt.Errorf("failed to insert child %s: %w", childName, err)
+// SpecialityCatalogName identifies the speciality catalog in catalog_versions.
+const SpecialityCatalogName = "specialities"
+
+//go:embed data/specialities.json
+var specialityCatalogData []byte
+
+// errDryRun rolls back the sync transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+type specialityCatalogEntry struct {
+	Code          string                   `json:"code"`
+	Name          string                   `json:"name"`
+	PreviousNames []string                 `json:"previous_names"`
+	Deprecated    bool                     `json:"deprecated"`
+	Children      []specialityCatalogEntry `json:"children"`
+}
+
+// SpecialityCatalog is the versioned speciality taxonomy bundled with the
+// application.
+type SpecialityCatalog struct {
+	Version      int                      `json:"version"`
+	Specialities []specialityCatalogEntry `json:"specialities"`
+}
+
+// SpecialityRename describes a speciality whose name or parent changed.
+type SpecialityRename struct {
+	Code string `json:"code"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// SpecialitySyncReport lists what a catalog sync changed, or would change for
+// a dry run.
+type SpecialitySyncReport struct {
+	Version        int                `json:"version"`
+	AppliedVersion int                `json:"applied_version"`
+	DryRun         bool               `json:"dry_run"`
+	Added          []string           `json:"added"`
+	Renamed        []SpecialityRename `json:"renamed"`
+	Reparented     []SpecialityRename `json:"reparented"`
+	Deprecated     []string           `json:"deprecated"`
+	Restored       []string           `json:"restored"`
+	Unchanged      int                `json:"unchanged"`
+	Unmanaged      []string           `json:"unmanaged"`
+}
+
+// HasChanges reports whether the sync touched the database.
+func (r SpecialitySyncReport) HasChanges() bool {
+	return len(r.Added)+len(r.Renamed)+len(r.Reparented)+len(r.Deprecated)+len(r.Restored) > 0
+}
+
+// LoadSpecialityCatalog reads the bundled speciality catalog.
+func LoadSpecialityCatalog() (*SpecialityCatalog, error) {
+	var catalog SpecialityCatalog
+	if err := json.Unmarshal(specialityCatalogData, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to read speciality catalog: %w", err)
+	}
+	return &catalog, nil
+}
+
+// SyncSpecialitiesIfOutdated syncs the bundled catalog when its version is
+// newer than the one last applied, so admin edits are not reverted on every
+// start.
+func SyncSpecialitiesIfOutdated(db *gorm.DB) error {
+	catalog, err := LoadSpecialityCatalog()
+	if err != nil {
+		return err
+	}
+	applied, err := appliedCatalogVersion(db, SpecialityCatalogName)
+	if err != nil {
+		return err
+	}
+	if applied >= catalog.Version {
+		return nil
+	}
+
+	report, err := SyncSpecialities(db, catalog, false)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Speciality catalog synced to version %d: %d added, %d renamed, %d reparented, %d deprecated\n",
+		report.Version, len(report.Added), len(report.Renamed), len(report.Reparented), len(report.Deprecated))
+	return nil
+}
+
+// MigrateMislabelledPeriodontics renames the "Paediatrics Office" root of the
+// legacy seed to "Periodontics Office". The legacy seed listed the periodontal
+// procedures under that name, so the row is the periodontics speciality and
+// the catalog sync then matches it by name. It is told apart from a genuine
+// paediatrics speciality by its seeded "Gum Disease Treatment" child. It must
+// run before the sync, which would otherwise add periodontics anew.
+func MigrateMislabelledPeriodontics(db *gorm.DB) error {
+	err := db.Model(&entity.Speciality{}).
+		Where("name = ? AND parent_id IS NULL AND COALESCE(code, '') = ''", "Paediatrics Office").
+		Where("EXISTS (SELECT 1 FROM specialities child WHERE child.parent_id = specialities.id AND child.name = ?)", "Gum Disease Treatment").
+		Update("name", "Periodontics Office").Error
+	if err != nil {
+		return fmt.Errorf("failed to rename the legacy periodontics speciality: %w", err)
+	}
+	return nil
+}
+
+// SyncSpecialities upserts the catalog into the specialities table. Rows are
+// matched by code, then legacy rows without a code by their current or
+// previous names under the same parent. Nothing is ever deleted: entries
+// removed from use are deprecated, which retires them. Rows that are not part
+// of the catalog are reported as unmanaged and left untouched.
+func SyncSpecialities(db *gorm.DB, catalog *SpecialityCatalog, dryRun bool) (*SpecialitySyncReport, error) {
+	applied, err := appliedCatalogVersion(db, SpecialityCatalogName)
+	if err != nil {
+		return nil, err
+	}
+	report := &SpecialitySyncReport{
+		Version:        catalog.Version,
+		AppliedVersion: applied,
+		DryRun:         dryRun,
+		Added:          []string{},
+		Renamed:        []SpecialityRename{},
+		Reparented:     []SpecialityRename{},
+		Deprecated:     []string{},
+		Restored:       []string{},
+		Unmanaged:      []string{},
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var rows []entity.Speciality
+		if err := tx.Order("id").Find(&rows).Error; err != nil {
+			return err
+		}
+		syncer := specialitySyncer{tx: tx, report: report, rows: rows, matched: map[int]bool{}}
+		for _, entry := range catalog.Specialities {
+			if err := syncer.sync(entry, nil, ""); err != nil {
+				return err
+			}
+		}
+		for _, row := range syncer.rows {
+			if !syncer.matched[row.ID] {
+				report.Unmanaged = append(report.Unmanaged, row.Name)
+			}
+		}
+
+		version := entity.CatalogVersion{Name: SpecialityCatalogName, Version: catalog.Version, AppliedAt: time.Now()}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&version).Error; err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, fmt.Errorf("failed to sync speciality catalog: %w", err)
+	}
+	return report, nil
+}
+
+type specialitySyncer struct {
+	tx      *gorm.DB
+	report  *SpecialitySyncReport
+	rows    []entity.Speciality
+	matched map[int]bool
+}
+
+func (s *specialitySyncer) sync(entry specialityCatalogEntry, parent *entity.Speciality, parentName string) error {
+	var parentID *int
+	if parent != nil {
+		parentID = &parent.ID
+	}
+
+	row := s.find(entry, parentID)
+	if row == nil {
+		created := entity.Speciality{Code: entry.Code, Name: entry.Name, ParentID: parentID, Retired: entry.Deprecated}
+		if err := s.tx.Create(&created).Error; err != nil {
+			return fmt.Errorf("failed to insert %s: %w", entry.Code, err)
+		}
+		s.rows = append(s.rows, created)
+		s.matched[created.ID] = true
+		s.report.Added = append(s.report.Added, entry.Code)
+		row = &s.rows[len(s.rows)-1]
+	} else {
+		s.matched[row.ID] = true
+		changed := row.Code != entry.Code
+		if row.Name != entry.Name {
+			s.report.Renamed = append(s.report.Renamed, SpecialityRename{Code: entry.Code, From: row.Name, To: entry.Name})
+			changed = true
+		}
+		if specialityParentKey(row.ParentID) != specialityParentKey(parentID) {
+			s.report.Reparented = append(s.report.Reparented, SpecialityRename{Code: entry.Code, From: s.nameOf(row.ParentID), To: parentName})
+			changed = true
+		}
+		if row.Retired != entry.Deprecated {
+			if entry.Deprecated {
+				s.report.Deprecated = append(s.report.Deprecated, entry.Code)
+			} else {
+				s.report.Restored = append(s.report.Restored, entry.Code)
+			}
+			changed = true
+		}
+
+		if changed {
+			row.Code = entry.Code
+			row.Name = entry.Name
+			row.ParentID = parentID
+			row.Retired = entry.Deprecated
+			err := s.tx.Model(&entity.Speciality{}).Where("id = ?", row.ID).
+				Updates(map[string]interface{}{"code": row.Code, "name": row.Name, "parent_id": row.ParentID, "retired": row.Retired}).Error
+			if err != nil {
+				return fmt.Errorf("failed to update %s: %w", entry.Code, err)
 			}
+		} else {
+			s.report.Unchanged++
+		}
+	}
+
+	current := *row
+	for _, child := range entry.Children {
+		if entry.Deprecated {
+			child.Deprecated = true
+		}
+		if err := s.sync(child, &current, entry.Name); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
+// find looks a catalog entry up by code, then among the legacy rows without
+// code by name under the same parent.
+func (s *specialitySyncer) find(entry specialityCatalogEntry, parentID *int) *entity.Speciality {
+	for i := range s.rows {
+		if s.rows[i].Code == entry.Code {
+			return &s.rows[i]
+		}
+	}
+	names := append([]string{entry.Name}, entry.PreviousNames...)
+	for i := range s.rows {
+		row := &s.rows[i]
+		if row.Code != "" || s.matched[row.ID] || specialityParentKey(row.ParentID) != specialityParentKey(parentID) {
+			continue
+		}
+		for _, name := range names {
+			if strings.EqualFold(strings.TrimSpace(row.Name), name) {
+				return row
+			}
+		}
+	}
 	return nil
 }
+
+func (s *specialitySyncer) nameOf(id *int) string {
+	if id == nil {
+		return ""
+	}
+	for _, row := range s.rows {
+		if row.ID == *id {
+			return row.Name
+		}
+	}
+	return ""
+}
+
+func specialityParentKey(parentID *int) int {
+	if parentID == nil {
+		return 0
+	}
+	return *parentID
+}
+
+func appliedCatalogVersion(db *gorm.DB, name string) (int, error) {
+	var version entity.CatalogVersion
+	err := db.Where("name = ?", name).Limit(1).Find(&version).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to read catalog version: %w", err)
+	}
+	return version.Version, nil
+}
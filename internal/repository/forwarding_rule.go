@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ForwardingRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewForwardingRuleRepo(db *gorm.DB) *ForwardingRuleRepository {
+	return &ForwardingRuleRepository{db: db}
+}
+
+func (dao *ForwardingRuleRepository) GetById(organizationId string, id string) (entity.ForwardingRule, error) {
+	var rule entity.ForwardingRule
+
+	tx := dao.db.First(&rule, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return rule, fmt.Errorf("forwarding rule not found")
+		}
+	}
+	return rule, tx.Error
+}
+
+// GetByOrganization returns the rules of an organization in evaluation order.
+func (dao *ForwardingRuleRepository) GetByOrganization(organizationId string) ([]entity.ForwardingRule, error) {
+	var rules []entity.ForwardingRule
+	err := dao.db.Where("organization_id = ?", organizationId).Order("priority, created_at").Find(&rules).Error
+	return rules, err
+}
+
+// NextPriority returns the priority placing a new rule after the existing ones.
+func (dao *ForwardingRuleRepository) NextPriority(organizationId uuid.UUID) (int, error) {
+	var priority *int
+	err := dao.db.Model(&entity.ForwardingRule{}).Where("organization_id = ?", organizationId).
+		Select("MAX(priority)").Scan(&priority).Error
+	if err != nil || priority == nil {
+		return 0, err
+	}
+	return *priority + 1, nil
+}
+
+func (dao *ForwardingRuleRepository) Save(rule *entity.ForwardingRule) error {
+	return dao.db.Create(rule).Error
+}
+
+func (dao *ForwardingRuleRepository) Update(rule *entity.ForwardingRule) error {
+	return dao.db.Save(rule).Error
+}
+
+func (dao *ForwardingRuleRepository) Delete(rule *entity.ForwardingRule) error {
+	return dao.db.Delete(rule).Error
+}
+
+// Reorder sets the priorities of the rules to their position in ids.
+func (dao *ForwardingRuleRepository) Reorder(tx *gorm.DB, organizationId uuid.UUID, ids []uuid.UUID) error {
+	for i, id := range ids {
+		err := tx.Model(&entity.ForwardingRule{}).
+			Where("id = ? AND organization_id = ?", id, organizationId).
+			Update("priority", i).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
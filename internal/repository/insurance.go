@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InsuranceRepository struct {
+	db *gorm.DB
+}
+
+func NewInsuranceRepo(db *gorm.DB) *InsuranceRepository {
+	return &InsuranceRepository{db: db}
+}
+
+func (dao *InsuranceRepository) GetCarrierById(id int) (entity.InsuranceCarrier, error) {
+	var carrier entity.InsuranceCarrier
+
+	tx := dao.db.First(&carrier, "id = ?", id)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return carrier, fmt.Errorf("insurance carrier not found")
+		}
+	}
+	return carrier, tx.Error
+}
+
+func (dao *InsuranceRepository) GetCarrierByCode(code string) (entity.InsuranceCarrier, error) {
+	var carrier entity.InsuranceCarrier
+
+	tx := dao.db.First(&carrier, "code = ?", code)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return carrier, fmt.Errorf("insurance carrier not found")
+		}
+	}
+	return carrier, tx.Error
+}
+
+func (dao *InsuranceRepository) ExistsCarrierCode(code string) (bool, error) {
+	var count int64
+	err := dao.db.Model(&entity.InsuranceCarrier{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// GetCarriers returns the carriers ordered by name, retired ones included only
+// when asked for.
+func (dao *InsuranceRepository) GetCarriers(includeRetired bool) ([]entity.InsuranceCarrier, error) {
+	var carriers []entity.InsuranceCarrier
+	query := dao.db.Order("name")
+	if !includeRetired {
+		query = query.Where("retired = ?", false)
+	}
+	err := query.Find(&carriers).Error
+	return carriers, err
+}
+
+func (dao *InsuranceRepository) SaveCarrier(carrier *entity.InsuranceCarrier) error {
+	return dao.db.Create(carrier).Error
+}
+
+func (dao *InsuranceRepository) UpdateCarrier(carrier *entity.InsuranceCarrier) error {
+	return dao.db.Model(carrier).Select("Name", "Aliases", "PlanTypes", "Retired").Updates(carrier).Error
+}
+
+// GetByOrganization returns the carriers of an organization. With a location,
+// both the organization-wide and the location entries are returned.
+func (dao *InsuranceRepository) GetByOrganization(organizationId string, locationId string) ([]entity.OrganizationInsurance, error) {
+	var insurances []entity.OrganizationInsurance
+	query := dao.db.Preload("Carrier").Where("organization_id = ?", organizationId)
+	if locationId != "" {
+		query = query.Where("location_id IS NULL OR location_id = ?", locationId)
+	} else {
+		query = query.Where("location_id IS NULL")
+	}
+	err := query.Find(&insurances).Error
+	return insurances, err
+}
+
+// Replace swaps the carriers of an organization, or of one of its locations,
+// for the given ones.
+func (dao *InsuranceRepository) Replace(tx *gorm.DB, organizationId uuid.UUID, locationId *uuid.UUID, insurances []entity.OrganizationInsurance) error {
+	query := tx.Where("organization_id = ?", organizationId)
+	if locationId != nil {
+		query = query.Where("location_id = ?", *locationId)
+	} else {
+		query = query.Where("location_id IS NULL")
+	}
+	if err := query.Delete(&entity.OrganizationInsurance{}).Error; err != nil {
+		return err
+	}
+	if len(insurances) == 0 {
+		return nil
+	}
+	return tx.Omit("Carrier").Create(&insurances).Error
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// knowledgeConfig is the text search configuration of a variant, named after
+// its language.
+var knowledgeConfig = fmt.Sprintf("(CASE v.language WHEN %d THEN 'french' WHEN %d THEN 'spanish' ELSE 'english' END)::regconfig",
+	entity.French, entity.Spanish)
+
+// knowledgeSearch ranks the variants matching any word of the query. Titles
+// and keywords weigh more than categories, and categories more than bodies.
+var knowledgeSearch = `
+SELECT m.entry_id, MAX(ts_rank(m.document, m.query)) AS rank
+FROM (
+	SELECT v.entry_id, e.position,
+		setweight(to_tsvector(` + knowledgeConfig + `, v.title), 'A') ||
+		setweight(to_tsvector(` + knowledgeConfig + `, coalesce(array_to_string(v.keywords, ' '), '')), 'A') ||
+		setweight(to_tsvector(` + knowledgeConfig + `, e.category), 'B') ||
+		setweight(to_tsvector(` + knowledgeConfig + `, v.body), 'C') AS document,
+		NULLIF(replace(plainto_tsquery(` + knowledgeConfig + `, @query)::text, ' & ', ' | '), '')::tsquery AS query
+	FROM knowledge_variants v
+	JOIN knowledge_entries e ON e.id = v.entry_id
+	WHERE e.organization_id = @organization AND e.enabled AND (@category = '' OR e.category = @category)
+) m
+WHERE m.document @@ m.query
+GROUP BY m.entry_id, m.position
+ORDER BY rank DESC, m.position
+LIMIT @limit`
+
+type KnowledgeRepository struct {
+	db *gorm.DB
+}
+
+func NewKnowledgeRepo(db *gorm.DB) *KnowledgeRepository {
+	return &KnowledgeRepository{db: db}
+}
+
+// KnowledgeMatch is an entry found by a search, with its relevance.
+type KnowledgeMatch struct {
+	EntryID uuid.UUID
+	Rank    float64
+}
+
+func (dao *KnowledgeRepository) GetById(organizationId string, id string) (entity.KnowledgeEntry, error) {
+	var entry entity.KnowledgeEntry
+
+	tx := dao.db.Preload("Variants", orderVariants).First(&entry, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return entry, fmt.Errorf("knowledge entry not found")
+		}
+	}
+	return entry, tx.Error
+}
+
+// GetByOrganization returns the entries of an organization in order,
+// optionally restricted to a kind or a category. With enabledOnly, disabled
+// entries are left out.
+func (dao *KnowledgeRepository) GetByOrganization(organizationId string, kind string, category string, enabledOnly bool) ([]entity.KnowledgeEntry, error) {
+	var entries []entity.KnowledgeEntry
+	query := dao.db.Preload("Variants", orderVariants).Where("organization_id = ?", organizationId)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if enabledOnly {
+		query = query.Where("enabled")
+	}
+	err := query.Order("position, created_at").Find(&entries).Error
+	return entries, err
+}
+
+// GetByIds returns the entries of an organization with the given ids, in the
+// order of ids.
+func (dao *KnowledgeRepository) GetByIds(organizationId string, ids []uuid.UUID) ([]entity.KnowledgeEntry, error) {
+	var entries []entity.KnowledgeEntry
+	err := dao.db.Preload("Variants", orderVariants).
+		Where("organization_id = ? AND id IN ?", organizationId, ids).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[uuid.UUID]entity.KnowledgeEntry, len(entries))
+	for _, entry := range entries {
+		byId[entry.ID] = entry
+	}
+	ordered := make([]entity.KnowledgeEntry, 0, len(entries))
+	for _, id := range ids {
+		if entry, ok := byId[id]; ok {
+			ordered = append(ordered, entry)
+		}
+	}
+	return ordered, nil
+}
+
+// GetCategories returns the categories used by an organization, sorted.
+func (dao *KnowledgeRepository) GetCategories(organizationId string) ([]string, error) {
+	var categories []string
+	err := dao.db.Model(&entity.KnowledgeEntry{}).
+		Where("organization_id = ? AND category <> ''", organizationId).
+		Distinct("category").Order("category").Pluck("category", &categories).Error
+	return categories, err
+}
+
+// Search returns the enabled entries of an organization matching a query,
+// most relevant first. Each variant is searched with the text search
+// configuration of its language, so words are stemmed the way they are
+// written.
+func (dao *KnowledgeRepository) Search(organizationId string, query string, category string, limit int) ([]KnowledgeMatch, error) {
+	var matches []KnowledgeMatch
+	err := dao.db.Raw(knowledgeSearch, map[string]interface{}{
+		"query":        query,
+		"organization": organizationId,
+		"category":     category,
+		"limit":        limit,
+	}).Scan(&matches).Error
+	return matches, err
+}
+
+// NextPosition returns the position placing a new entry after the existing ones.
+func (dao *KnowledgeRepository) NextPosition(organizationId uuid.UUID) (int, error) {
+	var position *int
+	err := dao.db.Model(&entity.KnowledgeEntry{}).Where("organization_id = ?", organizationId).
+		Select("MAX(position)").Scan(&position).Error
+	if err != nil || position == nil {
+		return 0, err
+	}
+	return *position + 1, nil
+}
+
+// Save creates an entry with its variants.
+func (dao *KnowledgeRepository) Save(tx *gorm.DB, entry *entity.KnowledgeEntry) error {
+	return tx.Create(entry).Error
+}
+
+// Update saves an entry and replaces its variants.
+func (dao *KnowledgeRepository) Update(tx *gorm.DB, entry *entity.KnowledgeEntry) error {
+	if err := tx.Where("entry_id = ?", entry.ID).Delete(&entity.KnowledgeVariant{}).Error; err != nil {
+		return err
+	}
+	for i := range entry.Variants {
+		entry.Variants[i].ID = uuid.Nil
+		entry.Variants[i].EntryID = entry.ID
+	}
+	if len(entry.Variants) > 0 {
+		if err := tx.Create(&entry.Variants).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Omit("Variants").Save(entry).Error
+}
+
+// Delete removes an entry with its variants.
+func (dao *KnowledgeRepository) Delete(tx *gorm.DB, entry *entity.KnowledgeEntry) error {
+	if err := tx.Where("entry_id = ?", entry.ID).Delete(&entity.KnowledgeVariant{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(entry).Error
+}
+
+// Reorder sets the positions of the entries to their index in ids.
+func (dao *KnowledgeRepository) Reorder(tx *gorm.DB, organizationId uuid.UUID, ids []uuid.UUID) error {
+	for i, id := range ids {
+		err := tx.Model(&entity.KnowledgeEntry{}).
+			Where("id = ? AND organization_id = ?", id, organizationId).
+			Update("position", i).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orderVariants(db *gorm.DB) *gorm.DB {
+	return db.Order("language")
+}
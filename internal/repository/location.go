@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"gorm.io/gorm"
+)
+
+type LocationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepo(db *gorm.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+func (dao *LocationRepository) GetById(organizationId string, locationId string) (entity.Location, error) {
+	var location entity.Location
+
+	tx := dao.db.Preload("Specialities").Preload("DailySchedules").Preload("Users").
+		First(&location, "id = ? AND organization_id = ?", locationId, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return location, fmt.Errorf("location not found")
+		}
+	}
+	return location, tx.Error
+}
+
+func (dao *LocationRepository) GetByOrganization(organizationId string) ([]entity.Location, error) {
+	var locations []entity.Location
+	err := dao.db.Preload("Specialities").Preload("DailySchedules").Preload("Users").
+		Where("organization_id = ?", organizationId).
+		Order("is_default DESC, name").
+		Find(&locations).Error
+	return locations, err
+}
+
+func (dao *LocationRepository) GetDefault(organizationId string) (entity.Location, error) {
+	var location entity.Location
+	tx := dao.db.First(&location, "organization_id = ? AND is_default = ?", organizationId, true)
+	if tx.Error == gorm.ErrRecordNotFound {
+		return location, fmt.Errorf("default location not found")
+	}
+	return location, tx.Error
+}
+
+func (dao *LocationRepository) Save(tx *gorm.DB, location *entity.Location) error {
+	return tx.Create(location).Error
+}
+
+func (dao *LocationRepository) Update(tx *gorm.DB, location *entity.Location) error {
+	return tx.Save(location).Error
+}
+
+func (dao *LocationRepository) Delete(tx *gorm.DB, location *entity.Location) error {
+	if err := tx.Model(location).Association("Specialities").Clear(); err != nil {
+		return err
+	}
+	if err := tx.Model(location).Association("DailySchedules").Clear(); err != nil {
+		return err
+	}
+	if err := tx.Model(location).Association("Users").Clear(); err != nil {
+		return err
+	}
+	if err := tx.Where("location_id = ?", location.ID).Delete(&entity.OrganizationInsurance{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("location_id = ?", location.ID).Delete(&entity.ForwardingRule{}).Error; err != nil {
+		return err
+	}
+	profiles := tx.Model(&entity.AgentProfile{}).Select("id").Where("location_id = ?", location.ID)
+	if err := tx.Where("profile_id IN (?)", profiles).Delete(&entity.AgentProfileVersion{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("location_id = ?", location.ID).Delete(&entity.AgentProfile{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(location).Error
+}
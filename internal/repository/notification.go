@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// SaveNotification stores an in-app notification.
+func (dao *NotificationRepository) SaveNotification(notification *entity.Notification) error {
+	return dao.db.Create(notification).Error
+}
+
+// GetNotifications returns a page of the in-app notifications of a user,
+// newest first, with the total number of matching notifications.
+func (dao *NotificationRepository) GetNotifications(userId string, unreadOnly bool, limit int, offset int) ([]entity.Notification, int64, error) {
+	query := dao.db.Model(&entity.Notification{}).Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var notifications []entity.Notification
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error
+	return notifications, total, err
+}
+
+func (dao *NotificationRepository) GetNotification(userId string, id string) (entity.Notification, error) {
+	var notification entity.Notification
+
+	tx := dao.db.First(&notification, "id = ? AND user_id = ?", id, userId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return notification, fmt.Errorf("notification not found")
+		}
+	}
+	return notification, tx.Error
+}
+
+func (dao *NotificationRepository) CountUnread(userId string) (int64, error) {
+	var count int64
+	err := dao.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).Count(&count).Error
+	return count, err
+}
+
+func (dao *NotificationRepository) MarkRead(notification *entity.Notification) error {
+	return dao.db.Model(notification).Update("read_at", notification.ReadAt).Error
+}
+
+func (dao *NotificationRepository) MarkAllRead(userId string, at time.Time) (int64, error) {
+	tx := dao.db.Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).Update("read_at", at)
+	return tx.RowsAffected, tx.Error
+}
+
+func (dao *NotificationRepository) GetPreferences(userId string) ([]entity.NotificationPreference, error) {
+	var preferences []entity.NotificationPreference
+	err := dao.db.Where("user_id = ?", userId).Order("event").Find(&preferences).Error
+	return preferences, err
+}
+
+// ReplacePreferences swaps the notification preferences of a user for the
+// given ones.
+func (dao *NotificationRepository) ReplacePreferences(tx *gorm.DB, userId uuid.UUID, preferences []entity.NotificationPreference) error {
+	if err := tx.Where("user_id = ?", userId).Delete(&entity.NotificationPreference{}).Error; err != nil {
+		return err
+	}
+	if len(preferences) == 0 {
+		return nil
+	}
+	return tx.Create(&preferences).Error
+}
+
+func (dao *NotificationRepository) SaveDeliveries(deliveries []entity.NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return dao.db.Create(&deliveries).Error
+}
+
+func (dao *NotificationRepository) UpdateDelivery(delivery *entity.NotificationDelivery) error {
+	return dao.db.Model(delivery).
+		Select("Status", "Attempts", "LastError", "NextAttemptAt", "SentAt").
+		Updates(delivery).Error
+}
+
+// ClaimDueDeliveries locks the pending deliveries due by now and pushes their
+// next attempt past the lease, so concurrent workers do not send them twice
+// and a crashed worker's deliveries are picked up again later.
+func (dao *NotificationRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]entity.NotificationDelivery, error) {
+	var deliveries []entity.NotificationDelivery
+	err := dao.db.Raw(`
+		UPDATE notification_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), entity.DeliveryPending, now, limit).
+		Scan(&deliveries).Error
+	return deliveries, err
+}
+
+// GetDeliveries returns the delivery log of an organization, newest first.
+func (dao *NotificationRepository) GetDeliveries(organizationId string, status string, limit int, offset int) ([]entity.NotificationDelivery, error) {
+	var deliveries []entity.NotificationDelivery
+	query := dao.db.Where("organization_id = ?", organizationId)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&deliveries).Error
+	return deliveries, err
+}
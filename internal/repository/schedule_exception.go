@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"gorm.io/gorm"
+)
+
+type ScheduleExceptionRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleExceptionRepo(db *gorm.DB) *ScheduleExceptionRepository {
+	return &ScheduleExceptionRepository{db: db}
+}
+
+func (dao *ScheduleExceptionRepository) GetById(organizationId string, id string) (entity.ScheduleException, error) {
+	var exception entity.ScheduleException
+
+	tx := dao.db.First(&exception, "id = ? AND organization_id = ?", id, organizationId)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return exception, fmt.Errorf("schedule exception not found")
+		}
+	}
+	return exception, tx.Error
+}
+
+// Find returns the exceptions of an organization between two dates, both
+// included. Empty location or user ids only match organization-wide entries.
+func (dao *ScheduleExceptionRepository) Find(organizationId string, locationId string, userId string, from time.Time, to time.Time) ([]entity.ScheduleException, error) {
+	var exceptions []entity.ScheduleException
+	query := dao.db.Where("organization_id = ? AND date BETWEEN ? AND ?", organizationId, from, to)
+	if locationId != "" {
+		query = query.Where("location_id = ?", locationId)
+	}
+	if userId != "" {
+		query = query.Where("user_id = ?", userId)
+	}
+	err := query.Order("date, from_time").Find(&exceptions).Error
+	return exceptions, err
+}
+
+func (dao *ScheduleExceptionRepository) ExistsHoliday(tx *gorm.DB, exception entity.ScheduleException) (bool, error) {
+	query := tx.Model(&entity.ScheduleException{}).
+		Where("organization_id = ? AND date = ? AND holiday_code = ?", exception.OrganizationID, exception.Date, exception.HolidayCode)
+	if exception.LocationID != nil {
+		query = query.Where("location_id = ?", *exception.LocationID)
+	} else {
+		query = query.Where("location_id IS NULL")
+	}
+	var count int64
+	err := query.Where("user_id IS NULL").Count(&count).Error
+	return count > 0, err
+}
+
+func (dao *ScheduleExceptionRepository) Save(tx *gorm.DB, exception *entity.ScheduleException) error {
+	return tx.Create(exception).Error
+}
+
+func (dao *ScheduleExceptionRepository) Update(exception *entity.ScheduleException) error {
+	return dao.db.Save(exception).Error
+}
+
+func (dao *ScheduleExceptionRepository) Delete(exception *entity.ScheduleException) error {
+	return dao.db.Delete(exception).Error
+}
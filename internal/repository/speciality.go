@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"gorm.io/gorm"
+)
+
+type SpecialityRepository struct {
+	db *gorm.DB
+}
+
+func NewSpecialityRepo(db *gorm.DB) *SpecialityRepository {
+	return &SpecialityRepository{db: db}
+}
+
+func (dao *SpecialityRepository) GetById(id int) (entity.Speciality, error) {
+	var speciality entity.Speciality
+
+	tx := dao.db.First(&speciality, "id = ?", id)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return speciality, fmt.Errorf("speciality not found")
+		}
+	}
+	return speciality, tx.Error
+}
+
+func (dao *SpecialityRepository) ExistsCode(code string) (bool, error) {
+	var count int64
+	err := dao.db.Model(&entity.Speciality{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// GetAll returns every speciality ordered by name, retired ones included
+// only when asked for.
+func (dao *SpecialityRepository) GetAll(includeRetired bool) ([]entity.Speciality, error) {
+	var specialities []entity.Speciality
+	query := dao.db.Order("name")
+	if !includeRetired {
+		query = query.Where("retired = ?", false)
+	}
+	err := query.Find(&specialities).Error
+	return specialities, err
+}
+
+func (dao *SpecialityRepository) Save(speciality *entity.Speciality) error {
+	return dao.db.Create(speciality).Error
+}
+
+func (dao *SpecialityRepository) Update(speciality *entity.Speciality) error {
+	return dao.db.Model(speciality).Select("Code", "Name", "ParentID", "Retired").Updates(speciality).Error
+}
+
+// Retire marks the given specialities as retired.
+func (dao *SpecialityRepository) Retire(ids []int) error {
+	return dao.db.Model(&entity.Speciality{}).Where("id IN ?", ids).Update("retired", true).Error
+}
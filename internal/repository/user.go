@@ -24,7 +24,7 @@ func (UserRepository *UserRepository) GetById(id string) (entity.User, error) {
 	// Check for errors
 	if tx.Error != nil {
 		if tx.Error == gorm.ErrRecordNotFound {
-			return user, fmt.Errorf("organization not found")
+			return user, fmt.Errorf("user not found")
 		}
 	}
 	return user, tx.Error
@@ -32,4 +32,17 @@ func (UserRepository *UserRepository) GetById(id string) (entity.User, error) {
 
 func (dao *UserRepository) Save(tx *gorm.DB, user *entity.User) error {
 	return tx.Create(user).Error
-}
\ No newline at end of file
+}
+
+func (dao *UserRepository) GetByEmail(email string) (entity.User, error) {
+	var user entity.User
+
+	tx := dao.db.First(&user, "email = ?", email)
+
+	if tx.Error != nil {
+		if tx.Error == gorm.ErrRecordNotFound {
+			return user, fmt.Errorf("user not found")
+		}
+	}
+	return user, tx.Error
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AgentConfigHandler struct {
+	AgentConfigService service.AgentConfigService
+	organizationAgent  fiber.Handler
+}
+
+// NewAgentConfigHandler creates an AgentConfigHandler. organizationAgent
+// guards the agent configuration of an organization, letting its members and
+// the voice platform, with the API key of its voice agent, fetch it.
+func NewAgentConfigHandler(agentConfigService service.AgentConfigService, organizationAgent fiber.Handler) *AgentConfigHandler {
+	return &AgentConfigHandler{
+		AgentConfigService: agentConfigService,
+		organizationAgent:  organizationAgent,
+	}
+}
+
+func (h *AgentConfigHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/agent-config", h.organizationAgent, h.getConfig)
+}
+
+// @Summary Get Agent Configuration
+// @Description Compile the configuration of the voice agent from the published agent profiles and the organization setup. The checksum, also sent as ETag, changes whenever the content does. The voice platform passes the API key of the voice agent in the X-Api-Key header instead of a bearer token.
+// @Tags AgentProfile
+// @Produce json,plain
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param X-Api-Key header string false "API key of the voice agent"
+// @Param format query string false "json (default) or text for the system prompt only"
+// @Param at query string false "RFC 3339 time the scripts are rendered for, now by default"
+// @Success 200 {object} agentconfig.Document
+// @Success 304 "Not modified"
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "No published agent profile"
+// @Router /api/v1/organizations/{organizationId}/agent-config [get]
+func (h *AgentConfigHandler) getConfig(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid at, expected an RFC 3339 time"})
+		}
+	}
+	format := c.Query("format", "json")
+	if format != "json" && format != "text" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid format, expected json or text"})
+	}
+
+	document, err := h.AgentConfigService.Compile(orgID.String(), at)
+	if err != nil {
+		return err
+	}
+
+	etag := `"` + document.Checksum + `"`
+	c.Set(fiber.HeaderETag, etag)
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	if format == "text" {
+		c.Type("txt", "utf-8")
+		return c.Status(fiber.StatusOK).SendString(document.Prompt)
+	}
+	return c.Status(fiber.StatusOK).JSON(document)
+}
@@ -0,0 +1,578 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/script"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AgentProfileHandler struct {
+	AgentProfileService service.AgentProfileService
+	authenticated       fiber.Handler
+	organizationMember  fiber.Handler
+	organizationAdmin   fiber.Handler
+}
+
+// NewAgentProfileHandler creates an AgentProfileHandler. authenticated guards
+// the script variables. Members of an organization read and draft its
+// profiles, going live only once published, and publish or roll them back so
+// the version history records who did it. Deleting a profile and reviewing a
+// version awaiting approval require an organization admin.
+func NewAgentProfileHandler(agentProfileService service.AgentProfileService, authenticated fiber.Handler, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *AgentProfileHandler {
+	return &AgentProfileHandler{
+		AgentProfileService: agentProfileService,
+		authenticated:       authenticated,
+		organizationMember:  organizationMember,
+		organizationAdmin:   organizationAdmin,
+	}
+}
+
+func (h *AgentProfileHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/agent-scripts/variables", h.authenticated, h.getScriptVariables)
+	app.Get("/api/v1/organizations/:organizationId/agent-profiles", h.organizationMember, h.getProfiles)
+	app.Post("/api/v1/organizations/:organizationId/agent-profiles", h.organizationMember, h.createProfile)
+	app.Get("/api/v1/organizations/:organizationId/agent-profiles/effective", h.organizationMember, h.getEffectiveProfile)
+	app.Post("/api/v1/organizations/:organizationId/agent-profiles/preview", h.organizationMember, h.previewScripts)
+	app.Get("/api/v1/organizations/:organizationId/agent-profiles/:id", h.organizationMember, h.getProfile)
+	app.Put("/api/v1/organizations/:organizationId/agent-profiles/:id", h.organizationMember, h.updateProfile)
+	app.Delete("/api/v1/organizations/:organizationId/agent-profiles/:id", h.organizationAdmin, h.deleteProfile)
+	app.Post("/api/v1/organizations/:organizationId/agent-profiles/:id/publish", h.organizationMember, h.publish)
+	app.Get("/api/v1/organizations/:organizationId/agent-profiles/:id/diff", h.organizationMember, h.diff)
+	app.Get("/api/v1/organizations/:organizationId/agent-profiles/:id/versions", h.organizationMember, h.getVersions)
+	app.Get("/api/v1/organizations/:organizationId/agent-profiles/:id/versions/:version", h.organizationMember, h.getVersion)
+	app.Post("/api/v1/organizations/:organizationId/agent-profiles/:id/versions/:version/approve", h.organizationAdmin, h.approveVersion)
+	app.Post("/api/v1/organizations/:organizationId/agent-profiles/:id/versions/:version/reject", h.organizationAdmin, h.rejectVersion)
+	app.Post("/api/v1/organizations/:organizationId/agent-profiles/:id/versions/:version/rollback", h.organizationMember, h.rollback)
+}
+
+// @Summary Get Agent Profiles
+// @Description Get the agent profiles of an organization, its default profile first
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} types.AgentProfileResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles [get]
+func (h *AgentProfileHandler) getProfiles(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	profiles, err := h.AgentProfileService.GetProfiles(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(toAgentProfileResponses(profiles))
+}
+
+// @Summary Get Effective Agent Profile
+// @Description Get the published profile the agent uses for a location, falling back to the organization default. Drafts are never returned.
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param location_id query string false "Location ID"
+// @Success 200 {object} types.AgentProfileResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Agent profile not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/effective [get]
+func (h *AgentProfileHandler) getEffectiveProfile(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	locationID, err := parseOptionalUUID(c.Query("location_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+	}
+
+	profile, err := h.AgentProfileService.GetEffectiveProfile(orgID.String(), locationID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToAgentProfileResponse(*profile))
+}
+
+// @Summary Get Agent Profile
+// @Description Get an agent profile
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Success 200 {object} types.AgentProfileResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Agent profile not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id} [get]
+func (h *AgentProfileHandler) getProfile(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid agent profile ID"})
+	}
+
+	profile, err := h.AgentProfileService.GetProfile(orgID.String(), id.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToAgentProfileResponse(*profile))
+}
+
+// @Summary Create Agent Profile
+// @Description Create the default agent profile of an organization, or the profile of one of its locations
+// @Tags AgentProfile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.AgentProfileRequest true "Agent profile"
+// @Success 201 {object} types.AgentProfileResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles [post]
+func (h *AgentProfileHandler) createProfile(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.AgentProfileRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	profile, err := h.AgentProfileService.CreateProfile(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToAgentProfileResponse(*profile))
+}
+
+// @Summary Update Agent Profile
+// @Description Update the language, voice and scripts of an agent profile
+// @Tags AgentProfile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param body body types.AgentProfileRequest true "Agent profile"
+// @Success 200 {object} types.AgentProfileResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id} [put]
+func (h *AgentProfileHandler) updateProfile(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid agent profile ID"})
+	}
+
+	var request types.AgentProfileRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	profile, err := h.AgentProfileService.UpdateProfile(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToAgentProfileResponse(*profile))
+}
+
+// @Summary Delete Agent Profile
+// @Description Delete an agent profile. A location without profile uses the organization default. (Admin only)
+// @Tags AgentProfile
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Agent profile not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id} [delete]
+func (h *AgentProfileHandler) deleteProfile(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid agent profile ID"})
+	}
+
+	if err := h.AgentProfileService.DeleteProfile(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Get Script Variables
+// @Description Get the {{variables}} usable in agent scripts
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} types.ScriptVariableResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/agent-scripts/variables [get]
+func (h *AgentProfileHandler) getScriptVariables(c *fiber.Ctx) error {
+	variables := make([]types.ScriptVariableResponse, 0, len(script.Variables))
+	for _, variable := range script.Variables {
+		variables = append(variables, types.ScriptVariableResponse{Name: variable.Name, Description: variable.Description})
+	}
+	return c.Status(fiber.StatusOK).JSON(variables)
+}
+
+// @Summary Preview Agent Scripts
+// @Description Render the scripts of a location for a date, time and language. Scripts left empty are taken from its agent profile.
+// @Tags AgentProfile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.ScriptPreviewRequest true "Preview"
+// @Success 200 {object} types.ScriptPreviewResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/preview [post]
+func (h *AgentProfileHandler) previewScripts(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.ScriptPreviewRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	preview, err := h.AgentProfileService.PreviewScripts(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(preview)
+}
+
+// @Summary Publish Agent Profile
+// @Description Publish the draft of an agent profile as a new version. When the organization requires an approval, versions submitted by non-Admins wait for one.
+// @Tags AgentProfile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param body body types.PublishRequest false "Publication note"
+// @Success 201 {object} types.AgentProfileVersionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Agent profile not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/publish [post]
+func (h *AgentProfileHandler) publish(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	orgID, id, err := parseProfilePath(c)
+	if err != nil {
+		return err
+	}
+
+	var request types.PublishRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	version, err := h.AgentProfileService.Publish(orgID, id, user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToAgentProfileVersionResponse(*version))
+}
+
+// @Summary Get Agent Profile Versions
+// @Description Get the version history of an agent profile, latest first
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Success 200 {array} types.AgentProfileVersionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Agent profile not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/versions [get]
+func (h *AgentProfileHandler) getVersions(c *fiber.Ctx) error {
+	orgID, id, err := parseProfilePath(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := h.AgentProfileService.GetVersions(orgID, id)
+	if err != nil {
+		return err
+	}
+	response := make([]types.AgentProfileVersionResponse, 0, len(versions))
+	for _, version := range versions {
+		response = append(response, service.ToAgentProfileVersionResponse(version))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Get Agent Profile Version
+// @Description Get a version of an agent profile
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param version path int true "Version"
+// @Success 200 {object} types.AgentProfileVersionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Version not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/versions/{version} [get]
+func (h *AgentProfileHandler) getVersion(c *fiber.Ctx) error {
+	orgID, id, err := parseProfilePath(c)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(c.Params("version"))
+	if err != nil || number <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+	}
+
+	version, err := h.AgentProfileService.GetVersion(orgID, id, number)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToAgentProfileVersionResponse(*version))
+}
+
+// @Summary Diff Agent Profile Versions
+// @Description Compare two versions of an agent profile word by word. By default the draft is compared with the published version.
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param from query string false "Version number, or draft; defaults to the published version"
+// @Param to query string false "Version number, or draft (default)"
+// @Success 200 {object} types.AgentProfileDiffResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Version not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/diff [get]
+func (h *AgentProfileHandler) diff(c *fiber.Ctx) error {
+	orgID, id, err := parseProfilePath(c)
+	if err != nil {
+		return err
+	}
+	from := -1
+	if value := c.Query("from"); value != "" {
+		if from, err = parseVersion(value); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from version"})
+		}
+	}
+	to, err := parseVersion(c.Query("to", "draft"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to version"})
+	}
+	if from < 0 {
+		profile, err := h.AgentProfileService.GetProfile(orgID, id)
+		if err != nil {
+			return err
+		}
+		from = profile.PublishedVersion
+	}
+
+	diff, err := h.AgentProfileService.Diff(orgID, id, from, to)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(diff)
+}
+
+// @Summary Approve Agent Profile Version
+// @Description Approve a version awaiting approval, which goes live (Admin only)
+// @Tags AgentProfile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param version path int true "Version"
+// @Param body body types.ReviewRequest false "Review note"
+// @Success 200 {object} types.AgentProfileVersionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Version not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/versions/{version}/approve [post]
+func (h *AgentProfileHandler) approveVersion(c *fiber.Ctx) error {
+	return h.reviewVersion(c, true)
+}
+
+// @Summary Reject Agent Profile Version
+// @Description Reject a version awaiting approval (Admin only)
+// @Tags AgentProfile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param version path int true "Version"
+// @Param body body types.ReviewRequest false "Review note"
+// @Success 200 {object} types.AgentProfileVersionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Version not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/versions/{version}/reject [post]
+func (h *AgentProfileHandler) rejectVersion(c *fiber.Ctx) error {
+	return h.reviewVersion(c, false)
+}
+
+func (h *AgentProfileHandler) reviewVersion(c *fiber.Ctx, approve bool) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	orgID, id, err := parseProfilePath(c)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(c.Params("version"))
+	if err != nil || number <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+	}
+
+	var request types.ReviewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	version, err := h.AgentProfileService.ReviewVersion(orgID, id, number, user, approve, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToAgentProfileVersionResponse(*version))
+}
+
+// @Summary Roll Back Agent Profile
+// @Description Publish again a version that was live before. It becomes a new version and replaces the draft; no approval is needed.
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Agent profile ID"
+// @Param version path int true "Version to restore"
+// @Success 201 {object} types.AgentProfileVersionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Version not found"
+// @Router /api/v1/organizations/{organizationId}/agent-profiles/{id}/versions/{version}/rollback [post]
+func (h *AgentProfileHandler) rollback(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	orgID, id, err := parseProfilePath(c)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(c.Params("version"))
+	if err != nil || number <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+	}
+
+	version, err := h.AgentProfileService.Rollback(orgID, id, number, user)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToAgentProfileVersionResponse(*version))
+}
+
+// parseProfilePath parses the organization and agent profile IDs of the path.
+func parseProfilePath(c *fiber.Ctx) (string, string, error) {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return "", "", errors.BadRequest("Invalid organization ID")
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return "", "", errors.BadRequest("Invalid agent profile ID")
+	}
+	return orgID.String(), id.String(), nil
+}
+
+// parseVersion parses a version number, "draft" standing for version 0.
+func parseVersion(value string) (int, error) {
+	if value == "draft" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid version %q", value)
+	}
+	return number, nil
+}
+
+func toAgentProfileResponses(profiles []entity.AgentProfile) []types.AgentProfileResponse {
+	response := make([]types.AgentProfileResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		response = append(response, service.ToAgentProfileResponse(profile))
+	}
+	return response
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AgentSyncHandler struct {
+	AgentSyncService   service.AgentSyncService
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+}
+
+// NewAgentSyncHandler creates an AgentSyncHandler. organizationMember guards
+// the sync status of an organization and organizationAdmin the sync trigger.
+func NewAgentSyncHandler(agentSyncService service.AgentSyncService, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *AgentSyncHandler {
+	return &AgentSyncHandler{
+		AgentSyncService:   agentSyncService,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+	}
+}
+
+func (h *AgentSyncHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/agent-sync", h.organizationMember, h.getStatus)
+	app.Post("/api/v1/organizations/:organizationId/agent-sync", h.organizationAdmin, h.sync)
+}
+
+// @Summary Get Agent Sync Status
+// @Description Tell whether the voice platform runs the current agent configuration of an organization
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {object} types.AgentSyncResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/agent-sync [get]
+func (h *AgentSyncHandler) getStatus(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	status, err := h.AgentSyncService.GetStatus(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(status)
+}
+
+// @Summary Sync Agent Configuration
+// @Description Queue a sync of the agent configuration to the voice platform, e.g. after it failed (Admin only)
+// @Tags AgentProfile
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 202 {object} types.AgentSyncResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/agent-sync [post]
+func (h *AgentSyncHandler) sync(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	status, err := h.AgentSyncService.Sync(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(status)
+}
@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AnalyticsHandler serves the call analytics of the organization of the
+// current user.
+type AnalyticsHandler struct {
+	AnalyticsService service.AnalyticsService
+	authenticated    fiber.Handler
+}
+
+// NewAnalyticsHandler creates an AnalyticsHandler. authenticated resolves the
+// current user whose organization is reported on.
+func NewAnalyticsHandler(analyticsService service.AnalyticsService, authenticated fiber.Handler) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		AnalyticsService: analyticsService,
+		authenticated:    authenticated,
+	}
+}
+
+func (h *AnalyticsHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/analytics/calls/summary", h.authenticated, h.getCallSummary)
+	app.Get("/api/v1/analytics/calls/volume", h.authenticated, h.getCallVolume)
+	app.Get("/api/v1/analytics/calls/tags", h.authenticated, h.getTopTags)
+	app.Get("/api/v1/analytics/calls/dispositions", h.authenticated, h.getCallDispositions)
+}
+
+// @Summary Get Call Summary
+// @Description Sum up the calls of the organization of the current user over a range of dates: outcomes, transfer rate, after-hours share, new and returning callers, and durations. Dates are in the time zone of the location or of the organization. Calls are included about a minute after they are recorded.
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD, default 29 days before the last date)"
+// @Param to query string false "Last date (YYYY-MM-DD, default today)"
+// @Param location_id query string false "Location ID"
+// @Success 200 {object} types.CallSummaryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/analytics/calls/summary [get]
+func (h *AnalyticsHandler) getCallSummary(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.AnalyticsService.CallSummary(user.OrganizationID.String(), filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(summary)
+}
+
+// @Summary Get Call Volume
+// @Description Count the calls of the organization of the current user by local hour, day or week (starting on Monday). Every period of the range is returned. Hourly volumes span at most 31 days.
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param interval query string false "hour, day (default) or week"
+// @Param from query string false "First date (YYYY-MM-DD, default 29 days before the last date)"
+// @Param to query string false "Last date (YYYY-MM-DD, default today)"
+// @Param location_id query string false "Location ID"
+// @Success 200 {object} types.CallVolumeResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/analytics/calls/volume [get]
+func (h *AnalyticsHandler) getCallVolume(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return err
+	}
+
+	volume, err := h.AnalyticsService.CallVolume(user.OrganizationID.String(), filter, c.Query("interval", service.AnalyticsDay))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(volume)
+}
+
+// @Summary Get Top Tags
+// @Description Get the tags set on the most calls of the organization of the current user over a range of dates
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD, default 29 days before the last date)"
+// @Param to query string false "Last date (YYYY-MM-DD, default today)"
+// @Param location_id query string false "Location ID"
+// @Param limit query int false "Number of tags (default 10, max 50)"
+// @Success 200 {object} types.TopTagsResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/analytics/calls/tags [get]
+func (h *AnalyticsHandler) getTopTags(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return err
+	}
+
+	tags, err := h.AnalyticsService.TopTags(user.OrganizationID.String(), filter, c.QueryInt("limit", 10))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(tags)
+}
+
+// @Summary Get Call Dispositions
+// @Description Count the calls of the organization of the current user per disposition over a range of dates, most set first, and those without a disposition
+// @Tags Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD, default 29 days before the last date)"
+// @Param to query string false "Last date (YYYY-MM-DD, default today)"
+// @Param location_id query string false "Location ID"
+// @Success 200 {object} types.CallDispositionsResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/analytics/calls/dispositions [get]
+func (h *AnalyticsHandler) getCallDispositions(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return err
+	}
+
+	dispositions, err := h.AnalyticsService.CallDispositions(user.OrganizationID.String(), filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(dispositions)
+}
+
+// parseAnalyticsFilter reads and validates the range of an analytics query.
+func parseAnalyticsFilter(c *fiber.Ctx) (types.AnalyticsFilter, error) {
+	filter := types.AnalyticsFilter{
+		From: c.Query("from"),
+		To:   c.Query("to"),
+	}
+	if value := c.Query("location_id"); value != "" {
+		locationId, err := uuid.Parse(value)
+		if err != nil {
+			return filter, errors.BadRequest("Invalid location ID")
+		}
+		filter.LocationId = &locationId
+	}
+	return filter, validateRequest(&filter)
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
@@ -34,12 +35,13 @@ func (authHandler *AuthHandler) Register(app *fiber.App) {
 	app.Post("/api/v1/user/register", authHandler.RegisterUser)
 }
 
-// validateRequest validates the request body
-func validateRequest(c *fiber.Ctx, req interface{}) error {
+// validateRequest validates a request body, answering with the invalid
+// fields.
+func validateRequest(req interface{}) error {
 	v := validator.GetValidator()
 	if err := v.Struct(req); err != nil {
 		logger.Error("Request validation failed", err)
-		return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		return errors.InvalidInput(err)
 	}
 	return nil
 }
@@ -104,7 +106,7 @@ func (authHandler *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).SendString("Invalid request")
 	}
 
-	if err := validateRequest(c, &req); err != nil {
+	if err := validateRequest(&req); err != nil {
 		return err
 	}
 
@@ -128,7 +130,7 @@ func (authHandler *AuthHandler) ConfirmForgotPassword(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).SendString("Invalid request")
 	}
 
-	if err := validateRequest(c, &req); err != nil {
+	if err := validateRequest(&req); err != nil {
 		return err
 	}
 
@@ -152,7 +154,7 @@ func (authHandler *AuthHandler) ConfirmTempPassword(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).SendString("Invalid request")
 	}
 
-	if err := validateRequest(c, &req); err != nil {
+	if err := validateRequest(&req); err != nil {
 		return err
 	}
 
@@ -176,7 +178,7 @@ func (authHandler *AuthHandler) ChangePassword(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).SendString("Invalid request")
 	}
 
-	if err := validateRequest(c, &req); err != nil {
+	if err := validateRequest(&req); err != nil {
 		return err
 	}
 
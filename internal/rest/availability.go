@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AvailabilityHandler struct {
+	AvailabilityService service.AvailabilityService
+	organizationMember  fiber.Handler
+}
+
+// NewAvailabilityHandler creates an AvailabilityHandler. organizationMember
+// guards the availability of an organization.
+func NewAvailabilityHandler(availabilityService service.AvailabilityService, organizationMember fiber.Handler) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		AvailabilityService: availabilityService,
+		organizationMember:  organizationMember,
+	}
+}
+
+func (h *AvailabilityHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/availability", h.organizationMember, h.getAvailability)
+}
+
+// @Summary Get Availability
+// @Description Tell whether the clinic, one of its locations or a staff member is open at a given instant, and when it next opens or closes
+// @Tags Availability
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param at query string false "Instant to check (RFC 3339), defaults to now"
+// @Param location_id query string false "Location ID"
+// @Param user_id query string false "Staff user ID"
+// @Success 200 {object} types.AvailabilityResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/availability [get]
+func (h *AvailabilityHandler) getAvailability(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid at, expected RFC 3339"})
+		}
+	}
+
+	locationID, err := parseOptionalUUID(c.Query("location_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+	}
+	userID, err := parseOptionalUUID(c.Query("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	availability, err := h.AvailabilityService.Availability(orgID.String(), locationID, userID, at)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(availability)
+}
+
+// parseOptionalUUID validates an optional id, returning "" when it is absent.
+func parseOptionalUUID(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
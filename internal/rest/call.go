@@ -1,29 +1,291 @@
 package rest
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
-// AuthHandler handles authentication-related requests
+// CallHandler receives the call reports of the voice platform and serves the
+// call history of the organization of the current user.
 type CallHandler struct {
-	AuthService service.SecurityService
-	UserService service.UserService
+	CallService       service.CallService
+	authenticated     fiber.Handler
+	organizationAdmin fiber.Handler
 }
 
-// NewAuthHandler creates a new AuthHandler
-func NewCallHandler(authService service.SecurityService, userService service.UserService) *CallHandler {
+// NewCallHandler creates a CallHandler. authenticated resolves the current
+// user whose calls are served; organizationAdmin guards the rotation of the
+// webhook secret of an organization.
+func NewCallHandler(callService service.CallService, authenticated fiber.Handler, organizationAdmin fiber.Handler) *CallHandler {
 	return &CallHandler{
-		authService,
-		userService,
+		CallService:       callService,
+		authenticated:     authenticated,
+		organizationAdmin: organizationAdmin,
+	}
+}
+
+func (h *CallHandler) Register(app *fiber.App) {
+	app.Post("/api/v1/webhooks/voice/:organizationId", h.receiveWebhook)
+	app.Post("/api/v1/organizations/:organizationId/call-webhook/secret", h.organizationAdmin, h.rotateWebhookSecret)
+	app.Get("/api/v1/calls", h.authenticated, h.getCalls)
+	app.Get("/api/v1/calls/search", h.authenticated, h.searchCalls)
+	app.Get("/api/v1/calls/:id", h.authenticated, h.getCall)
+}
+
+// @Summary Get Calls
+// @Description Get a page of the calls of the organization of the current user. Pass the next_cursor of a page as cursor, with the same sort and order, to get the next one.
+// @Tags Call
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Calls started at or after this time (RFC 3339)"
+// @Param to query string false "Calls started before this time (RFC 3339)"
+// @Param caller query string false "Digits contained in the caller number"
+// @Param min_duration query int false "Minimum duration in seconds"
+// @Param max_duration query int false "Maximum duration in seconds"
+// @Param transferred query bool false "Only transferred or only not transferred calls"
+// @Param tags query string false "Comma-separated tags, all of which must be set"
+// @Param status query string false "Comma-separated outcomes (completed, missed, forwarded, voicemail)"
+// @Param disposition query string false "Comma-separated disposition codes"
+// @Param location_id query string false "Location ID"
+// @Param contact_id query string false "Contact ID"
+// @Param sort query string false "started_at (default) or duration"
+// @Param order query string false "desc (default) or asc"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "Cursor of the next page"
+// @Success 200 {object} types.CallPageResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/calls [get]
+func (h *CallHandler) getCalls(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	criteria, err := parseCallCriteria(c)
+	if err != nil {
+		return err
+	}
+	filter := types.CallFilter{
+		CallCriteria: criteria,
+		Sort:         c.Query("sort", "started_at"),
+		Order:        c.Query("order", "desc"),
+		Limit:        c.QueryInt("limit", 20),
+		Cursor:       c.Query("cursor"),
+	}
+	if err := validateRequest(&filter); err != nil {
+		return err
+	}
+
+	page, err := h.CallService.ListCalls(user.OrganizationID.String(), filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+// @Summary Search Calls
+// @Description Search the transcripts of the calls of the organization of the current user, in English and French. Calls are returned most relevant first, with up to 3 matching turns each and their time in the call. The filters of the call list apply.
+// @Tags Call
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Words or quoted phrases; prefix a word with - to exclude it"
+// @Param from query string false "Calls started at or after this time (RFC 3339)"
+// @Param to query string false "Calls started before this time (RFC 3339)"
+// @Param caller query string false "Digits contained in the caller number"
+// @Param min_duration query int false "Minimum duration in seconds"
+// @Param max_duration query int false "Maximum duration in seconds"
+// @Param transferred query bool false "Only transferred or only not transferred calls"
+// @Param tags query string false "Comma-separated tags, all of which must be set"
+// @Param status query string false "Comma-separated outcomes (completed, missed, forwarded, voicemail)"
+// @Param disposition query string false "Comma-separated disposition codes"
+// @Param location_id query string false "Location ID"
+// @Param contact_id query string false "Contact ID"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} types.CallSearchResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/calls/search [get]
+func (h *CallHandler) searchCalls(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	criteria, err := parseCallCriteria(c)
+	if err != nil {
+		return err
+	}
+	filter := types.CallSearchFilter{
+		CallCriteria: criteria,
+		Query:        strings.TrimSpace(c.Query("q")),
+		Limit:        c.QueryInt("limit", 20),
+		Offset:       c.QueryInt("offset", 0),
+	}
+	if err := validateRequest(&filter); err != nil {
+		return err
+	}
+
+	page, err := h.CallService.SearchCalls(user.OrganizationID.String(), filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+// @Summary Get Call
+// @Description Get a call of the organization of the current user with its full transcript and the notes of the staff
+// @Tags Call
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {object} types.CallDetailResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Call not found"
+// @Router /api/v1/calls/{id} [get]
+func (h *CallHandler) getCall(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	call, err := h.CallService.GetCall(user.OrganizationID.String(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(call)
+}
+
+// parseCallCriteria reads the criteria selecting calls from the query.
+func parseCallCriteria(c *fiber.Ctx) (types.CallCriteria, error) {
+	criteria := types.CallCriteria{
+		Caller:       c.Query("caller"),
+		Tags:         splitQuery(c.Query("tags")),
+		Statuses:     splitQuery(c.Query("status")),
+		Dispositions: splitQuery(c.Query("disposition")),
+	}
+	for _, name := range []string{"from", "to"} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return criteria, errors.BadRequest("Invalid " + name + " time")
+		}
+		if name == "from" {
+			criteria.From = &at
+		} else {
+			criteria.To = &at
+		}
+	}
+	for name, target := range map[string]**int{"min_duration": &criteria.MinDuration, "max_duration": &criteria.MaxDuration} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			return criteria, errors.BadRequest("Invalid " + name)
+		}
+		*target = &seconds
+	}
+	if value := c.Query("transferred"); value != "" {
+		transferred, err := strconv.ParseBool(value)
+		if err != nil {
+			return criteria, errors.BadRequest("Invalid transferred")
+		}
+		criteria.Transferred = &transferred
+	}
+	if value := c.Query("location_id"); value != "" {
+		locationId, err := uuid.Parse(value)
+		if err != nil {
+			return criteria, errors.BadRequest("Invalid location ID")
+		}
+		criteria.LocationId = &locationId
 	}
+	if value := c.Query("contact_id"); value != "" {
+		contactId, err := uuid.Parse(value)
+		if err != nil {
+			return criteria, errors.BadRequest("Invalid contact ID")
+		}
+		criteria.ContactId = &contactId
+	}
+	return criteria, nil
 }
 
-// Register registers the authentication routes
-func (authHandler *CallHandler) Register(app *fiber.App) {
-	app.Get("/api/v1/call/vapi-assistant-calls", authHandler.GetVapiAssistantCalls)
+// splitQuery splits a comma-separated query parameter, dropping blank values.
+func splitQuery(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
 }
 
-func (authHandler *CallHandler) GetVapiAssistantCalls(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{"message": "GetVapiAssistantCalls"})
+// @Summary Receive Call Report
+// @Description Receive an end-of-call report of the voice platform. The report must be signed with the webhook secret of the organization. It is acknowledged right away and processed in the background; a report already received is acknowledged as a duplicate.
+// @Tags Call
+// @Accept json
+// @Produce json
+// @Param organizationId path string true "Organization ID"
+// @Param X-Comvoca-Timestamp header string true "Unix time the report was signed at, in seconds"
+// @Param X-Comvoca-Signature header string true "sha256= followed by the hex HMAC-SHA256 of the timestamp, a dot and the body"
+// @Param body body types.VoiceWebhookRequest true "Report"
+// @Success 202 {object} types.VoiceWebhookResponse "Accepted"
+// @Success 200 {object} types.VoiceWebhookResponse "Duplicate or ignored"
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Invalid signature"
+// @Router /api/v1/webhooks/voice/{organizationId} [post]
+func (h *CallHandler) receiveWebhook(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	status, err := h.CallService.ReceiveWebhook(orgID.String(), c.Get(service.WebhookTimestampHeader), c.Get(service.WebhookSignatureHeader), c.Body())
+	if err != nil {
+		return err
+	}
+	code := fiber.StatusOK
+	if status == types.WebhookAccepted {
+		code = fiber.StatusAccepted
+	}
+	return c.Status(code).JSON(types.VoiceWebhookResponse{Status: status})
+}
+
+// @Summary Rotate Webhook Secret
+// @Description Generate a new secret for signing the call reports of an organization. The previous one stops working right away. (Admin only)
+// @Tags Call
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {object} types.WebhookSecretResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/call-webhook/secret [post]
+func (h *CallHandler) rotateWebhookSecret(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	secret, err := h.CallService.RotateWebhookSecret(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(secret)
 }
@@ -0,0 +1,499 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CallReviewHandler serves the dispositions and tags of organizations, and
+// lets the staff set them on calls, leave notes and see the edits of a call.
+type CallReviewHandler struct {
+	CallReviewService  service.CallReviewService
+	authenticated      fiber.Handler
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+}
+
+// NewCallReviewHandler creates a CallReviewHandler. authenticated resolves the
+// current user who reviews the calls of their organization. organizationMember
+// guards the endpoints reading the dispositions and tags of an organization
+// and organizationAdmin the ones changing them.
+func NewCallReviewHandler(callReviewService service.CallReviewService, authenticated fiber.Handler, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *CallReviewHandler {
+	return &CallReviewHandler{
+		CallReviewService:  callReviewService,
+		authenticated:      authenticated,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+	}
+}
+
+func (h *CallReviewHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/call-dispositions", h.organizationMember, h.getDispositions)
+	app.Post("/api/v1/organizations/:organizationId/call-dispositions", h.organizationAdmin, h.createDisposition)
+	app.Put("/api/v1/organizations/:organizationId/call-dispositions/:id", h.organizationAdmin, h.updateDisposition)
+	app.Delete("/api/v1/organizations/:organizationId/call-dispositions/:id", h.organizationAdmin, h.deleteDisposition)
+	app.Get("/api/v1/organizations/:organizationId/call-tags", h.organizationMember, h.getTags)
+	app.Post("/api/v1/organizations/:organizationId/call-tags", h.organizationAdmin, h.createTag)
+	app.Put("/api/v1/organizations/:organizationId/call-tags/:id", h.organizationAdmin, h.updateTag)
+	app.Delete("/api/v1/organizations/:organizationId/call-tags/:id", h.organizationAdmin, h.deleteTag)
+	app.Put("/api/v1/calls/:id/disposition", h.authenticated, h.setDisposition)
+	app.Put("/api/v1/calls/:id/tags", h.authenticated, h.setTags)
+	app.Get("/api/v1/calls/:id/history", h.authenticated, h.getHistory)
+	app.Post("/api/v1/calls/:id/notes", h.authenticated, h.addNote)
+	app.Put("/api/v1/calls/:id/notes/:noteId", h.authenticated, h.updateNote)
+	app.Delete("/api/v1/calls/:id/notes/:noteId", h.authenticated, h.deleteNote)
+}
+
+// @Summary Get Call Dispositions
+// @Description Get the dispositions of an organization in order, archived ones included. An organization without any gets the default ones: booked, callback_needed, spam and resolved.
+// @Tags Call Review
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} types.CallDispositionResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/call-dispositions [get]
+func (h *CallReviewHandler) getDispositions(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	dispositions, err := h.CallReviewService.GetDispositions(orgID.String())
+	if err != nil {
+		return err
+	}
+	response := make([]types.CallDispositionResponse, 0, len(dispositions))
+	for _, disposition := range dispositions {
+		response = append(response, service.ToCallDispositionResponse(disposition))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Create Call Disposition
+// @Description Add a disposition to an organization. Its code is lowercased and cannot be changed afterwards. (Admin only)
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.CallDispositionRequest true "Disposition"
+// @Success 201 {object} types.CallDispositionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/call-dispositions [post]
+func (h *CallReviewHandler) createDisposition(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.CallDispositionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	disposition, err := h.CallReviewService.CreateDisposition(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToCallDispositionResponse(*disposition))
+}
+
+// @Summary Update Call Disposition
+// @Description Update the label, position or archival of a disposition. Archived dispositions stay on the calls they are set on but cannot be set anymore. (Admin only)
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Disposition ID"
+// @Param body body types.CallDispositionRequest true "Disposition"
+// @Success 200 {object} types.CallDispositionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Disposition not found"
+// @Router /api/v1/organizations/{organizationId}/call-dispositions/{id} [put]
+func (h *CallReviewHandler) updateDisposition(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid disposition ID"})
+	}
+
+	var request types.CallDispositionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	disposition, err := h.CallReviewService.UpdateDisposition(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallDispositionResponse(*disposition))
+}
+
+// @Summary Delete Call Disposition
+// @Description Delete a disposition that is not set on any call. Archive it otherwise. (Admin only)
+// @Tags Call Review
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Disposition ID"
+// @Success 204
+// @Failure 400 {object} errors.ErrorResponse "Disposition in use"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Disposition not found"
+// @Router /api/v1/organizations/{organizationId}/call-dispositions/{id} [delete]
+func (h *CallReviewHandler) deleteDisposition(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid disposition ID"})
+	}
+
+	if err := h.CallReviewService.DeleteDisposition(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Get Call Tags
+// @Description Get the tags of an organization by name
+// @Tags Call Review
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} types.CallTagResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/call-tags [get]
+func (h *CallReviewHandler) getTags(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	tags, err := h.CallReviewService.GetTags(orgID.String())
+	if err != nil {
+		return err
+	}
+	response := make([]types.CallTagResponse, 0, len(tags))
+	for _, tag := range tags {
+		response = append(response, service.ToCallTagResponse(tag))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Create Call Tag
+// @Description Add a tag to an organization (Admin only)
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.CallTagRequest true "Tag"
+// @Success 201 {object} types.CallTagResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/call-tags [post]
+func (h *CallReviewHandler) createTag(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.CallTagRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	tag, err := h.CallReviewService.CreateTag(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToCallTagResponse(*tag))
+}
+
+// @Summary Rename Call Tag
+// @Description Rename a tag, on the calls it is set on too (Admin only)
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Tag ID"
+// @Param body body types.CallTagRequest true "Tag"
+// @Success 200 {object} types.CallTagResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Tag not found"
+// @Router /api/v1/organizations/{organizationId}/call-tags/{id} [put]
+func (h *CallReviewHandler) updateTag(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+
+	var request types.CallTagRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	tag, err := h.CallReviewService.UpdateTag(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallTagResponse(*tag))
+}
+
+// @Summary Delete Call Tag
+// @Description Delete a tag and remove it from the calls it is set on (Admin only)
+// @Tags Call Review
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Tag ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Tag not found"
+// @Router /api/v1/organizations/{organizationId}/call-tags/{id} [delete]
+func (h *CallReviewHandler) deleteTag(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+
+	if err := h.CallReviewService.DeleteTag(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Set Call Disposition
+// @Description Set the disposition of a call of the organization of the current user, or clear it with an empty code. The edit is recorded in the history of the call.
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Param body body types.SetCallDispositionRequest true "Disposition code"
+// @Success 200 {object} types.CallResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Call not found"
+// @Router /api/v1/calls/{id}/disposition [put]
+func (h *CallReviewHandler) setDisposition(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.SetCallDispositionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	call, err := h.CallReviewService.SetDisposition(user.OrganizationID.String(), c.Params("id"), user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallResponse(*call))
+}
+
+// @Summary Set Call Tags
+// @Description Replace the tags of a call of the organization of the current user. Every tag must be one of the organization. The edit is recorded in the history of the call.
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Param body body types.SetCallTagsRequest true "Tags"
+// @Success 200 {object} types.CallResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Call not found"
+// @Router /api/v1/calls/{id}/tags [put]
+func (h *CallReviewHandler) setTags(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.SetCallTagsRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	call, err := h.CallReviewService.SetTags(user.OrganizationID.String(), c.Params("id"), user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallResponse(*call))
+}
+
+// @Summary Get Call History
+// @Description Get the edits of the disposition, tags and notes of a call of the organization of the current user, newest first
+// @Tags Call Review
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Success 200 {array} types.CallChangeResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Call not found"
+// @Router /api/v1/calls/{id}/history [get]
+func (h *CallReviewHandler) getHistory(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	changes, err := h.CallReviewService.GetHistory(user.OrganizationID.String(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	response := make([]types.CallChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		response = append(response, service.ToCallChangeResponse(change))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Add Call Note
+// @Description Leave a note on a call of the organization of the current user
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Param body body types.CallNoteRequest true "Note"
+// @Success 201 {object} types.CallNoteResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Call not found"
+// @Router /api/v1/calls/{id}/notes [post]
+func (h *CallReviewHandler) addNote(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.CallNoteRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	note, err := h.CallReviewService.AddNote(user.OrganizationID.String(), c.Params("id"), user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToCallNoteResponse(*note))
+}
+
+// @Summary Update Call Note
+// @Description Edit a note of a call. Only its author and admins can.
+// @Tags Call Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Param noteId path string true "Note ID"
+// @Param body body types.CallNoteRequest true "Note"
+// @Success 200 {object} types.CallNoteResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Note not found"
+// @Router /api/v1/calls/{id}/notes/{noteId} [put]
+func (h *CallReviewHandler) updateNote(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.CallNoteRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	note, err := h.CallReviewService.UpdateNote(user.OrganizationID.String(), c.Params("id"), c.Params("noteId"), user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallNoteResponse(*note))
+}
+
+// @Summary Delete Call Note
+// @Description Delete a note of a call. Only its author and admins can.
+// @Tags Call Review
+// @Security BearerAuth
+// @Param id path string true "Call ID"
+// @Param noteId path string true "Note ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Note not found"
+// @Router /api/v1/calls/{id}/notes/{noteId} [delete]
+func (h *CallReviewHandler) deleteNote(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	if err := h.CallReviewService.DeleteNote(user.OrganizationID.String(), c.Params("id"), c.Params("noteId"), user); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,324 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CallbackHandler serves the callback rules of organizations and the
+// callback tasks of the organization of the current user.
+type CallbackHandler struct {
+	CallbackService    service.CallbackService
+	authenticated      fiber.Handler
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+}
+
+// NewCallbackHandler creates a CallbackHandler. authenticated resolves the
+// current user whose callback tasks are served. organizationMember guards the
+// endpoints reading the callback rules of an organization and
+// organizationAdmin the ones changing them.
+func NewCallbackHandler(callbackService service.CallbackService, authenticated fiber.Handler, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *CallbackHandler {
+	return &CallbackHandler{
+		CallbackService:    callbackService,
+		authenticated:      authenticated,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+	}
+}
+
+func (h *CallbackHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/callback-rules", h.organizationMember, h.getRules)
+	app.Post("/api/v1/organizations/:organizationId/callback-rules", h.organizationAdmin, h.createRule)
+	app.Put("/api/v1/organizations/:organizationId/callback-rules/:id", h.organizationAdmin, h.updateRule)
+	app.Delete("/api/v1/organizations/:organizationId/callback-rules/:id", h.organizationAdmin, h.deleteRule)
+	app.Get("/api/v1/callbacks", h.authenticated, h.getTasks)
+	app.Get("/api/v1/callbacks/mine", h.authenticated, h.getMyTasks)
+	app.Get("/api/v1/callbacks/:id", h.authenticated, h.getTask)
+	app.Put("/api/v1/callbacks/:id/assignee", h.authenticated, h.assignTask)
+	app.Put("/api/v1/callbacks/:id/status", h.authenticated, h.setTaskStatus)
+}
+
+// @Summary Get Callback Rules
+// @Description Get the rules creating callback tasks from the calls of an organization, in the order they are tried. An organization without any gets the default ones.
+// @Tags Callback
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} types.CallbackRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/callback-rules [get]
+func (h *CallbackHandler) getRules(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	rules, err := h.CallbackService.GetRules(orgID.String())
+	if err != nil {
+		return err
+	}
+	response := make([]types.CallbackRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		response = append(response, service.ToCallbackRuleResponse(rule))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Create Callback Rule
+// @Description Add a rule creating callback tasks from the calls of an organization (Admin only)
+// @Tags Callback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.CallbackRuleRequest true "Callback rule"
+// @Success 201 {object} types.CallbackRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/callback-rules [post]
+func (h *CallbackHandler) createRule(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.CallbackRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	rule, err := h.CallbackService.CreateRule(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToCallbackRuleResponse(*rule))
+}
+
+// @Summary Update Callback Rule
+// @Description Update a callback rule. The tasks it already created are left as they are. (Admin only)
+// @Tags Callback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Callback rule ID"
+// @Param body body types.CallbackRuleRequest true "Callback rule"
+// @Success 200 {object} types.CallbackRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Callback rule not found"
+// @Router /api/v1/organizations/{organizationId}/callback-rules/{id} [put]
+func (h *CallbackHandler) updateRule(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid callback rule ID"})
+	}
+
+	var request types.CallbackRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	rule, err := h.CallbackService.UpdateRule(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallbackRuleResponse(*rule))
+}
+
+// @Summary Delete Callback Rule
+// @Description Delete a callback rule. The tasks it created are kept. An organization left without rules gets the default ones back; disable rules to stop them. (Admin only)
+// @Tags Callback
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Callback rule ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Callback rule not found"
+// @Router /api/v1/organizations/{organizationId}/callback-rules/{id} [delete]
+func (h *CallbackHandler) deleteRule(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid callback rule ID"})
+	}
+
+	if err := h.CallbackService.DeleteRule(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Get Callbacks
+// @Description Get a page of the callback tasks of the organization of the current user, the soonest due first. Only open and in-progress tasks are listed unless statuses are given.
+// @Tags Callback
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Comma-separated statuses (open, in_progress, completed, cancelled)"
+// @Param assignee query string false "me, unassigned or a user ID"
+// @Param overdue query bool false "Only active tasks past their due time"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} types.CallbackPageResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/callbacks [get]
+func (h *CallbackHandler) getTasks(c *fiber.Ctx) error {
+	return h.listTasks(c, c.Query("assignee"))
+}
+
+// @Summary Get My Callbacks
+// @Description Get a page of the callback tasks assigned to the current user, the soonest due first. Only open and in-progress tasks are listed unless statuses are given.
+// @Tags Callback
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Comma-separated statuses (open, in_progress, completed, cancelled)"
+// @Param overdue query bool false "Only active tasks past their due time"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} types.CallbackPageResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/callbacks/mine [get]
+func (h *CallbackHandler) getMyTasks(c *fiber.Ctx) error {
+	return h.listTasks(c, "me")
+}
+
+func (h *CallbackHandler) listTasks(c *fiber.Ctx, assignee string) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	filter := types.CallbackFilter{
+		Statuses: splitQuery(c.Query("status")),
+		Assignee: assignee,
+		Overdue:  c.QueryBool("overdue"),
+		Limit:    c.QueryInt("limit", 20),
+		Offset:   c.QueryInt("offset", 0),
+	}
+	if err := validateRequest(&filter); err != nil {
+		return err
+	}
+
+	page, err := h.CallbackService.ListTasks(user, filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+// @Summary Get Callback
+// @Description Get a callback task of the organization of the current user
+// @Tags Callback
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Callback task ID"
+// @Success 200 {object} types.CallbackTaskResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Callback task not found"
+// @Router /api/v1/callbacks/{id} [get]
+func (h *CallbackHandler) getTask(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	task, err := h.CallbackService.GetTask(user.OrganizationID.String(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallbackTaskResponse(*task, time.Now()))
+}
+
+// @Summary Assign Callback
+// @Description Assign a callback task to a user of the organization, who is notified, or unassign it with a null assignee
+// @Tags Callback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Callback task ID"
+// @Param body body types.AssignCallbackRequest true "Assignee"
+// @Success 200 {object} types.CallbackTaskResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Callback task not found"
+// @Router /api/v1/callbacks/{id}/assignee [put]
+func (h *CallbackHandler) assignTask(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.AssignCallbackRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	task, err := h.CallbackService.AssignTask(user.OrganizationID.String(), c.Params("id"), user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallbackTaskResponse(*task, time.Now()))
+}
+
+// @Summary Set Callback Status
+// @Description Move a callback task to another status. Open and in-progress tasks can be completed or cancelled, and closed ones reopened. Starting an unassigned task assigns it to the current user.
+// @Tags Callback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Callback task ID"
+// @Param body body types.CallbackStatusRequest true "Status"
+// @Success 200 {object} types.CallbackTaskResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Callback task not found"
+// @Router /api/v1/callbacks/{id}/status [put]
+func (h *CallbackHandler) setTaskStatus(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.CallbackStatusRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	task, err := h.CallbackService.SetTaskStatus(user.OrganizationID.String(), c.Params("id"), user, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToCallbackTaskResponse(*task, time.Now()))
+}
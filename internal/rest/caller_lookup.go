@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CallerLookupHandler serves the voice agent what is known about a caller,
+// authenticated with the API key of the agent of the organization.
+type CallerLookupHandler struct {
+	CallerLookupService service.CallerLookupService
+	organizationAdmin   fiber.Handler
+}
+
+// NewCallerLookupHandler creates a CallerLookupHandler. organizationAdmin
+// guards the rotation of the API key of the agent of an organization.
+func NewCallerLookupHandler(callerLookupService service.CallerLookupService, organizationAdmin fiber.Handler) *CallerLookupHandler {
+	return &CallerLookupHandler{
+		CallerLookupService: callerLookupService,
+		organizationAdmin:   organizationAdmin,
+	}
+}
+
+func (h *CallerLookupHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/caller-lookup", h.lookupCaller)
+	app.Post("/api/v1/organizations/:organizationId/agent-key", h.organizationAdmin, h.rotateAgentKey)
+}
+
+// @Summary Look Up Caller
+// @Description Get what is known about a caller when a call starts: their name, preferred language, VIP and blocked status, latest calls and the callbacks still owed to them. The API key of the agent of the organization must be passed in the X-Api-Key header. The response is bounded by a time budget; complete is false when part of it could not be loaded in time.
+// @Tags Caller Lookup
+// @Produce json
+// @Param organizationId path string true "Organization ID"
+// @Param number query string true "Inbound caller number"
+// @Param X-Api-Key header string true "API key of the voice agent"
+// @Success 200 {object} types.CallerLookupResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Invalid API key"
+// @Failure 503 {object} errors.ErrorResponse "Caller lookup is unavailable"
+// @Router /api/v1/organizations/{organizationId}/caller-lookup [get]
+func (h *CallerLookupHandler) lookupCaller(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	number := c.Query("number")
+	if number == "" || len(number) > 30 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid number"})
+	}
+
+	caller, err := h.CallerLookupService.LookupCaller(c.UserContext(), orgID, c.Get(service.AgentKeyHeader), number)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(caller)
+}
+
+// @Summary Rotate Agent Key
+// @Description Generate a new API key for the voice agent of an organization to look up callers. The previous one stops working right away. (Admin only)
+// @Tags Caller Lookup
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {object} types.AgentKeyResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/agent-key [post]
+func (h *CallerLookupHandler) rotateAgentKey(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	key, err := h.CallerLookupService.RotateAgentKey(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(key)
+}
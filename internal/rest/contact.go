@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ContactHandler serves the caller directory of the organization of the
+// current user.
+type ContactHandler struct {
+	ContactService service.ContactService
+	authenticated  fiber.Handler
+}
+
+// NewContactHandler creates a ContactHandler. authenticated resolves the
+// current user whose contacts are served.
+func NewContactHandler(contactService service.ContactService, authenticated fiber.Handler) *ContactHandler {
+	return &ContactHandler{
+		ContactService: contactService,
+		authenticated:  authenticated,
+	}
+}
+
+func (h *ContactHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/contacts", h.authenticated, h.getContacts)
+	app.Get("/api/v1/contacts/:id", h.authenticated, h.getContact)
+	app.Put("/api/v1/contacts/:id", h.authenticated, h.updateContact)
+	app.Post("/api/v1/contacts/:id/merge", h.authenticated, h.mergeContacts)
+	app.Post("/api/v1/contacts/:id/split", h.authenticated, h.splitContact)
+}
+
+// @Summary Get Contacts
+// @Description Get a page of the callers of the organization of the current user, the most recent first, with how many times they called
+// @Tags Contact
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Part of the name or of a phone number"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} types.ContactPageResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/contacts [get]
+func (h *ContactHandler) getContacts(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	filter := types.ContactFilter{
+		Query:  c.Query("q"),
+		Limit:  c.QueryInt("limit", 20),
+		Offset: c.QueryInt("offset", 0),
+	}
+	if err := validateRequest(&filter); err != nil {
+		return err
+	}
+
+	page, err := h.ContactService.ListContacts(user.OrganizationID, filter)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+// @Summary Get Contact
+// @Description Get a caller of the organization of the current user with their latest calls
+// @Tags Contact
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Contact ID"
+// @Success 200 {object} types.ContactDetailResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Contact not found"
+// @Router /api/v1/contacts/{id} [get]
+func (h *ContactHandler) getContact(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	contact, err := h.ContactService.GetContact(user.OrganizationID.String(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(contact)
+}
+
+// @Summary Update Contact
+// @Description Update the name, notes, preferred language and VIP and blocked status of a caller
+// @Tags Contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Contact ID"
+// @Param body body types.ContactRequest true "Contact"
+// @Success 200 {object} types.ContactDetailResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Contact not found"
+// @Router /api/v1/contacts/{id} [put]
+func (h *ContactHandler) updateContact(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.ContactRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	contact, err := h.ContactService.UpdateContact(user.OrganizationID.String(), c.Params("id"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(contact)
+}
+
+// @Summary Merge Contacts
+// @Description Merge callers that are the same person into a contact. Their numbers and calls move to it and they are deleted.
+// @Tags Contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID of the contact merged into"
+// @Param body body types.MergeContactsRequest true "Contacts to merge"
+// @Success 200 {object} types.ContactDetailResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Contact not found"
+// @Router /api/v1/contacts/{id}/merge [post]
+func (h *ContactHandler) mergeContacts(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.MergeContactsRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	contact, err := h.ContactService.MergeContacts(user.OrganizationID.String(), c.Params("id"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(contact)
+}
+
+// @Summary Split Contact
+// @Description Move numbers of a caller, with the calls from them, to a new contact. The caller keeps at least one number.
+// @Tags Contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Contact ID"
+// @Param body body types.SplitContactRequest true "Numbers to split off"
+// @Success 201 {object} types.ContactDetailResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 404 {object} errors.ErrorResponse "Contact not found"
+// @Router /api/v1/contacts/{id}/split [post]
+func (h *ContactHandler) splitContact(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	var request types.SplitContactRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	contact, err := h.ContactService.SplitContact(user.OrganizationID.String(), c.Params("id"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(contact)
+}
@@ -0,0 +1,283 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ForwardingHandler struct {
+	ForwardingService  service.ForwardingService
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+	organizationAgent  fiber.Handler
+}
+
+// NewForwardingHandler creates a ForwardingHandler. organizationMember guards
+// the endpoints reading the forwarding rules of an organization and
+// organizationAdmin the ones changing them. organizationAgent also lets the
+// voice agent of the organization resolve and simulate them during calls.
+func NewForwardingHandler(forwardingService service.ForwardingService, organizationMember fiber.Handler, organizationAdmin fiber.Handler, organizationAgent fiber.Handler) *ForwardingHandler {
+	return &ForwardingHandler{
+		ForwardingService:  forwardingService,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+		organizationAgent:  organizationAgent,
+	}
+}
+
+func (h *ForwardingHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/forwarding-rules", h.organizationMember, h.getRules)
+	app.Post("/api/v1/organizations/:organizationId/forwarding-rules", h.organizationAdmin, h.createRule)
+	app.Put("/api/v1/organizations/:organizationId/forwarding-rules/order", h.organizationAdmin, h.reorderRules)
+	app.Post("/api/v1/organizations/:organizationId/forwarding-rules/simulate", h.organizationAgent, h.simulate)
+	app.Put("/api/v1/organizations/:organizationId/forwarding-rules/:id", h.organizationAdmin, h.updateRule)
+	app.Delete("/api/v1/organizations/:organizationId/forwarding-rules/:id", h.organizationAdmin, h.deleteRule)
+	app.Get("/api/v1/organizations/:organizationId/forwarding/resolve", h.organizationAgent, h.resolve)
+}
+
+// @Summary Get Forwarding Rules
+// @Description Get the call forwarding rules of an organization in evaluation order
+// @Tags Forwarding
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} types.ForwardingRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/forwarding-rules [get]
+func (h *ForwardingHandler) getRules(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	rules, err := h.ForwardingService.GetRules(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(toForwardingRuleResponses(rules))
+}
+
+// @Summary Create Forwarding Rule
+// @Description Add a call forwarding rule, evaluated after the existing ones (Admin only)
+// @Tags Forwarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.ForwardingRuleRequest true "Forwarding rule"
+// @Success 201 {object} types.ForwardingRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/forwarding-rules [post]
+func (h *ForwardingHandler) createRule(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.ForwardingRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	rule, err := h.ForwardingService.CreateRule(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToForwardingRuleResponse(*rule))
+}
+
+// @Summary Update Forwarding Rule
+// @Description Update the conditions and action of a call forwarding rule (Admin only)
+// @Tags Forwarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Forwarding rule ID"
+// @Param body body types.ForwardingRuleRequest true "Forwarding rule"
+// @Success 200 {object} types.ForwardingRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/forwarding-rules/{id} [put]
+func (h *ForwardingHandler) updateRule(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid forwarding rule ID"})
+	}
+
+	var request types.ForwardingRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	rule, err := h.ForwardingService.UpdateRule(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToForwardingRuleResponse(*rule))
+}
+
+// @Summary Delete Forwarding Rule
+// @Description Delete a call forwarding rule (Admin only)
+// @Tags Forwarding
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Forwarding rule ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Forwarding rule not found"
+// @Router /api/v1/organizations/{organizationId}/forwarding-rules/{id} [delete]
+func (h *ForwardingHandler) deleteRule(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid forwarding rule ID"})
+	}
+
+	if err := h.ForwardingService.DeleteRule(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Reorder Forwarding Rules
+// @Description Set the evaluation order of the call forwarding rules. Every rule must be listed once. (Admin only)
+// @Tags Forwarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.ForwardingOrderRequest true "Rule ids in evaluation order"
+// @Success 200 {array} types.ForwardingRuleResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/forwarding-rules/order [put]
+func (h *ForwardingHandler) reorderRules(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.ForwardingOrderRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	rules, err := h.ForwardingService.ReorderRules(orgID.String(), request.RuleIds)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(toForwardingRuleResponses(rules))
+}
+
+// @Summary Resolve Call Forwarding
+// @Description Tell the voice agent where to transfer a call right now. The voice agent passes its API key in the X-Api-Key header instead of a bearer token.
+// @Tags Forwarding
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param X-Api-Key header string false "API key of the voice agent"
+// @Param intent query string false "emergency, billing, new_patient, existing_patient, appointment or general"
+// @Param location_id query string false "Location ID"
+// @Success 200 {object} types.ForwardingDecision
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/forwarding/resolve [get]
+func (h *ForwardingHandler) resolve(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	call := types.ForwardingCall{Intent: c.Query("intent")}
+	if value := c.Query("location_id"); value != "" {
+		locationID, err := uuid.Parse(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+		}
+		call.LocationId = &locationID
+	}
+	if err := validateRequest(&call); err != nil {
+		return err
+	}
+
+	decision, err := h.ForwardingService.Resolve(orgID.String(), call, false)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(decision)
+}
+
+// @Summary Simulate Call Forwarding
+// @Description Resolve a hypothetical call and explain how every rule was evaluated. The voice agent passes its API key in the X-Api-Key header instead of a bearer token.
+// @Tags Forwarding
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param X-Api-Key header string false "API key of the voice agent"
+// @Param body body types.ForwardingCall true "Hypothetical call"
+// @Success 200 {object} types.ForwardingDecision
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/forwarding-rules/simulate [post]
+func (h *ForwardingHandler) simulate(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var call types.ForwardingCall
+	if err := c.BodyParser(&call); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&call); err != nil {
+		return err
+	}
+
+	decision, err := h.ForwardingService.Resolve(orgID.String(), call, true)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(decision)
+}
+
+func toForwardingRuleResponses(rules []entity.ForwardingRule) []types.ForwardingRuleResponse {
+	response := make([]types.ForwardingRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		response = append(response, service.ToForwardingRuleResponse(rule))
+	}
+	return response
+}
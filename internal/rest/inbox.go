@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxInboxPageSize bounds the page size of the inbox.
+	maxInboxPageSize = 100
+	// streamHeartbeat keeps idle streams open through proxies.
+	streamHeartbeat = 25 * time.Second
+)
+
+// InboxHandler serves the in-app notifications of the authenticated user.
+type InboxHandler struct {
+	NotificationService service.NotificationService
+	authenticated       fiber.Handler
+}
+
+// NewInboxHandler creates an InboxHandler. authenticated resolves the current
+// user whose inbox is served.
+func NewInboxHandler(notificationService service.NotificationService, authenticated fiber.Handler) *InboxHandler {
+	return &InboxHandler{
+		NotificationService: notificationService,
+		authenticated:       authenticated,
+	}
+}
+
+func (h *InboxHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/notifications", h.authenticated, h.getNotifications)
+	app.Get("/api/v1/notifications/unread-count", h.authenticated, h.getUnreadCount)
+	app.Get("/api/v1/notifications/stream", h.authenticated, h.stream)
+	app.Post("/api/v1/notifications/read-all", h.authenticated, h.markAllRead)
+	app.Post("/api/v1/notifications/:id/read", h.authenticated, h.markRead)
+}
+
+// @Summary Get Notifications
+// @Description Get a page of the in-app notifications of the current user, newest first
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} types.NotificationPageResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/notifications [get]
+func (h *InboxHandler) getNotifications(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	limit := c.QueryInt("limit", 20)
+	offset := c.QueryInt("offset", 0)
+	if limit <= 0 || limit > maxInboxPageSize || offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid pagination"})
+	}
+
+	page, err := h.NotificationService.GetInbox(user.ID, c.QueryBool("unread", false), limit, offset)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+// @Summary Get Unread Notification Count
+// @Description Get the number of unread in-app notifications of the current user
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.UnreadCountResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/notifications/unread-count [get]
+func (h *InboxHandler) getUnreadCount(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	count, err := h.NotificationService.UnreadCount(user.ID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(types.UnreadCountResponse{UnreadCount: count})
+}
+
+// @Summary Mark Notification Read
+// @Description Mark an in-app notification of the current user as read
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 200 {object} types.NotificationResponse
+// @Failure 404 {object} errors.ErrorResponse "Notification not found"
+// @Router /api/v1/notifications/{id}/read [post]
+func (h *InboxHandler) markRead(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid notification ID"})
+	}
+
+	notification, err := h.NotificationService.MarkRead(user.ID, id.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(notification)
+}
+
+// @Summary Mark All Notifications Read
+// @Description Mark every in-app notification of the current user as read
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} types.UnreadCountResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/notifications/read-all [post]
+func (h *InboxHandler) markAllRead(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	if _, err := h.NotificationService.MarkAllRead(user.ID); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(types.UnreadCountResponse{UnreadCount: 0})
+}
+
+// @Summary Stream Notifications
+// @Description Server-sent events stream of the current user's new in-app notifications. An "unread_count" event is sent on connection and after every "notification" event. Browsers' EventSource cannot set headers, so the token may also be passed as the access_token query parameter.
+// @Tags Notification
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} types.NotificationResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Router /api/v1/notifications/stream [get]
+func (h *InboxHandler) stream(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	userID := user.ID
+	count, err := h.NotificationService.UnreadCount(userID)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before writing anything so no notification is missed between
+	// the initial count and the stream
+	notifications, unsubscribe := h.NotificationService.Subscribe(userID)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		if writeEvent(w, "unread_count", types.UnreadCountResponse{UnreadCount: count}) != nil {
+			return
+		}
+		for {
+			select {
+			case notification := <-notifications:
+				if writeEvent(w, "notification", service.ToNotificationResponse(notification)) != nil {
+					return
+				}
+				count, err := h.NotificationService.UnreadCount(userID)
+				if err == nil && writeEvent(w, "unread_count", types.UnreadCountResponse{UnreadCount: count}) != nil {
+					return
+				}
+			case <-heartbeat.C:
+				// a comment line, ignored by clients; fails once the client is gone
+				if _, err := w.WriteString(": heartbeat\n\n"); err != nil || w.Flush() != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+// writeEvent writes a server-sent event and flushes it to the client.
+func writeEvent(w *bufio.Writer, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type InsuranceHandler struct {
+	InsuranceService   service.InsuranceService
+	adminOnly          fiber.Handler
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+	organizationAgent  fiber.Handler
+}
+
+// NewInsuranceHandler creates an InsuranceHandler. adminOnly guards the
+// endpoints changing the carrier catalog; organizationMember and
+// organizationAdmin guard the insurances of an organization. organizationAgent
+// also lets the voice agent of the organization match them during calls.
+func NewInsuranceHandler(insuranceService service.InsuranceService, adminOnly fiber.Handler, organizationMember fiber.Handler, organizationAdmin fiber.Handler, organizationAgent fiber.Handler) *InsuranceHandler {
+	return &InsuranceHandler{
+		InsuranceService:   insuranceService,
+		adminOnly:          adminOnly,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+		organizationAgent:  organizationAgent,
+	}
+}
+
+func (h *InsuranceHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/insurance-carriers", h.getCarriers)
+	app.Post("/api/v1/insurance-carriers", h.adminOnly, h.createCarrier)
+	app.Put("/api/v1/insurance-carriers/:id", h.adminOnly, h.updateCarrier)
+	app.Get("/api/v1/organizations/:organizationId/insurances", h.organizationMember, h.getInsurances)
+	app.Put("/api/v1/organizations/:organizationId/insurances", h.organizationAdmin, h.replaceInsurances)
+	app.Get("/api/v1/organizations/:organizationId/insurances/match", h.organizationAgent, h.matchInsurance)
+}
+
+// @Summary Get Insurance Carriers
+// @Description Get the insurance carrier catalog, optionally filtered by fuzzy matching on names and aliases
+// @Tags Insurance
+// @Produce json
+// @Param q query string false "Search by name or alias"
+// @Param include_retired query bool false "Include retired carriers"
+// @Success 200 {array} types.InsuranceCarrierResponse
+// @Router /api/v1/insurance-carriers [get]
+func (h *InsuranceHandler) getCarriers(c *fiber.Ctx) error {
+	carriers, err := h.InsuranceService.GetCarriers(c.Query("q"), c.QueryBool("include_retired", false))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(carriers)
+}
+
+// @Summary Create Insurance Carrier
+// @Description Add a carrier to the catalog (Admin only)
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body types.InsuranceCarrierRequest true "Insurance carrier"
+// @Success 201 {object} types.InsuranceCarrierResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/insurance-carriers [post]
+func (h *InsuranceHandler) createCarrier(c *fiber.Ctx) error {
+	var request types.InsuranceCarrierRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	carrier, err := h.InsuranceService.CreateCarrier(request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(carrier)
+}
+
+// @Summary Update Insurance Carrier
+// @Description Rename a carrier, change its aliases and plan types, or retire it (Admin only)
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Insurance carrier ID"
+// @Param body body types.InsuranceCarrierRequest true "Insurance carrier"
+// @Success 200 {object} types.InsuranceCarrierResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Insurance carrier not found"
+// @Router /api/v1/insurance-carriers/{id} [put]
+func (h *InsuranceHandler) updateCarrier(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid insurance carrier ID"})
+	}
+
+	var request types.InsuranceCarrierRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	carrier, err := h.InsuranceService.UpdateCarrier(id, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(carrier)
+}
+
+// @Summary Get Organization Insurances
+// @Description Get the carriers an organization accepts or declines. With a location, its own entries replace the organization-wide ones.
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param location_id query string false "Location ID"
+// @Success 200 {array} types.OrganizationInsuranceResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/insurances [get]
+func (h *InsuranceHandler) getInsurances(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	locationID, err := parseOptionalUUID(c.Query("location_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+	}
+
+	insurances, err := h.InsuranceService.GetInsurances(orgID.String(), locationID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(insurances)
+}
+
+// @Summary Replace Organization Insurances
+// @Description Replace the carriers of an organization, or of one of its locations when location_id is set (Admin only)
+// @Tags Insurance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.OrganizationInsurancesRequest true "Insurances"
+// @Success 200 {array} types.OrganizationInsuranceResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/insurances [put]
+func (h *InsuranceHandler) replaceInsurances(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.OrganizationInsurancesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	insurances, err := h.InsuranceService.ReplaceInsurances(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(insurances)
+}
+
+// @Summary Match Insurance
+// @Description Answer "do you take X?" by fuzzy matching the caller's phrasing against the carrier catalog and the organization's accepted carriers. The voice agent passes its API key in the X-Api-Key header instead of a bearer token.
+// @Tags Insurance
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param X-Api-Key header string false "API key of the voice agent"
+// @Param q query string true "Insurance as named by the caller"
+// @Param location_id query string false "Location ID"
+// @Success 200 {object} types.InsuranceMatchResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/insurances/match [get]
+func (h *InsuranceHandler) matchInsurance(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	locationID, err := parseOptionalUUID(c.Query("location_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+	}
+
+	match, err := h.InsuranceService.Match(orgID.String(), locationID, c.Query("q"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(match)
+}
@@ -0,0 +1,300 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type KnowledgeHandler struct {
+	KnowledgeService   service.KnowledgeService
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+	organizationAgent  fiber.Handler
+}
+
+// NewKnowledgeHandler creates a KnowledgeHandler. organizationMember guards
+// the endpoints reading the knowledge base of an organization and
+// organizationAdmin the ones changing it. organizationAgent also lets the
+// voice agent of the organization search it during calls.
+func NewKnowledgeHandler(knowledgeService service.KnowledgeService, organizationMember fiber.Handler, organizationAdmin fiber.Handler, organizationAgent fiber.Handler) *KnowledgeHandler {
+	return &KnowledgeHandler{
+		KnowledgeService:   knowledgeService,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+		organizationAgent:  organizationAgent,
+	}
+}
+
+func (h *KnowledgeHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/knowledge-entries", h.organizationMember, h.getEntries)
+	app.Post("/api/v1/organizations/:organizationId/knowledge-entries", h.organizationAdmin, h.createEntry)
+	app.Get("/api/v1/organizations/:organizationId/knowledge-entries/categories", h.organizationMember, h.getCategories)
+	app.Put("/api/v1/organizations/:organizationId/knowledge-entries/order", h.organizationAdmin, h.reorderEntries)
+	app.Get("/api/v1/organizations/:organizationId/knowledge-entries/:id", h.organizationMember, h.getEntry)
+	app.Put("/api/v1/organizations/:organizationId/knowledge-entries/:id", h.organizationAdmin, h.updateEntry)
+	app.Delete("/api/v1/organizations/:organizationId/knowledge-entries/:id", h.organizationAdmin, h.deleteEntry)
+	app.Get("/api/v1/organizations/:organizationId/knowledge/search", h.organizationAgent, h.search)
+}
+
+// @Summary Get Knowledge Entries
+// @Description Get the knowledge base entries of an organization in order
+// @Tags Knowledge
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param kind query string false "faq or article"
+// @Param category query string false "Category"
+// @Success 200 {array} types.KnowledgeEntryResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries [get]
+func (h *KnowledgeHandler) getEntries(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	kind := c.Query("kind")
+	if kind != "" && kind != entity.KnowledgeFAQ && kind != entity.KnowledgeArticle {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid kind, expected faq or article"})
+	}
+
+	entries, err := h.KnowledgeService.GetEntries(orgID.String(), kind, c.Query("category"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(toKnowledgeEntryResponses(entries))
+}
+
+// @Summary Get Knowledge Entry
+// @Description Get a knowledge base entry with its text in every language
+// @Tags Knowledge
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Knowledge entry ID"
+// @Success 200 {object} types.KnowledgeEntryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Knowledge entry not found"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries/{id} [get]
+func (h *KnowledgeHandler) getEntry(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid knowledge entry ID"})
+	}
+
+	entry, err := h.KnowledgeService.GetEntry(orgID.String(), id.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToKnowledgeEntryResponse(*entry))
+}
+
+// @Summary Get Knowledge Categories
+// @Description Get the categories used by the knowledge base of an organization
+// @Tags Knowledge
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} string
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries/categories [get]
+func (h *KnowledgeHandler) getCategories(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	categories, err := h.KnowledgeService.GetCategories(orgID.String())
+	if err != nil {
+		return err
+	}
+	if categories == nil {
+		categories = []string{}
+	}
+	return c.Status(fiber.StatusOK).JSON(categories)
+}
+
+// @Summary Create Knowledge Entry
+// @Description Add a question and answer or an article to the knowledge base, after the existing entries (Admin only)
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.KnowledgeEntryRequest true "Knowledge entry"
+// @Success 201 {object} types.KnowledgeEntryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries [post]
+func (h *KnowledgeHandler) createEntry(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.KnowledgeEntryRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	entry, err := h.KnowledgeService.CreateEntry(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToKnowledgeEntryResponse(*entry))
+}
+
+// @Summary Update Knowledge Entry
+// @Description Update a knowledge base entry. Its text is replaced in every language. (Admin only)
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Knowledge entry ID"
+// @Param body body types.KnowledgeEntryRequest true "Knowledge entry"
+// @Success 200 {object} types.KnowledgeEntryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Not found"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries/{id} [put]
+func (h *KnowledgeHandler) updateEntry(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid knowledge entry ID"})
+	}
+
+	var request types.KnowledgeEntryRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	entry, err := h.KnowledgeService.UpdateEntry(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToKnowledgeEntryResponse(*entry))
+}
+
+// @Summary Delete Knowledge Entry
+// @Description Delete a knowledge base entry (Admin only)
+// @Tags Knowledge
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Knowledge entry ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Knowledge entry not found"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries/{id} [delete]
+func (h *KnowledgeHandler) deleteEntry(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid knowledge entry ID"})
+	}
+
+	if err := h.KnowledgeService.DeleteEntry(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Reorder Knowledge Entries
+// @Description Set the order of the knowledge base entries. Every entry must be listed once. (Admin only)
+// @Tags Knowledge
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.KnowledgeOrderRequest true "Entry ids in order"
+// @Success 200 {array} types.KnowledgeEntryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/knowledge-entries/order [put]
+func (h *KnowledgeHandler) reorderEntries(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.KnowledgeOrderRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	entries, err := h.KnowledgeService.ReorderEntries(orgID.String(), request.EntryIds)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(toKnowledgeEntryResponses(entries))
+}
+
+// @Summary Search Knowledge Base
+// @Description Find the enabled knowledge base entries answering a caller's question, most relevant first. Used by the voice agent during calls, which passes its API key in the X-Api-Key header instead of a bearer token.
+// @Tags Knowledge
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param X-Api-Key header string false "API key of the voice agent"
+// @Param q query string true "Question or keywords"
+// @Param language query string false "english, french or spanish"
+// @Param category query string false "Category"
+// @Param limit query int false "Maximum number of results (default 5, at most 20)"
+// @Success 200 {object} types.KnowledgeSearchResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/knowledge/search [get]
+func (h *KnowledgeHandler) search(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	results, err := h.KnowledgeService.Search(orgID.String(), c.Query("q"), c.Query("language"), c.Query("category"), c.QueryInt("limit"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(results)
+}
+
+func toKnowledgeEntryResponses(entries []entity.KnowledgeEntry) []types.KnowledgeEntryResponse {
+	response := make([]types.KnowledgeEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, service.ToKnowledgeEntryResponse(entry))
+	}
+	return response
+}
@@ -0,0 +1,231 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type LocationHandler struct {
+	LocationService    service.LocationService
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+}
+
+// NewLocationHandler creates a LocationHandler. organizationMember guards the
+// endpoints reading the locations of an organization and organizationAdmin
+// the ones changing them.
+func NewLocationHandler(locationService service.LocationService, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *LocationHandler {
+	return &LocationHandler{
+		LocationService:    locationService,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+	}
+}
+
+func (h *LocationHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/locations", h.organizationMember, h.getLocations)
+	app.Post("/api/v1/organizations/:organizationId/locations", h.organizationAdmin, h.createLocation)
+	app.Get("/api/v1/organizations/:organizationId/locations/:id", h.organizationMember, h.getLocationById)
+	app.Put("/api/v1/organizations/:organizationId/locations/:id", h.organizationAdmin, h.updateLocation)
+	app.Delete("/api/v1/organizations/:organizationId/locations/:id", h.organizationAdmin, h.deleteLocation)
+	app.Put("/api/v1/organizations/:organizationId/locations/:id/users", h.organizationAdmin, h.assignUsers)
+}
+
+// @Summary Get Locations by Organization
+// @Description Retrieve all locations (offices) of an organization
+// @Tags Location
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {array} types.LocationResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/locations [get]
+func (h *LocationHandler) getLocations(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	locations, err := h.LocationService.GetLocations(orgID.String())
+	if err != nil {
+		return err
+	}
+
+	response := make([]types.LocationResponse, 0, len(locations))
+	for _, location := range locations {
+		response = append(response, service.ToLocationResponse(location))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Create Location
+// @Description Add a new location to an organization. The first location becomes the default one. (Admin only)
+// @Tags Location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.LocationRequest true "Location"
+// @Success 201 {object} types.LocationResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/locations [post]
+func (h *LocationHandler) createLocation(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.LocationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	location, err := h.LocationService.CreateLocation(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToLocationResponse(*location))
+}
+
+// @Summary Get Location by id
+// @Description Get a location of an organization by its ID
+// @Tags Location
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Location ID"
+// @Success 200 {object} types.LocationResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/organizations/{organizationId}/locations/{id} [get]
+func (h *LocationHandler) getLocationById(c *fiber.Ctx) error {
+	orgID, locationID, err := parseLocationParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	location, err := h.LocationService.GetLocationById(orgID.String(), locationID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToLocationResponse(*location))
+}
+
+// @Summary Update Location
+// @Description Update all fields and relationships of a location (Admin only)
+// @Tags Location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Location ID"
+// @Param body body types.LocationRequest true "Location"
+// @Success 200 {object} types.LocationResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/organizations/{organizationId}/locations/{id} [put]
+func (h *LocationHandler) updateLocation(c *fiber.Ctx) error {
+	orgID, locationID, err := parseLocationParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var request types.LocationRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	location, err := h.LocationService.UpdateLocation(orgID.String(), locationID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToLocationResponse(*location))
+}
+
+// @Summary Delete Location
+// @Description Delete a location. The default location cannot be deleted. (Admin only)
+// @Tags Location
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Location ID"
+// @Success 204
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/organizations/{organizationId}/locations/{id} [delete]
+func (h *LocationHandler) deleteLocation(c *fiber.Ctx) error {
+	orgID, locationID, err := parseLocationParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.LocationService.DeleteLocation(orgID.String(), locationID.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Assign Users to Location
+// @Description Replace the staff users working at a location (Admin only)
+// @Tags Location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Location ID"
+// @Param body body types.LocationUsersRequest true "User IDs"
+// @Success 200 {object} types.LocationResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Location not found"
+// @Router /api/v1/organizations/{organizationId}/locations/{id}/users [put]
+func (h *LocationHandler) assignUsers(c *fiber.Ctx) error {
+	orgID, locationID, err := parseLocationParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var request types.LocationUsersRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	location, err := h.LocationService.AssignUsers(orgID.String(), locationID.String(), request.UserIds)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToLocationResponse(*location))
+}
+
+func parseLocationParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid organization ID")
+	}
+	locationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid location ID")
+	}
+	return orgID, locationID, nil
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// maxDeliveriesPageSize bounds the page size of the delivery log.
+const maxDeliveriesPageSize = 200
+
+type NotificationHandler struct {
+	NotificationService service.NotificationService
+	authenticated       fiber.Handler
+	organizationAdmin   fiber.Handler
+}
+
+// NewNotificationHandler creates a NotificationHandler. authenticated
+// resolves the current user managing notification preferences;
+// organizationAdmin guards the delivery log and test notifications of an
+// organization.
+func NewNotificationHandler(notificationService service.NotificationService, authenticated fiber.Handler, organizationAdmin fiber.Handler) *NotificationHandler {
+	return &NotificationHandler{
+		NotificationService: notificationService,
+		authenticated:       authenticated,
+		organizationAdmin:   organizationAdmin,
+	}
+}
+
+func (h *NotificationHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/users/:id/notification-preferences", h.authenticated, h.getPreferences)
+	app.Put("/api/v1/users/:id/notification-preferences", h.authenticated, h.updatePreferences)
+	app.Get("/api/v1/organizations/:organizationId/notification-deliveries", h.organizationAdmin, h.getDeliveries)
+	app.Post("/api/v1/organizations/:organizationId/notifications/test", h.organizationAdmin, h.sendTest)
+}
+
+// @Summary Get Notification Preferences
+// @Description Get the organization notification channels and the overrides of a user. Users see their own preferences, and admins those of the users of their organization.
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} types.NotificationPreferencesResponse
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "User not found"
+// @Router /api/v1/users/{id}/notification-preferences [get]
+func (h *NotificationHandler) getPreferences(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	preferences, err := h.NotificationService.GetPreferences(user, userID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(preferences)
+}
+
+// @Summary Update Notification Preferences
+// @Description Replace the notification overrides of a user. A preference without event applies to every event; an unset channel inherits the organization default. Users change their own preferences, and admins those of the users of their organization.
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param body body types.NotificationPreferencesRequest true "Preferences"
+// @Success 200 {object} types.NotificationPreferencesResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "User not found"
+// @Router /api/v1/users/{id}/notification-preferences [put]
+func (h *NotificationHandler) updatePreferences(c *fiber.Ctx) error {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var request types.NotificationPreferencesRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	preferences, err := h.NotificationService.UpdatePreferences(user, userID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(preferences)
+}
+
+// @Summary Get Notification Deliveries
+// @Description Get the notification delivery log of an organization, newest first (Admin only)
+// @Tags Notification
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param status query string false "pending, sent, failed or skipped"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param offset query int false "Offset"
+// @Success 200 {array} types.NotificationDeliveryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/notification-deliveries [get]
+func (h *NotificationHandler) getDeliveries(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	status := c.Query("status")
+	switch entity.DeliveryStatus(status) {
+	case "", entity.DeliveryPending, entity.DeliverySent, entity.DeliveryFailed, entity.DeliverySkipped:
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid status"})
+	}
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+	if limit <= 0 || limit > maxDeliveriesPageSize || offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid pagination"})
+	}
+
+	deliveries, err := h.NotificationService.GetDeliveries(orgID.String(), status, limit, offset)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(deliveries)
+}
+
+// @Summary Send Test Notification
+// @Description Send the test notification to a user, or to every user of the organization, on their enabled channels (Admin only)
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.NotificationTestRequest false "Recipient"
+// @Success 202 {array} types.NotificationDeliveryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/notifications/test [post]
+func (h *NotificationHandler) sendTest(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.NotificationTestRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+
+	deliveries, err := h.NotificationService.SendTest(orgID.String(), request.UserId)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(deliveries)
+}
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type OnboardingHandler struct {
+	OnboardingService  service.OnboardingService
+	organizationMember fiber.Handler
+	organizationAdmin  fiber.Handler
+}
+
+// NewOnboardingHandler creates an OnboardingHandler. organizationMember guards
+// the onboarding checklist of an organization and organizationAdmin the
+// reminders.
+func NewOnboardingHandler(onboardingService service.OnboardingService, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *OnboardingHandler {
+	return &OnboardingHandler{
+		OnboardingService:  onboardingService,
+		organizationMember: organizationMember,
+		organizationAdmin:  organizationAdmin,
+	}
+}
+
+func (h *OnboardingHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/onboarding", h.organizationMember, h.getChecklist)
+	app.Post("/api/v1/organizations/:organizationId/onboarding/remind", h.organizationAdmin, h.sendReminder)
+}
+
+// @Summary Get Onboarding Checklist
+// @Description Get the setup steps of an organization and which of them are done
+// @Tags Onboarding
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 200 {object} types.OnboardingChecklistResponse
+// @Failure 400 {object} errors.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/onboarding [get]
+func (h *OnboardingHandler) getChecklist(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	checklist, err := h.OnboardingService.GetChecklist(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(checklist)
+}
+
+// @Summary Send Onboarding Reminder
+// @Description Remind the users of an organization of the setup steps left (Admin only)
+// @Tags Onboarding
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Success 202 {array} types.NotificationDeliveryResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Organization not found"
+// @Router /api/v1/organizations/{organizationId}/onboarding/remind [post]
+func (h *OnboardingHandler) sendReminder(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	deliveries, err := h.OnboardingService.SendReminder(orgID.String())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(deliveries)
+}
@@ -22,7 +22,6 @@ func NewOrganizationHandler(organizationService service.OrganizationService) *Or
 func (Organization *OrganizationHandler) Register(app *fiber.App) {
 	app.Put("/api/v1/organizations/:id", Organization.addOrUpdateOrganization)
 	app.Get("/api/v1/organizations/:id", Organization.getOrganizationById)
-	app.Get("/api/v1/organizations/specialty", Organization.getOrganizationSpeciality)
 	app.Post("/api/v1/organizations/:organizationId/users", Organization.registerUserRelatedByOrganization)
 	app.Get("/api/v1/organizations/:organizationId/users", Organization.getUsersByOrganization)
 	app.Get("/api/v1/organizations/user", Organization.getOrginizationByUserId)
@@ -47,9 +46,12 @@ func (Organization *OrganizationHandler) addOrUpdateOrganization(c *fiber.Ctx) e
 
 	// Parse Request Body
 	var updateRequest types.OrganizationRequest
-	if err := c.BodyParser(updateRequest); err != nil {
+	if err := c.BodyParser(&updateRequest); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
+	if err := validateRequest(&updateRequest); err != nil {
+		return err
+	}
 
 	// Call service to update
 	updatedOrg, err := Organization.OrganizationService.UpdateOrganization(orgID, updateRequest)
@@ -78,19 +80,6 @@ func (Organization *OrganizationHandler) getOrganizationById(c *fiber.Ctx) error
 	return c.Status(fiber.StatusOK).JSON(org)
 }
 
-// Get Organization speciality
-// @Summary Get Organization speciality
-// @Description Get Organization speciality
-// @Tags Organization
-// @Produce  json
-// @Success 200 {object} entity.Speciality
-// @Router /api/v1/organizations/speciality [get]
-func (Organization *OrganizationHandler) getOrganizationSpeciality(c *fiber.Ctx) error {
-
-	/*entity.Speciality.GetFlatSpecialityList()*/
-	return nil
-}
-
 // registerUserRelatedByOrganization registers a user related to an organization.
 // @Summary Register User Related to Organization
 // @Description Register a user related to an organization
@@ -0,0 +1,268 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ScheduleExceptionHandler struct {
+	ScheduleExceptionService service.ScheduleExceptionService
+	organizationMember       fiber.Handler
+	organizationAdmin        fiber.Handler
+}
+
+// NewScheduleExceptionHandler creates a ScheduleExceptionHandler.
+// organizationMember guards the endpoints reading the exceptions and calendar
+// of an organization and organizationAdmin the ones changing them.
+func NewScheduleExceptionHandler(scheduleExceptionService service.ScheduleExceptionService, organizationMember fiber.Handler, organizationAdmin fiber.Handler) *ScheduleExceptionHandler {
+	return &ScheduleExceptionHandler{
+		ScheduleExceptionService: scheduleExceptionService,
+		organizationMember:       organizationMember,
+		organizationAdmin:        organizationAdmin,
+	}
+}
+
+func (h *ScheduleExceptionHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/organizations/:organizationId/schedule-exceptions", h.organizationMember, h.getExceptions)
+	app.Post("/api/v1/organizations/:organizationId/schedule-exceptions", h.organizationAdmin, h.createException)
+	app.Post("/api/v1/organizations/:organizationId/schedule-exceptions/holidays", h.organizationAdmin, h.importHolidays)
+	app.Put("/api/v1/organizations/:organizationId/schedule-exceptions/:id", h.organizationAdmin, h.updateException)
+	app.Delete("/api/v1/organizations/:organizationId/schedule-exceptions/:id", h.organizationAdmin, h.deleteException)
+	app.Get("/api/v1/organizations/:organizationId/calendar", h.organizationMember, h.getCalendar)
+}
+
+// @Summary Get Schedule Exceptions
+// @Description List holiday and closure exceptions of an organization between two dates
+// @Tags Schedule
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param from query string true "First date (YYYY-MM-DD)"
+// @Param to query string true "Last date (YYYY-MM-DD)"
+// @Param location_id query string false "Only exceptions of this location"
+// @Param user_id query string false "Only exceptions of this user"
+// @Success 200 {array} types.ScheduleExceptionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/schedule-exceptions [get]
+func (h *ScheduleExceptionHandler) getExceptions(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return err
+	}
+	locationID, err := parseOptionalUUID(c.Query("location_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+	}
+	userID, err := parseOptionalUUID(c.Query("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	exceptions, err := h.ScheduleExceptionService.GetExceptions(orgID.String(), locationID, userID, from, to)
+	if err != nil {
+		return err
+	}
+	response := make([]types.ScheduleExceptionResponse, 0, len(exceptions))
+	for _, exception := range exceptions {
+		response = append(response, service.ToScheduleExceptionResponse(exception))
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Create Schedule Exception
+// @Description Close all day or set special hours on a date, for the organization, a location or a user (Admin only)
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.ScheduleExceptionRequest true "Exception"
+// @Success 201 {object} types.ScheduleExceptionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/schedule-exceptions [post]
+func (h *ScheduleExceptionHandler) createException(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.ScheduleExceptionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	exception, err := h.ScheduleExceptionService.CreateException(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(service.ToScheduleExceptionResponse(*exception))
+}
+
+// @Summary Update Schedule Exception
+// @Description Update a holiday or closure exception (Admin only)
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Exception ID"
+// @Param body body types.ScheduleExceptionRequest true "Exception"
+// @Success 200 {object} types.ScheduleExceptionResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Exception not found"
+// @Router /api/v1/organizations/{organizationId}/schedule-exceptions/{id} [put]
+func (h *ScheduleExceptionHandler) updateException(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid exception ID"})
+	}
+
+	var request types.ScheduleExceptionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	exception, err := h.ScheduleExceptionService.UpdateException(orgID.String(), id.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(service.ToScheduleExceptionResponse(*exception))
+}
+
+// @Summary Delete Schedule Exception
+// @Description Delete a holiday or closure exception (Admin only)
+// @Tags Schedule
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param id path string true "Exception ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Exception not found"
+// @Router /api/v1/organizations/{organizationId}/schedule-exceptions/{id} [delete]
+func (h *ScheduleExceptionHandler) deleteException(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid exception ID"})
+	}
+
+	if err := h.ScheduleExceptionService.DeleteException(orgID.String(), id.String()); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Import Holidays
+// @Description Close the organization or a location on every statutory holiday of a province for a year (Admin only)
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param body body types.HolidayImportRequest true "Holiday import"
+// @Success 200 {object} types.HolidayImportResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/schedule-exceptions/holidays [post]
+func (h *ScheduleExceptionHandler) importHolidays(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+
+	var request types.HolidayImportRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	response, err := h.ScheduleExceptionService.ImportHolidays(orgID.String(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// @Summary Get Calendar
+// @Description Preview the effective opening hours of every date in a range, with exceptions applied over the weekly schedule
+// @Tags Schedule
+// @Produce json
+// @Security BearerAuth
+// @Param organizationId path string true "Organization ID"
+// @Param from query string true "First date (YYYY-MM-DD)"
+// @Param to query string true "Last date (YYYY-MM-DD)"
+// @Param location_id query string false "Location ID"
+// @Param user_id query string false "Staff user ID"
+// @Success 200 {object} types.CalendarResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/organizations/{organizationId}/calendar [get]
+func (h *ScheduleExceptionHandler) getCalendar(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("organizationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid organization ID"})
+	}
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return err
+	}
+	locationID, err := parseOptionalUUID(c.Query("location_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid location ID"})
+	}
+	userID, err := parseOptionalUUID(c.Query("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	calendar, err := h.ScheduleExceptionService.Calendar(orgID.String(), locationID, userID, from, to)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(calendar)
+}
+
+// parseDateRange reads the mandatory from and to query dates.
+func parseDateRange(c *fiber.Ctx) (time.Time, time.Time, error) {
+	from, err := time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fiber.NewError(fiber.StatusBadRequest, "Invalid from, expected YYYY-MM-DD")
+	}
+	to, err := time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fiber.NewError(fiber.StatusBadRequest, "Invalid to, expected YYYY-MM-DD")
+	}
+	return from, to, nil
+}
@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SpecialityHandler struct {
+	SpecialityService service.SpecialityService
+	adminOnly         fiber.Handler
+}
+
+// NewSpecialityHandler creates a SpecialityHandler. adminOnly guards the
+// endpoints changing the taxonomy.
+func NewSpecialityHandler(specialityService service.SpecialityService, adminOnly fiber.Handler) *SpecialityHandler {
+	return &SpecialityHandler{
+		SpecialityService: specialityService,
+		adminOnly:         adminOnly,
+	}
+}
+
+func (h *SpecialityHandler) Register(app *fiber.App) {
+	app.Get("/api/v1/specialities", h.getSpecialities)
+	app.Post("/api/v1/specialities", h.adminOnly, h.createSpeciality)
+	app.Put("/api/v1/specialities/:id", h.adminOnly, h.updateSpeciality)
+	app.Delete("/api/v1/specialities/:id", h.adminOnly, h.retireSpeciality)
+}
+
+// @Summary Get Specialities
+// @Description Get the speciality taxonomy as a tree or a flat list, optionally filtered by name
+// @Tags Speciality
+// @Produce json
+// @Param format query string false "tree (default) or flat"
+// @Param q query string false "Search by name"
+// @Param include_retired query bool false "Include retired specialities"
+// @Success 200 {array} types.SpecialityResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Router /api/v1/specialities [get]
+func (h *SpecialityHandler) getSpecialities(c *fiber.Ctx) error {
+	search := c.Query("q")
+	includeRetired := c.QueryBool("include_retired", false)
+
+	var specialities []types.SpecialityResponse
+	var err error
+	switch c.Query("format", "tree") {
+	case "tree":
+		specialities, err = h.SpecialityService.GetTree(search, includeRetired)
+	case "flat":
+		specialities, err = h.SpecialityService.GetFlat(search, includeRetired)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid format, expected tree or flat"})
+	}
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(specialities)
+}
+
+// @Summary Create Speciality
+// @Description Add a speciality to the taxonomy (Admin only)
+// @Tags Speciality
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body types.SpecialityRequest true "Speciality"
+// @Success 201 {object} types.SpecialityResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Router /api/v1/specialities [post]
+func (h *SpecialityHandler) createSpeciality(c *fiber.Ctx) error {
+	var request types.SpecialityRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	speciality, err := h.SpecialityService.CreateSpeciality(request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(speciality)
+}
+
+// @Summary Update Speciality
+// @Description Rename, reparent, retire or restore a speciality. Retiring it retires its descendants too; an omitted retired flag or parent_id leaves it unchanged, and a parent_id of 0 moves it to the root. (Admin only)
+// @Tags Speciality
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Speciality ID"
+// @Param body body types.SpecialityRequest true "Speciality"
+// @Success 200 {object} types.SpecialityResponse
+// @Failure 400 {object} errors.ErrorResponse "Bad Request"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Speciality not found"
+// @Router /api/v1/specialities/{id} [put]
+func (h *SpecialityHandler) updateSpeciality(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid speciality ID"})
+	}
+
+	var request types.SpecialityRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	speciality, err := h.SpecialityService.UpdateSpeciality(id, request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(speciality)
+}
+
+// @Summary Retire Speciality
+// @Description Retire a speciality and its descendants. They are hidden from the picker but organizations referencing them keep working. (Admin only)
+// @Tags Speciality
+// @Security BearerAuth
+// @Param id path int true "Speciality ID"
+// @Success 204
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "Speciality not found"
+// @Router /api/v1/specialities/{id} [delete]
+func (h *SpecialityHandler) retireSpeciality(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid speciality ID"})
+	}
+
+	if err := h.SpecialityService.RetireSpeciality(id); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
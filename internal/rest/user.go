@@ -1,18 +1,26 @@
 package rest
 
 import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/middleware"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/service"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type UserHandler struct {
-	userService service.UserService
+	userService   service.UserService
+	authenticated fiber.Handler
 }
 
-func NewUserHandler(userService service.UserService) *UserHandler {
+// NewUserHandler creates a UserHandler. authenticated resolves the current
+// user, who can only change their own schedule unless they are an Admin of
+// the organization of the user.
+func NewUserHandler(userService service.UserService, authenticated fiber.Handler) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:   userService,
+		authenticated: authenticated,
 	}
 }
 
@@ -20,7 +28,7 @@ func (user *UserHandler) Register(app *fiber.App) {
 	app.Put("/api/v1/users", user.addOrUpdateUser)
 	app.Get("/api/v1/users/:id", user.getUserById)
 	app.Get("/api/v1/users/role/:role", user.getUsersByRole)
-	app.Get("/api/v1/users/role/:role", user.getUsersByRole)
+	app.Put("/api/v1/users/:id/schedules", user.authenticated, user.updateUserSchedules)
 }
 
 // Add or Update a User such as Admin, Staff, Support
@@ -43,14 +51,54 @@ func (u *UserHandler) addOrUpdateUser(c *fiber.Ctx) error {
 // @Description Get User by id
 // @Tags User
 // @Produce  json
-// @Param   user_id path string true "User ID"
+// @Param   id path string true "User ID"
 // @Success 200 {object} types.UserResponse
-// @Router /api/v1/users/{user_id} [get]
+// @Router /api/v1/users/{id} [get]
 func (u *UserHandler) getUserById(c *fiber.Ctx) error {
 
 	return c.JSON(types.UserResponse{})
 }
 
+// Update the weekly schedule of a User
+// @Summary Update User schedule
+// @Description Replace the weekly schedule of a staff member. Ranges of the same day must not overlap; split shifts are allowed. Users can change their own schedule, and the Admins of their organization can change it too.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param   id path string true "User ID"
+// @Param   body body types.UserScheduleRequest true "Schedule"
+// @Success 200 {array} types.DailySchedule
+// @Failure 400 {object} errors.ErrorResponse "Invalid schedule"
+// @Failure 401 {object} errors.ErrorResponse "Unauthorized"
+// @Failure 403 {object} errors.ErrorResponse "Forbidden"
+// @Failure 404 {object} errors.ErrorResponse "User not found"
+// @Router /api/v1/users/{id}/schedules [put]
+func (u *UserHandler) updateUserSchedules(c *fiber.Ctx) error {
+	actor, ok := middleware.CurrentUser(c)
+	if !ok {
+		return errors.Unauthorized("")
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var request types.UserScheduleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if err := validateRequest(&request); err != nil {
+		return err
+	}
+
+	schedules, err := u.userService.UpdateSchedules(actor, id.String(), request.DailySchedules)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(schedules)
+}
+
 // Get Users by role
 // @Summary Get Users by role
 // @Description Get Users by role
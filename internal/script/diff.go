@@ -0,0 +1,71 @@
+package script
+
+import "regexp"
+
+// Operations of a diff chunk.
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// Chunk is a run of text kept, inserted or deleted between two scripts.
+type Chunk struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+var diffToken = regexp.MustCompile(`\s+|[^\s]+`)
+
+// Diff compares two scripts word by word. Concatenating the equal and delete
+// chunks gives the old script back, the equal and insert chunks the new one.
+func Diff(old string, new string) []Chunk {
+	a := diffToken.FindAllString(old, -1)
+	b := diffToken.FindAllString(new, -1)
+
+	// lengths[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var chunks []Chunk
+	add := func(op string, text string) {
+		if n := len(chunks); n > 0 && chunks[n-1].Op == op {
+			chunks[n-1].Text += text
+			return
+		}
+		chunks = append(chunks, Chunk{Op: op, Text: text})
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(DiffEqual, a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			add(DiffDelete, a[i])
+			i++
+		default:
+			add(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(DiffInsert, b[j])
+	}
+	return chunks
+}
@@ -0,0 +1,172 @@
+package script
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Period is a span of opening hours.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Context holds what the variables of a script are computed from. Times are
+// formatted in the time zone of At.
+type Context struct {
+	Language         string
+	At               time.Time
+	OrganizationName string
+	LocationName     string
+	Today            []Period
+	NextOpening      *time.Time
+	ForwardingNumber string
+	Insurers         []string
+	Specialities     []string
+}
+
+// locale holds the wording of a language. Languages without a locale use
+// English.
+type locale struct {
+	clock    func(t time.Time) string
+	span     string
+	and      string
+	closed   string
+	today    string
+	tomorrow string
+	at       string
+	weekdays [7]string
+	months   [12]string
+	date     func(l locale, t time.Time) string
+	// hints are words telling that a caller speaks the language
+	hints []string
+}
+
+var locales = map[string]locale{
+	"english": {
+		clock:    func(t time.Time) string { return t.Format("3:04 PM") },
+		span:     "to",
+		and:      "and",
+		closed:   "closed",
+		today:    "today",
+		tomorrow: "tomorrow",
+		at:       "at",
+		weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		date: func(l locale, t time.Time) string {
+			return fmt.Sprintf("%s, %s %d", l.weekdays[t.Weekday()], l.months[t.Month()-1], t.Day())
+		},
+		hints: []string{"hello", "hi", "english", "yes"},
+	},
+	"french": {
+		clock: func(t time.Time) string {
+			if t.Minute() == 0 {
+				return fmt.Sprintf("%d h", t.Hour())
+			}
+			return fmt.Sprintf("%d h %02d", t.Hour(), t.Minute())
+		},
+		span:     "à",
+		and:      "et",
+		closed:   "fermé",
+		today:    "aujourd'hui",
+		tomorrow: "demain",
+		at:       "à",
+		weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		date: func(l locale, t time.Time) string {
+			return fmt.Sprintf("%s %d %s", l.weekdays[t.Weekday()], t.Day(), l.months[t.Month()-1])
+		},
+		hints: []string{"bonjour", "allô", "français", "oui"},
+	},
+	"spanish": {
+		clock:    func(t time.Time) string { return fmt.Sprintf("%d:%02d", t.Hour(), t.Minute()) },
+		span:     "a",
+		and:      "y",
+		closed:   "cerrado",
+		today:    "hoy",
+		tomorrow: "mañana",
+		at:       "a las",
+		weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		date: func(l locale, t time.Time) string {
+			return fmt.Sprintf("%s %d de %s", l.weekdays[t.Weekday()], t.Day(), l.months[t.Month()-1])
+		},
+		hints: []string{"hola", "buenos días", "español", "sí"},
+	},
+}
+
+// DetectionHints returns the built-in words telling that a caller speaks a
+// language, used along with the hints of the agent profile.
+func DetectionHints(language string) []string {
+	return slices.Clone(locales[language].hints)
+}
+
+// Values computes the value of every variable.
+func (c Context) Values() map[string]string {
+	l, ok := locales[c.Language]
+	if !ok {
+		l = locales["english"]
+	}
+	zone := c.At.Location()
+
+	todayHours := l.closed
+	if len(c.Today) > 0 {
+		spans := make([]string, 0, len(c.Today))
+		for _, period := range c.Today {
+			spans = append(spans, fmt.Sprintf("%s %s %s", l.clock(period.Start.In(zone)), l.span, l.clock(period.End.In(zone))))
+		}
+		todayHours = l.join(spans)
+	}
+
+	nextOpening := ""
+	if c.NextOpening != nil {
+		nextOpening = l.relative(c.At, c.NextOpening.In(zone))
+	}
+
+	locationName := c.LocationName
+	if locationName == "" {
+		locationName = c.OrganizationName
+	}
+
+	return map[string]string{
+		OrganizationName: c.OrganizationName,
+		LocationName:     locationName,
+		TodayHours:       todayHours,
+		NextOpening:      nextOpening,
+		ForwardingNumber: c.ForwardingNumber,
+		AcceptedInsurers: l.join(c.Insurers),
+		Specialities:     l.join(c.Specialities),
+	}
+}
+
+// join lists items as "a, b and c".
+func (l locale) join(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + l.and + " " + items[len(items)-1]
+}
+
+// relative formats t relative to the day of now: "today at …", "tomorrow at
+// …", the weekday within a week, the full date after.
+func (l locale) relative(now time.Time, t time.Time) string {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	year, month, day = t.Date()
+	days := int(time.Date(year, month, day, 0, 0, 0, 0, now.Location()).Sub(today).Hours()+12) / 24
+
+	var date string
+	switch {
+	case days == 0:
+		date = l.today
+	case days == 1:
+		date = l.tomorrow
+	case days > 1 && days < 7:
+		date = l.weekdays[t.Weekday()]
+	default:
+		date = l.date(l, t)
+	}
+	return fmt.Sprintf("%s %s %s", date, l.at, l.clock(t))
+}
@@ -0,0 +1,154 @@
+package script
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValues(t *testing.T) {
+	zone, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a Wednesday
+	at := time.Date(2026, time.March, 11, 10, 30, 0, 0, zone)
+	on := func(day int, hour int, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, zone)
+	}
+	tomorrow := on(12, 9, 0)
+	friday := on(13, 13, 30)
+	nextMonth := time.Date(2026, time.April, 2, 8, 0, 0, 0, zone)
+	afterLunch := on(11, 13, 0).UTC()
+	today := []Period{{Start: on(11, 8, 0), End: on(11, 12, 0)}, {Start: on(11, 13, 0), End: on(11, 17, 30)}}
+
+	tests := []struct {
+		name    string
+		context Context
+		want    map[string]string
+	}{
+		{
+			name: "english",
+			context: Context{
+				Language:         "english",
+				At:               at,
+				OrganizationName: "Maple Dental",
+				LocationName:     "Plateau",
+				Today:            today,
+				NextOpening:      &tomorrow,
+				ForwardingNumber: "+15145550102",
+				Insurers:         []string{"Manulife", "Sun Life", "Blue Cross"},
+				Specialities:     []string{"Cleaning"},
+			},
+			want: map[string]string{
+				OrganizationName: "Maple Dental",
+				LocationName:     "Plateau",
+				TodayHours:       "8:00 AM to 12:00 PM and 1:00 PM to 5:30 PM",
+				NextOpening:      "tomorrow at 9:00 AM",
+				ForwardingNumber: "+15145550102",
+				AcceptedInsurers: "Manulife, Sun Life and Blue Cross",
+				Specialities:     "Cleaning",
+			},
+		},
+		{
+			name: "french",
+			context: Context{
+				Language:         "french",
+				At:               at,
+				OrganizationName: "Maple Dental",
+				Today:            today,
+				NextOpening:      &friday,
+				Insurers:         []string{"Manulife", "Sun Life"},
+			},
+			want: map[string]string{
+				OrganizationName: "Maple Dental",
+				LocationName:     "Maple Dental",
+				TodayHours:       "8 h à 12 h et 13 h à 17 h 30",
+				NextOpening:      "vendredi à 13 h 30",
+				ForwardingNumber: "",
+				AcceptedInsurers: "Manulife et Sun Life",
+				Specialities:     "",
+			},
+		},
+		{
+			name: "spanish closed",
+			context: Context{
+				Language:         "spanish",
+				At:               at,
+				OrganizationName: "Maple Dental",
+				NextOpening:      &nextMonth,
+			},
+			want: map[string]string{
+				OrganizationName: "Maple Dental",
+				LocationName:     "Maple Dental",
+				TodayHours:       "cerrado",
+				NextOpening:      "jueves 2 de abril a las 8:00",
+				ForwardingNumber: "",
+				AcceptedInsurers: "",
+				Specialities:     "",
+			},
+		},
+		{
+			name: "unknown language uses english",
+			context: Context{
+				Language:         "german",
+				At:               at,
+				OrganizationName: "Maple Dental",
+				NextOpening:      &tomorrow,
+			},
+			want: map[string]string{
+				OrganizationName: "Maple Dental",
+				LocationName:     "Maple Dental",
+				TodayHours:       "closed",
+				NextOpening:      "tomorrow at 9:00 AM",
+				ForwardingNumber: "",
+				AcceptedInsurers: "",
+				Specialities:     "",
+			},
+		},
+		{
+			name: "times in the zone of at",
+			context: Context{
+				Language:         "english",
+				At:               at,
+				OrganizationName: "Maple Dental",
+				Today:            []Period{{Start: on(11, 8, 0).UTC(), End: on(11, 12, 0).UTC()}},
+				NextOpening:      &afterLunch,
+			},
+			want: map[string]string{
+				OrganizationName: "Maple Dental",
+				LocationName:     "Maple Dental",
+				TodayHours:       "8:00 AM to 12:00 PM",
+				NextOpening:      "today at 1:00 PM",
+				ForwardingNumber: "",
+				AcceptedInsurers: "",
+				Specialities:     "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.context.Values()
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("%s = %q, want %q", name, got[name], want)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %d values, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestRenderValues(t *testing.T) {
+	zone := time.FixedZone("EST", -5*3600)
+	at := time.Date(2026, time.March, 11, 18, 0, 0, 0, zone)
+	next := time.Date(2026, time.March, 12, 9, 0, 0, 0, zone)
+	values := Context{Language: "english", At: at, OrganizationName: "Maple Dental", NextOpening: &next}.Values()
+
+	got := Render("{{organization_name}} is {{today_hours}} now and opens {{next_opening}}.", values)
+	want := "Maple Dental is closed now and opens tomorrow at 9:00 AM."
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Package script renders the agent scripts written by clinics. Scripts are
+// plain text with {{variable}} placeholders filled from the clinic setup.
+// Rendering is a pure function of the script and the values, so it gives the
+// same output for the same input.
+package script
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Variables usable in scripts.
+const (
+	OrganizationName = "organization_name"
+	LocationName     = "location_name"
+	TodayHours       = "today_hours"
+	NextOpening      = "next_opening"
+	ForwardingNumber = "forwarding_number"
+	AcceptedInsurers = "accepted_insurers"
+	Specialities     = "specialities"
+)
+
+// TimeVariables lists the variables whose value depends on the time a script
+// is rendered at.
+var TimeVariables = []string{TodayHours, NextOpening}
+
+// Variable describes a variable usable in scripts.
+type Variable struct {
+	Name        string
+	Description string
+}
+
+// Variables lists the variables usable in scripts.
+var Variables = []Variable{
+	{OrganizationName, "Name of the organization"},
+	{LocationName, "Name of the location the call is for, or the organization name"},
+	{TodayHours, "Opening hours of the day, or closed"},
+	{NextOpening, "When the clinic opens next, e.g. tomorrow at 9:00 AM"},
+	{ForwardingNumber, "Number calls are forwarded to"},
+	{AcceptedInsurers, "Insurance carriers accepted"},
+	{Specialities, "Services offered"},
+}
+
+var placeholder = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// UnknownVariablesError lists the placeholders of a script that are not
+// known variables.
+type UnknownVariablesError struct {
+	Names []string
+}
+
+func (e *UnknownVariablesError) Error() string {
+	return fmt.Sprintf("unknown variables: %s", strings.Join(e.Names, ", "))
+}
+
+// IsVariable tells whether name is a known variable.
+func IsVariable(name string) bool {
+	for _, variable := range Variables {
+		if variable.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Placeholders returns the variable names used by a script, in order of
+// first use.
+func Placeholders(text string) []string {
+	var names []string
+	for _, match := range placeholder.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Validate returns an *UnknownVariablesError when the script uses variables
+// that do not exist.
+func Validate(text string) error {
+	var unknown []string
+	for _, name := range Placeholders(text) {
+		if !IsVariable(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return &UnknownVariablesError{Names: unknown}
+	}
+	return nil
+}
+
+// Render replaces the placeholders of a script by their values. Unknown
+// variables are left as they are.
+func Render(text string, values map[string]string) string {
+	return placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return match
+	})
+}
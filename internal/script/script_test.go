@@ -0,0 +1,80 @@
+package script
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	values := map[string]string{
+		OrganizationName: "Maple Dental",
+		TodayHours:       "8:00 AM to 5:00 PM",
+		Specialities:     "",
+	}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"no placeholder", "Hello.", "Hello."},
+		{"placeholder", "Welcome to {{organization_name}}.", "Welcome to Maple Dental."},
+		{"spaces inside braces", "Welcome to {{ organization_name }}.", "Welcome to Maple Dental."},
+		{"repeated placeholder", "{{organization_name}}, {{organization_name}}", "Maple Dental, Maple Dental"},
+		{"several placeholders", "{{organization_name}} is open {{today_hours}}.", "Maple Dental is open 8:00 AM to 5:00 PM."},
+		{"empty value", "We offer {{specialities}}.", "We offer ."},
+		{"variable without value", "Call {{forwarding_number}}.", "Call {{forwarding_number}}."},
+		{"unknown variable", "Hi {{caller_name}}.", "Hi {{caller_name}}."},
+		{"single braces", "Hi {organization_name}.", "Hi {organization_name}."},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.text, values); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		unknown []string
+	}{
+		{"no placeholder", "Hello.", nil},
+		{"known variables", "{{organization_name}} opens {{next_opening}}.", nil},
+		{"every variable", "{{organization_name}} {{location_name}} {{today_hours}} {{next_opening}} {{forwarding_number}} {{accepted_insurers}} {{specialities}}", nil},
+		{"unknown variable", "Hi {{caller_name}}.", []string{"caller_name"}},
+		{"unknown variables in order", "{{b}} {{organization_name}} {{a}} {{b}}", []string{"b", "a"}},
+		{"wrong case", "{{Organization_Name}}", []string{"Organization_Name"}},
+		{"empty placeholder", "{{}}", []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.text)
+			if tt.unknown == nil {
+				if err != nil {
+					t.Errorf("Validate(%q) = %v, want nil", tt.text, err)
+				}
+				return
+			}
+			var unknown *UnknownVariablesError
+			if !errors.As(err, &unknown) {
+				t.Fatalf("Validate(%q) = %v, want *UnknownVariablesError", tt.text, err)
+			}
+			if !slices.Equal(unknown.Names, tt.unknown) {
+				t.Errorf("Validate(%q) names = %q, want %q", tt.text, unknown.Names, tt.unknown)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	got := Placeholders("{{today_hours}} {{ organization_name }} {{today_hours}}")
+	want := []string{"today_hours", "organization_name"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Placeholders() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/agentconfig"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/script"
+	"gorm.io/gorm"
+)
+
+// agentConfigHorizonDays is how far ahead special hours are listed.
+const agentConfigHorizonDays = 30
+
+// AgentConfigService compiles the configuration of the voice agent of an
+// organization from its published agent profiles and its setup.
+type AgentConfigService struct {
+	db           *gorm.DB
+	profiles     *AgentProfileService
+	availability *AvailabilityService
+	insurance    *InsuranceService
+	knowledge    *KnowledgeService
+}
+
+func NewAgentConfigService(db *gorm.DB, profiles *AgentProfileService, availability *AvailabilityService, insurance *InsuranceService, knowledge *KnowledgeService) *AgentConfigService {
+	return &AgentConfigService{db: db, profiles: profiles, availability: availability, insurance: insurance, knowledge: knowledge}
+}
+
+// Compile builds the agent configuration of an organization as of at. Scripts
+// are rendered for that time, and special hours are listed from that day on.
+func (s *AgentConfigService) Compile(organizationId string, at time.Time) (*agentconfig.Document, error) {
+	var organization entity.Organization
+	err := s.db.Preload("Specialities").
+		Preload("Locations", func(db *gorm.DB) *gorm.DB { return db.Order("is_default DESC, name, id") }).
+		Preload("Locations.Specialities").
+		First(&organization, "id = ?", organizationId).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+
+	profile, err := s.profiles.GetEffectiveProfile(organizationId, "")
+	if err != nil {
+		return nil, err
+	}
+	agent, err := s.compileAgent(organizationId, "", *profile, at)
+	if err != nil {
+		return nil, err
+	}
+	timeZone := organization.TimeZone
+	if timeZone == "" {
+		timeZone = entity.DefaultTimeZone
+	}
+	config := agentconfig.Config{
+		Organization: agentconfig.Organization{
+			Id:               organization.ID.String(),
+			Name:             organization.Name,
+			Email:            organization.Email,
+			Website:          organization.Website,
+			PhoneNumber:      organization.PhoneNumber,
+			ForwardingNumber: organization.CallForwardingNumber,
+			TimeZone:         timeZone,
+		},
+		Agent:     *agent,
+		Locations: make([]agentconfig.Location, 0, len(organization.Locations)),
+		Knowledge: []agentconfig.KnowledgeEntry{},
+	}
+
+	for _, location := range organization.Locations {
+		compiled, err := s.compileLocation(organization, location, at)
+		if err != nil {
+			return nil, err
+		}
+		config.Locations = append(config.Locations, *compiled)
+	}
+
+	entries, err := s.knowledge.GetEnabledEntries(organizationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		compiled := agentconfig.KnowledgeEntry{
+			Id:       entry.ID.String(),
+			Kind:     entry.Kind,
+			Category: entry.Category,
+			Texts:    make([]agentconfig.KnowledgeText, 0, len(entry.Variants)),
+		}
+		for _, variant := range entry.Variants {
+			compiled.Texts = append(compiled.Texts, agentconfig.KnowledgeText{
+				Language: variant.Language.String(),
+				Title:    variant.Title,
+				Body:     variant.Body,
+				Keywords: variant.Keywords,
+			})
+		}
+		config.Knowledge = append(config.Knowledge, compiled)
+	}
+	return agentconfig.Compile(config)
+}
+
+func (s *AgentConfigService) compileLocation(organization entity.Organization, location entity.Location, at time.Time) (*agentconfig.Location, error) {
+	organizationId := organization.ID.String()
+	locationId := location.ID.String()
+
+	hours, err := s.availability.OpeningHours(organizationId, locationId, "")
+	if err != nil {
+		return nil, err
+	}
+	at = at.In(hours.Location)
+	compiled := &agentconfig.Location{
+		Id:               locationId,
+		Name:             location.Name,
+		IsDefault:        location.IsDefault,
+		Address:          locationAddress(location),
+		PhoneNumber:      location.PhoneNumber,
+		ForwardingNumber: location.CallForwardingNumber,
+		TimeZone:         hours.Location.String(),
+		WeeklyHours:      agentconfig.WeeklyHours(hours.Schedules),
+		Closures:         []agentconfig.Closure{},
+		Specialities:     []string{},
+		AcceptedInsurers: []string{},
+		DeclinedInsurers: []string{},
+	}
+
+	for _, day := range hours.Calendar(at, at.AddDate(0, 0, agentConfigHorizonDays)) {
+		if day.Source == ScheduleSourceWeekly {
+			continue
+		}
+		closure := agentconfig.Closure{Date: day.Date, Reason: day.Reason, Hours: []string{}}
+		for _, interval := range day.Intervals {
+			closure.Hours = append(closure.Hours, formatInterval(interval, hours.Location))
+		}
+		compiled.Closures = append(compiled.Closures, closure)
+	}
+
+	specialities := organization.Specialities
+	if len(location.Specialities) > 0 {
+		specialities = location.Specialities
+	}
+	for _, speciality := range specialities {
+		compiled.Specialities = append(compiled.Specialities, speciality.Name)
+	}
+	slices.Sort(compiled.Specialities)
+
+	insurances, err := s.insurance.effectiveInsurances(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, insurance := range insurances {
+		if insurance.Accepted {
+			compiled.AcceptedInsurers = append(compiled.AcceptedInsurers, insurance.Carrier.Name)
+		} else {
+			compiled.DeclinedInsurers = append(compiled.DeclinedInsurers, insurance.Carrier.Name)
+		}
+	}
+	slices.Sort(compiled.AcceptedInsurers)
+	slices.Sort(compiled.DeclinedInsurers)
+
+	profile, err := s.profiles.dao.GetByScope(organizationId, locationId)
+	if err == nil && profile.PublishedVersion > 0 {
+		version, err := s.profiles.dao.GetVersion(profile.ID.String(), profile.PublishedVersion)
+		if err != nil {
+			return nil, err
+		}
+		if compiled.Agent, err = s.compileAgent(organizationId, locationId, version.Content(profile), at); err != nil {
+			return nil, err
+		}
+	}
+	return compiled, nil
+}
+
+// compileAgent renders the scripts of a published profile in every language
+// it speaks, each with the variables worded in that language.
+func (s *AgentConfigService) compileAgent(organizationId string, locationId string, profile entity.AgentProfile, at time.Time) (*agentconfig.Agent, error) {
+	agent := &agentconfig.Agent{
+		ProfileId:      profile.ID.String(),
+		ProfileVersion: profile.PublishedVersion,
+		Language:       profile.Language.String(),
+		VoiceType:      profile.VoiceType.String(),
+	}
+	for _, scripts := range profile.Scripts() {
+		context, err := s.profiles.ScriptContext(organizationId, locationId, scripts.Language, at)
+		if err != nil {
+			return nil, err
+		}
+		values := context.Values()
+		timeless := maps.Clone(values)
+		for _, name := range script.TimeVariables {
+			delete(timeless, name)
+		}
+		hints := script.DetectionHints(scripts.Language.String())
+		for _, hint := range scripts.DetectionHints {
+			if !slices.Contains(hints, hint) {
+				hints = append(hints, hint)
+			}
+		}
+		agent.Languages = append(agent.Languages, agentconfig.LanguageScript{
+			Language:       scripts.Language.String(),
+			InitialScript:  script.Render(scripts.InitialScript, values),
+			ClosingScript:  script.Render(scripts.ClosingScript, values),
+			DetectionHints: hints,
+			Timeless: &agentconfig.Scripts{
+				InitialScript: script.Render(scripts.InitialScript, timeless),
+				ClosingScript: script.Render(scripts.ClosingScript, timeless),
+			},
+		})
+	}
+	agent.InitialScript = agent.Languages[0].InitialScript
+	agent.ClosingScript = agent.Languages[0].ClosingScript
+	return agent, nil
+}
+
+// formatInterval writes an interval as "09:00-17:00", an end at midnight as
+// "24:00".
+func formatInterval(interval Interval, loc *time.Location) string {
+	start := interval.Start.In(loc)
+	end := interval.End.In(loc)
+	to := end.Format("15:04")
+	if to == "00:00" && end.After(start) {
+		to = "24:00"
+	}
+	return fmt.Sprintf("%s-%s", start.Format("15:04"), to)
+}
+
+func locationAddress(location entity.Location) string {
+	var parts []string
+	for _, part := range []string{location.AddressLine1, location.AddressLine2, location.City, location.Province, location.PostalCode, location.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/script"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AgentProfileService struct {
+	db           *gorm.DB
+	dao          *repository.AgentProfileRepository
+	availability *AvailabilityService
+	insurance    *InsuranceService
+	onPublish    []func(organizationId uuid.UUID) error
+}
+
+func NewAgentProfileService(db *gorm.DB, dao *repository.AgentProfileRepository, availability *AvailabilityService, insurance *InsuranceService) *AgentProfileService {
+	return &AgentProfileService{db: db, dao: dao, availability: availability, insurance: insurance}
+}
+
+// OnPublish registers a function called whenever what the agent uses for an
+// organization changes: a version is published or rolled back, or a published
+// profile is deleted.
+func (s *AgentProfileService) OnPublish(fn func(organizationId uuid.UUID) error) {
+	s.onPublish = append(s.onPublish, fn)
+}
+
+func (s *AgentProfileService) published(organizationId uuid.UUID) {
+	for _, fn := range s.onPublish {
+		if err := fn(organizationId); err != nil {
+			logger.Error("Failed to handle agent profile publication:", organizationId, err)
+		}
+	}
+}
+
+func (s *AgentProfileService) GetProfiles(organizationId string) ([]entity.AgentProfile, error) {
+	return s.dao.GetByOrganization(organizationId)
+}
+
+func (s *AgentProfileService) GetProfile(organizationId string, id string) (*entity.AgentProfile, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	return &profile, nil
+}
+
+// GetEffectiveProfile returns the published profile the agent uses for a
+// location: its own profile when it has a published one, the organization
+// default otherwise. Drafts are never returned.
+func (s *AgentProfileService) GetEffectiveProfile(organizationId string, locationId string) (*entity.AgentProfile, error) {
+	profile, err := s.findProfile(organizationId, locationId, true)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.dao.GetVersion(profile.ID.String(), profile.PublishedVersion)
+	if err != nil {
+		return nil, err
+	}
+	published := version.Content(*profile)
+	return &published, nil
+}
+
+// findProfile returns the profile of a location, falling back to the
+// organization default. With published, profiles never published are skipped.
+func (s *AgentProfileService) findProfile(organizationId string, locationId string, published bool) (*entity.AgentProfile, error) {
+	if locationId != "" {
+		profile, err := s.dao.GetByScope(organizationId, locationId)
+		if err == nil && (!published || profile.PublishedVersion > 0) {
+			return &profile, nil
+		}
+	}
+	profile, err := s.dao.GetByScope(organizationId, "")
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if published && profile.PublishedVersion == 0 {
+		return nil, errors.NotFound("no published agent profile")
+	}
+	return &profile, nil
+}
+
+// CreateProfile adds the default profile of an organization, or the profile
+// of one of its locations. Each of them has at most one profile.
+func (s *AgentProfileService) CreateProfile(organizationId string, dto types.AgentProfileRequest) (*entity.AgentProfile, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+
+	profile := entity.AgentProfile{OrganizationID: orgID}
+	if err := s.applyProfileRequest(&profile, dto); err != nil {
+		return nil, err
+	}
+	if err := s.dao.Save(&profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (s *AgentProfileService) UpdateProfile(organizationId string, id string, dto types.AgentProfileRequest) (*entity.AgentProfile, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := s.applyProfileRequest(&profile, dto); err != nil {
+		return nil, err
+	}
+	if err := s.dao.Update(&profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (s *AgentProfileService) DeleteProfile(organizationId string, id string) error {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.DeleteWithVersions(tx, &profile)
+	})
+	if err == nil && profile.PublishedVersion > 0 {
+		s.published(profile.OrganizationID)
+	}
+	return err
+}
+
+func (s *AgentProfileService) applyProfileRequest(profile *entity.AgentProfile, dto types.AgentProfileRequest) error {
+	language, err := entity.ParseLanguageType(dto.Language)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	voiceType, err := entity.ParseVoiceType(dto.VoiceType)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+
+	locationId := ""
+	if dto.LocationId != nil {
+		locationId = dto.LocationId.String()
+		var count int64
+		err := s.db.Model(&entity.Location{}).
+			Where("id = ? AND organization_id = ?", locationId, profile.OrganizationID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.NotFound("location not found")
+		}
+	}
+	existing, err := s.dao.GetByScope(profile.OrganizationID.String(), locationId)
+	if err == nil && existing.ID != profile.ID {
+		if dto.LocationId != nil {
+			return errors.BadRequest("The location already has an agent profile")
+		}
+		return errors.BadRequest("The organization already has a default agent profile")
+	}
+
+	translations := entity.AgentScripts{}
+	for _, translation := range dto.Translations {
+		translationLanguage, err := entity.ParseLanguageType(translation.Language)
+		if err != nil {
+			return errors.BadRequest(err.Error())
+		}
+		if translationLanguage == language {
+			return errors.BadRequest(fmt.Sprintf("The %s scripts are the default ones, not a translation", language))
+		}
+		for _, other := range translations {
+			if other.Language == translationLanguage {
+				return errors.BadRequest(fmt.Sprintf("The %s scripts are given twice", translationLanguage))
+			}
+		}
+		translations = append(translations, entity.AgentScript{
+			Language:       translationLanguage,
+			InitialScript:  translation.InitialScript,
+			ClosingScript:  translation.ClosingScript,
+			DetectionHints: translation.DetectionHints,
+		})
+	}
+
+	profile.LocationID = dto.LocationId
+	profile.Language = language
+	profile.VoiceType = voiceType
+	profile.InitialScript = dto.InitialScript
+	profile.ClosingScript = dto.ClosingScript
+	profile.DetectionHints = dto.DetectionHints
+	profile.Translations = translations
+	return nil
+}
+
+// ScriptContext gathers what the script variables of a location are computed
+// from, as of at.
+func (s *AgentProfileService) ScriptContext(organizationId string, locationId string, language entity.LanguageType, at time.Time) (*script.Context, error) {
+	var organization entity.Organization
+	if err := s.db.Preload("Specialities").First(&organization, "id = ?", organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+	hours, err := s.availability.OpeningHours(organizationId, locationId, "")
+	if err != nil {
+		return nil, err
+	}
+	at = at.In(hours.Location)
+
+	context := &script.Context{
+		Language:         language.String(),
+		At:               at,
+		OrganizationName: organization.Name,
+		ForwardingNumber: organization.CallForwardingNumber,
+	}
+	specialities := organization.Specialities
+	if locationId != "" {
+		var location entity.Location
+		if err := s.db.Preload("Specialities").First(&location, "id = ?", locationId).Error; err != nil {
+			return nil, err
+		}
+		context.LocationName = location.Name
+		if location.CallForwardingNumber != "" {
+			context.ForwardingNumber = location.CallForwardingNumber
+		}
+		if len(location.Specialities) > 0 {
+			specialities = location.Specialities
+		}
+	}
+	for _, speciality := range specialities {
+		context.Specialities = append(context.Specialities, speciality.Name)
+	}
+	slices.Sort(context.Specialities)
+
+	year, month, day := at.Date()
+	for _, interval := range hours.IntervalsOn(year, month, day) {
+		context.Today = append(context.Today, script.Period{Start: interval.Start, End: interval.End})
+	}
+	_, context.NextOpening, _ = hours.Status(at)
+
+	insurances, err := s.insurance.effectiveInsurances(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, insurance := range insurances {
+		if insurance.Accepted {
+			context.Insurers = append(context.Insurers, insurance.Carrier.Name)
+		}
+	}
+	slices.Sort(context.Insurers)
+	return context, nil
+}
+
+// PreviewScripts renders scripts for a location as the agent would say them
+// at the requested time. Scripts left empty come from the draft profile of the
+// location. Unknown variables are reported and left as they are.
+func (s *AgentProfileService) PreviewScripts(organizationId string, dto types.ScriptPreviewRequest) (*types.ScriptPreviewResponse, error) {
+	locationId := ""
+	if dto.LocationId != nil {
+		locationId = dto.LocationId.String()
+	}
+	language := entity.English
+	if dto.Language != "" {
+		var err error
+		if language, err = entity.ParseLanguageType(dto.Language); err != nil {
+			return nil, errors.BadRequest(err.Error())
+		}
+	}
+	fallback := false
+	if dto.InitialScript == "" || dto.ClosingScript == "" || dto.Language == "" {
+		profile, err := s.findProfile(organizationId, locationId, false)
+		if err != nil && (dto.InitialScript == "" || dto.ClosingScript == "") {
+			return nil, err
+		}
+		if err == nil {
+			if dto.Language == "" {
+				language = profile.Language
+			}
+			scripts, usedFallback := profile.ScriptFor(language)
+			if dto.InitialScript == "" {
+				dto.InitialScript = scripts.InitialScript
+				fallback = usedFallback
+			}
+			if dto.ClosingScript == "" {
+				dto.ClosingScript = scripts.ClosingScript
+				fallback = usedFallback
+			}
+		}
+	}
+	at := time.Now()
+	if dto.At != nil {
+		at = *dto.At
+	}
+
+	context, err := s.ScriptContext(organizationId, locationId, language, at)
+	if err != nil {
+		return nil, err
+	}
+	values := context.Values()
+	unknown := []string{}
+	for _, text := range []string{dto.InitialScript, dto.ClosingScript} {
+		if err, ok := script.Validate(text).(*script.UnknownVariablesError); ok {
+			for _, name := range err.Names {
+				if !slices.Contains(unknown, name) {
+					unknown = append(unknown, name)
+				}
+			}
+		}
+	}
+	return &types.ScriptPreviewResponse{
+		Language:         language.String(),
+		Fallback:         fallback,
+		At:               context.At,
+		InitialScript:    script.Render(dto.InitialScript, values),
+		ClosingScript:    script.Render(dto.ClosingScript, values),
+		Variables:        values,
+		UnknownVariables: unknown,
+	}, nil
+}
+
+func ToAgentProfileResponse(profile entity.AgentProfile) types.AgentProfileResponse {
+	return types.AgentProfileResponse{
+		Id:               profile.ID,
+		OrganizationId:   profile.OrganizationID,
+		LocationId:       profile.LocationID,
+		Language:         profile.Language.String(),
+		Languages:        toLanguageNames(profile.Scripts()),
+		VoiceType:        profile.VoiceType.String(),
+		InitialScript:    profile.InitialScript,
+		ClosingScript:    profile.ClosingScript,
+		DetectionHints:   toStrings(profile.DetectionHints),
+		Translations:     toAgentScriptResponses(profile.Translations),
+		PublishedVersion: profile.PublishedVersion,
+		UpdatedAt:        profile.UpdatedAt,
+	}
+}
+
+func toAgentScriptResponses(scripts entity.AgentScripts) []types.AgentScriptResponse {
+	responses := []types.AgentScriptResponse{}
+	for _, scripts := range scripts {
+		responses = append(responses, types.AgentScriptResponse{
+			Language:       scripts.Language.String(),
+			InitialScript:  scripts.InitialScript,
+			ClosingScript:  scripts.ClosingScript,
+			DetectionHints: toStrings(scripts.DetectionHints),
+			Complete:       scripts.Complete(),
+		})
+	}
+	return responses
+}
+
+func toLanguageNames(scripts []entity.AgentScript) []string {
+	names := make([]string, 0, len(scripts))
+	for _, scripts := range scripts {
+		names = append(names, scripts.Language.String())
+	}
+	return names
+}
+
+// toStrings returns an empty slice rather than nil, for JSON responses.
+func toStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
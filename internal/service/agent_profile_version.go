@@ -0,0 +1,352 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/script"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *AgentProfileService) GetVersions(organizationId string, id string) ([]entity.AgentProfileVersion, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	return s.dao.GetVersions(profile.ID.String())
+}
+
+func (s *AgentProfileService) GetVersion(organizationId string, id string, version int) (*entity.AgentProfileVersion, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	profileVersion, err := s.dao.GetVersion(profile.ID.String(), version)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	return &profileVersion, nil
+}
+
+// Publish snapshots the draft of a profile as a new version. It goes live
+// right away, unless the organization requires an approval and the user is
+// not an Admin, in which case it waits for one.
+func (s *AgentProfileService) Publish(organizationId string, id string, user *entity.User, dto types.PublishRequest) (*entity.AgentProfileVersion, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := checkOrganizationMember(user, profile.OrganizationID); err != nil {
+		return nil, err
+	}
+	if err := validatePublishable(profile); err != nil {
+		return nil, err
+	}
+	if profile.PublishedVersion > 0 {
+		published, err := s.dao.GetVersion(profile.ID.String(), profile.PublishedVersion)
+		if err != nil {
+			return nil, err
+		}
+		if published.Content(profile).SameContent(profile) {
+			return nil, errors.BadRequest("The draft has no changes to publish")
+		}
+	}
+	pending, err := s.dao.GetPendingVersion(profile.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Version %d is already awaiting approval", pending.Version))
+	}
+
+	var organization entity.Organization
+	if err := s.db.First(&organization, "id = ?", profile.OrganizationID).Error; err != nil {
+		return nil, err
+	}
+	needsApproval := organization.AgentApprovalRequired && !isAdmin(user)
+
+	version := snapshot(profile, user, dto.Note)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if version.Version, err = s.dao.NextVersion(tx, profile.ID); err != nil {
+			return err
+		}
+		if needsApproval {
+			version.Status = entity.VersionPending
+			return s.dao.SaveVersion(tx, &version)
+		}
+		now := time.Now()
+		version.PublishedAt = &now
+		if err := s.dao.SaveVersion(tx, &version); err != nil {
+			return err
+		}
+		return s.dao.Publish(tx, &profile, &version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if version.Status == entity.VersionPublished {
+		s.published(profile.OrganizationID)
+	}
+	return &version, nil
+}
+
+// ReviewVersion approves or rejects the version of a profile awaiting
+// approval. An approved version goes live. Only the Admins of the
+// organization of the profile review its versions.
+func (s *AgentProfileService) ReviewVersion(organizationId string, id string, number int, reviewer *entity.User, approve bool, dto types.ReviewRequest) (*entity.AgentProfileVersion, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := checkOrganizationMember(reviewer, profile.OrganizationID); err != nil {
+		return nil, err
+	}
+	if !isAdmin(reviewer) {
+		return nil, errors.Forbidden("Only an Admin can review a version")
+	}
+	version, err := s.dao.GetVersion(profile.ID.String(), number)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if version.Status != entity.VersionPending {
+		return nil, errors.BadRequest(fmt.Sprintf("Version %d is not awaiting approval", number))
+	}
+
+	now := time.Now()
+	version.ReviewedAt = &now
+	version.ReviewedByID = &reviewer.ID
+	if dto.Note != "" {
+		version.Note = dto.Note
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if !approve {
+			version.Status = entity.VersionRejected
+			return s.dao.UpdateVersion(tx, &version)
+		}
+		version.PublishedAt = &now
+		return s.dao.Publish(tx, &profile, &version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if approve {
+		s.published(profile.OrganizationID)
+	}
+	return &version, nil
+}
+
+// Rollback publishes again the content of a version that was live before, as
+// a new version, and resets the draft to it. It needs no approval since the
+// content was published already.
+func (s *AgentProfileService) Rollback(organizationId string, id string, number int, user *entity.User) (*entity.AgentProfileVersion, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := checkOrganizationMember(user, profile.OrganizationID); err != nil {
+		return nil, err
+	}
+	target, err := s.dao.GetVersion(profile.ID.String(), number)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if target.PublishedAt == nil {
+		return nil, errors.BadRequest(fmt.Sprintf("Version %d was never published", number))
+	}
+	if number == profile.PublishedVersion {
+		return nil, errors.BadRequest(fmt.Sprintf("Version %d is already published", number))
+	}
+
+	profile = target.Content(profile)
+	now := time.Now()
+	version := snapshot(profile, user, fmt.Sprintf("Rollback to version %d", number))
+	version.RolledBackFrom = &target.Version
+	version.PublishedAt = &now
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if version.Version, err = s.dao.NextVersion(tx, profile.ID); err != nil {
+			return err
+		}
+		if err := s.dao.SaveVersion(tx, &version); err != nil {
+			return err
+		}
+		if err := s.dao.Publish(tx, &profile, &version); err != nil {
+			return err
+		}
+		return tx.Save(&profile).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.published(profile.OrganizationID)
+	return &version, nil
+}
+
+// Diff compares two versions of a profile, 0 standing for the draft.
+func (s *AgentProfileService) Diff(organizationId string, id string, from int, to int) (*types.AgentProfileDiffResponse, error) {
+	profile, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	content := func(number int) (entity.AgentProfile, error) {
+		if number == 0 {
+			return profile, nil
+		}
+		version, err := s.dao.GetVersion(profile.ID.String(), number)
+		if err != nil {
+			return profile, errors.NotFound(err.Error())
+		}
+		return version.Content(profile), nil
+	}
+	before, err := content(from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := content(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &types.AgentProfileDiffResponse{
+		From:      from,
+		To:        to,
+		Changed:   !before.SameContent(after),
+		Changes:   []types.FieldChange{},
+		Languages: []types.LanguageDiff{},
+	}
+	if before.Language != after.Language {
+		diff.Changes = append(diff.Changes, types.FieldChange{Field: "language", From: before.Language.String(), To: after.Language.String()})
+	}
+	if before.VoiceType != after.VoiceType {
+		diff.Changes = append(diff.Changes, types.FieldChange{Field: "voice_type", From: before.VoiceType.String(), To: after.VoiceType.String()})
+	}
+	for _, language := range scriptLanguages(after, before) {
+		previous, next := scriptIn(before, language), scriptIn(after, language)
+		diff.Languages = append(diff.Languages, types.LanguageDiff{
+			Language:      language.String(),
+			InitialScript: script.Diff(previous.InitialScript, next.InitialScript),
+			ClosingScript: script.Diff(previous.ClosingScript, next.ClosingScript),
+		})
+		field := func(name string) string {
+			if language == after.Language {
+				return name
+			}
+			return language.String() + "." + name
+		}
+		if previous.InitialScript != next.InitialScript {
+			diff.Changes = append(diff.Changes, types.FieldChange{Field: field("initial_script"), From: previous.InitialScript, To: next.InitialScript})
+		}
+		if previous.ClosingScript != next.ClosingScript {
+			diff.Changes = append(diff.Changes, types.FieldChange{Field: field("closing_script"), From: previous.ClosingScript, To: next.ClosingScript})
+		}
+		if !slices.Equal(previous.DetectionHints, next.DetectionHints) {
+			diff.Changes = append(diff.Changes, types.FieldChange{
+				Field: field("detection_hints"),
+				From:  strings.Join(previous.DetectionHints, ", "),
+				To:    strings.Join(next.DetectionHints, ", "),
+			})
+		}
+	}
+	return diff, nil
+}
+
+// scriptLanguages returns the languages of the given profiles, each once, the
+// default language of the first one first.
+func scriptLanguages(profiles ...entity.AgentProfile) []entity.LanguageType {
+	var languages []entity.LanguageType
+	for _, profile := range profiles {
+		for _, scripts := range profile.Scripts() {
+			if !slices.Contains(languages, scripts.Language) {
+				languages = append(languages, scripts.Language)
+			}
+		}
+	}
+	return languages
+}
+
+// scriptIn returns the scripts of a profile in a language, empty when the
+// profile does not speak it. Unlike ScriptFor, nothing falls back.
+func scriptIn(profile entity.AgentProfile, language entity.LanguageType) entity.AgentScript {
+	for _, scripts := range profile.Scripts() {
+		if scripts.Language == language {
+			return scripts
+		}
+	}
+	return entity.AgentScript{Language: language}
+}
+
+// validatePublishable checks a draft again before it goes live, since the
+// variables known may have changed since it was saved. Every language the
+// agent speaks needs both of its scripts.
+func validatePublishable(profile entity.AgentProfile) error {
+	if !profile.DefaultScript().Complete() {
+		return errors.BadRequest("The initial and closing scripts are required")
+	}
+	for _, scripts := range profile.Scripts() {
+		if !scripts.Complete() {
+			return errors.BadRequest(fmt.Sprintf("The %s scripts are incomplete", scripts.Language))
+		}
+		for _, text := range []string{scripts.InitialScript, scripts.ClosingScript} {
+			if err := script.Validate(text); err != nil {
+				return errors.BadRequest(err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func snapshot(profile entity.AgentProfile, user *entity.User, note string) entity.AgentProfileVersion {
+	version := entity.AgentProfileVersion{
+		ProfileID:      profile.ID,
+		Language:       profile.Language,
+		VoiceType:      profile.VoiceType,
+		InitialScript:  profile.InitialScript,
+		ClosingScript:  profile.ClosingScript,
+		DetectionHints: profile.DetectionHints,
+		Translations:   profile.Translations,
+		Note:           note,
+	}
+	if user != nil {
+		version.SubmittedByID = &user.ID
+	}
+	return version
+}
+
+// checkOrganizationMember only lets the users of an organization change its
+// agent, whatever their role.
+func checkOrganizationMember(user *entity.User, organizationId uuid.UUID) error {
+	if user == nil || user.OrganizationID != organizationId {
+		return errors.Forbidden("")
+	}
+	return nil
+}
+
+func isAdmin(user *entity.User) bool {
+	return user != nil && user.Role != nil && *user.Role == entity.Admin
+}
+
+func ToAgentProfileVersionResponse(version entity.AgentProfileVersion) types.AgentProfileVersionResponse {
+	return types.AgentProfileVersionResponse{
+		Version:        version.Version,
+		Status:         string(version.Status),
+		Language:       version.Language.String(),
+		Languages:      toLanguageNames(version.Content(entity.AgentProfile{}).Scripts()),
+		VoiceType:      version.VoiceType.String(),
+		InitialScript:  version.InitialScript,
+		ClosingScript:  version.ClosingScript,
+		DetectionHints: toStrings(version.DetectionHints),
+		Translations:   toAgentScriptResponses(version.Translations),
+		Note:           version.Note,
+		RolledBackFrom: version.RolledBackFrom,
+		SubmittedBy:    version.SubmittedByID,
+		ReviewedBy:     version.ReviewedByID,
+		ReviewedAt:     version.ReviewedAt,
+		PublishedAt:    version.PublishedAt,
+		CreatedAt:      version.CreatedAt,
+	}
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/agentconfig"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/voice"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// agentSyncBatchSize bounds the syncs run per worker pass.
+	agentSyncBatchSize = 10
+	// agentSyncLease is how long a claimed sync is hidden from other workers.
+	agentSyncLease = 2 * time.Minute
+	// agentSyncTimeout bounds a single sync attempt.
+	agentSyncTimeout = 30 * time.Second
+	// agentSyncCheckInterval is how often synced configurations are compiled
+	// again to catch changes. Scripts mentioning today's hours are synced
+	// again on the first check of every day.
+	agentSyncCheckInterval = time.Hour
+	// maxAssistantNameLength is the longest assistant name Vapi accepts.
+	maxAssistantNameLength = 40
+)
+
+// AgentSyncService pushes the compiled agent configuration of organizations
+// to the voice platform in the background, retrying failures with an
+// exponential backoff.
+type AgentSyncService struct {
+	db       *gorm.DB
+	dao      *repository.AgentSyncRepository
+	configs  *AgentConfigService
+	platform voice.Platform
+	retry    retryPolicy
+	worker   *worker[entity.AgentSync]
+}
+
+// NewAgentSyncService creates an AgentSyncService. With a nil platform,
+// syncing is disabled.
+func NewAgentSyncService(db *gorm.DB, dao *repository.AgentSyncRepository, configs *AgentConfigService, platform voice.Platform, cfg config.VoicePlatformConfig) *AgentSyncService {
+	s := &AgentSyncService{
+		db:       db,
+		dao:      dao,
+		configs:  configs,
+		platform: platform,
+		retry:    newRetryPolicy(cfg.MaxAttempts, 8, time.Duration(cfg.BackoffSeconds)*time.Second),
+	}
+	s.worker = newWorker("agent syncs", agentSyncBatchSize, agentSyncLease, time.Duration(cfg.PollIntervalSeconds)*time.Second, dao.ClaimDue, s.sync)
+	return s
+}
+
+// RequestSync queues a sync of the agent configuration of an organization.
+func (s *AgentSyncService) RequestSync(organizationId uuid.UUID) error {
+	if s.platform == nil {
+		return nil
+	}
+	if err := s.dao.Request(organizationId, s.platform.Name(), time.Now()); err != nil {
+		return err
+	}
+	s.worker.wakeUp()
+	return nil
+}
+
+// ConfigChanged queues a sync after a change of what the configuration of an
+// organization is compiled from. Organizations whose configuration cannot be
+// compiled yet, e.g. without published agent profile, are left alone.
+func (s *AgentSyncService) ConfigChanged(organizationId uuid.UUID) error {
+	if s.platform == nil {
+		return nil
+	}
+	if _, err := s.configs.Compile(organizationId.String(), time.Now()); err != nil {
+		return nil
+	}
+	return s.RequestSync(organizationId)
+}
+
+// Run syncs the pending configurations until the context is cancelled.
+func (s *AgentSyncService) Run(ctx context.Context) {
+	if s.platform == nil {
+		return
+	}
+	go s.checkOutdated(ctx)
+	s.worker.run(ctx)
+}
+
+// checkOutdated queues a sync for the organizations whose configuration
+// changed every agentSyncCheckInterval, until the context is cancelled.
+func (s *AgentSyncService) checkOutdated(ctx context.Context) {
+	check := time.NewTicker(agentSyncCheckInterval)
+	defer check.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-check.C:
+			s.requestOutdated()
+		}
+	}
+}
+
+func (s *AgentSyncService) sync(ctx context.Context, sync *entity.AgentSync) {
+	sync.Attempts++
+	checksum, err := s.push(ctx, sync.OrganizationID)
+	now := time.Now()
+	switch {
+	case err == nil:
+		sync.Status = entity.SyncSynced
+		sync.Checksum = checksum
+		sync.SyncedAt = &now
+		sync.NextAttemptAt = nil
+		sync.LastError = ""
+	default:
+		sync.NextAttemptAt = s.retry.nextAttempt(now, sync.Attempts)
+		if sync.NextAttemptAt == nil {
+			sync.Status = entity.SyncFailed
+		}
+		sync.LastError = err.Error()
+	}
+	if err != nil {
+		logger.Warn("Agent sync failed:", sync.OrganizationID, "attempt", sync.Attempts, err)
+	}
+	if err := s.dao.Update(sync); err != nil {
+		logger.Error("Failed to update agent sync:", sync.OrganizationID, err)
+	}
+}
+
+// push creates or updates the assistant of an organization and returns the
+// checksum of the configuration sent.
+func (s *AgentSyncService) push(ctx context.Context, organizationId uuid.UUID) (string, error) {
+	var organization entity.Organization
+	if err := s.db.First(&organization, "id = ?", organizationId).Error; err != nil {
+		return "", err
+	}
+	document, err := s.configs.Compile(organizationId.String(), time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	assistant := toAssistant(organization, document)
+
+	ctx, cancel := context.WithTimeout(ctx, agentSyncTimeout)
+	defer cancel()
+	if organization.VoiceAssistantID != "" {
+		err := s.platform.UpdateAssistant(ctx, organization.VoiceAssistantID, assistant)
+		if err == nil {
+			return document.Checksum, nil
+		}
+		if !stderrors.Is(err, voice.ErrAssistantNotFound) {
+			return "", err
+		}
+		logger.Warn("Voice assistant not found, creating it again:", organizationId, organization.VoiceAssistantID)
+	}
+	id, err := s.platform.CreateAssistant(ctx, assistant)
+	if err != nil {
+		return "", err
+	}
+	if err := s.dao.SetAssistantID(organizationId, id); err != nil {
+		return "", err
+	}
+	return document.Checksum, nil
+}
+
+// toAssistant builds the assistant of an organization from its compiled
+// configuration.
+func toAssistant(organization entity.Organization, document *agentconfig.Document) voice.Assistant {
+	name := []rune(organization.Name)
+	if len(name) > maxAssistantNameLength {
+		name = name[:maxAssistantNameLength]
+	}
+	return voice.Assistant{
+		Name:           string(name),
+		Language:       document.Config.Agent.Language,
+		Languages:      agentLanguages(document.Config.Agent),
+		VoiceType:      document.Config.Agent.VoiceType,
+		FirstMessage:   document.Config.Agent.InitialScript,
+		EndCallMessage: document.Config.Agent.ClosingScript,
+		SystemPrompt:   document.Prompt,
+		Metadata: map[string]string{
+			"organization_id": organization.ID.String(),
+			"checksum":        document.Checksum,
+		},
+	}
+}
+
+// requestOutdated queues a sync for the organizations whose configuration
+// changed since it was last synced, or whose scripts depending on the time
+// were rendered on a previous day.
+func (s *AgentSyncService) requestOutdated() {
+	var syncs []entity.AgentSync
+	if err := s.db.Where("status = ?", entity.SyncSynced).Find(&syncs).Error; err != nil {
+		logger.Error("Failed to list agent syncs:", err)
+		return
+	}
+	now := time.Now()
+	for _, sync := range syncs {
+		document, err := s.configs.Compile(sync.OrganizationID.String(), now)
+		if err != nil {
+			continue
+		}
+		if document.Checksum == sync.Checksum && !(document.TimeDependent && syncedBeforeToday(sync, document, now)) {
+			continue
+		}
+		if err := s.RequestSync(sync.OrganizationID); err != nil {
+			logger.Error("Failed to request agent sync:", sync.OrganizationID, err)
+		}
+	}
+}
+
+// syncedBeforeToday tells whether a configuration was last synced on a
+// previous day of its organization.
+func syncedBeforeToday(sync entity.AgentSync, document *agentconfig.Document, now time.Time) bool {
+	if sync.SyncedAt == nil {
+		return true
+	}
+	loc, err := time.LoadLocation(document.Config.Organization.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	year, month, day := now.In(loc).Date()
+	return sync.SyncedAt.Before(time.Date(year, month, day, 0, 0, 0, 0, loc))
+}
+
+// GetStatus reports the sync of an organization and whether the platform runs
+// its current configuration.
+func (s *AgentSyncService) GetStatus(organizationId string) (*types.AgentSyncResponse, error) {
+	var organization entity.Organization
+	if err := s.db.First(&organization, "id = ?", organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+	response := &types.AgentSyncResponse{
+		OrganizationId: organization.ID,
+		AssistantId:    organization.VoiceAssistantID,
+		Status:         types.SyncStatusNotSynced,
+	}
+	if s.platform == nil {
+		response.Status = types.SyncStatusDisabled
+		return response, nil
+	}
+	response.Platform = s.platform.Name()
+	if document, err := s.configs.Compile(organizationId, time.Now()); err == nil {
+		response.CurrentChecksum = document.Checksum
+	}
+
+	sync, err := s.dao.GetByOrganization(organizationId)
+	if err != nil {
+		return response, nil
+	}
+	response.Status = string(sync.Status)
+	response.Checksum = sync.Checksum
+	response.Attempts = sync.Attempts
+	response.LastError = sync.LastError
+	response.NextAttemptAt = sync.NextAttemptAt
+	response.RequestedAt = &sync.RequestedAt
+	response.SyncedAt = sync.SyncedAt
+	response.UpToDate = sync.Status == entity.SyncSynced && sync.Checksum == response.CurrentChecksum
+	return response, nil
+}
+
+// Sync queues a sync of an organization on demand, e.g. after a failure.
+func (s *AgentSyncService) Sync(organizationId string) (*types.AgentSyncResponse, error) {
+	if s.platform == nil {
+		return nil, errors.BadRequest("Syncing to a voice platform is disabled")
+	}
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	if _, err := s.configs.Compile(organizationId, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.RequestSync(orgID); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(organizationId)
+}
+
+// agentLanguages returns the languages an agent speaks, the default one first.
+func agentLanguages(agent agentconfig.Agent) []string {
+	languages := make([]string, 0, len(agent.Languages))
+	for _, scripts := range agent.Languages {
+		languages = append(languages, scripts.Language)
+	}
+	return languages
+}
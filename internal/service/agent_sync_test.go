@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/agentconfig"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/voice"
+	"github.com/google/uuid"
+)
+
+func TestSyncAssistantToFakeVapi(t *testing.T) {
+	server := httptest.NewServer(voice.NewFakeVapiServer("test-key"))
+	defer server.Close()
+	platform, err := voice.NewPlatform(config.VoicePlatformConfig{
+		Driver: "vapi",
+		Vapi:   config.VapiConfig{BaseURL: server.URL, APIKey: "test-key"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	organization := entity.Organization{Name: "Maple Dental and Orthodontics of the Plateau Mont-Royal"}
+	organization.ID = uuid.New()
+	document, err := agentconfig.Compile(agentconfig.Config{
+		Organization: agentconfig.Organization{Id: organization.ID.String(), Name: organization.Name, TimeZone: "America/Toronto"},
+		Agent: agentconfig.Agent{
+			Language:      "french",
+			VoiceType:     "casual",
+			InitialScript: "Bonjour !",
+			ClosingScript: "Au revoir.",
+			Languages: []agentconfig.LanguageScript{
+				{Language: "french", InitialScript: "Bonjour !", ClosingScript: "Au revoir."},
+				{Language: "english", InitialScript: "Hello!", ClosingScript: "Goodbye."},
+			},
+		},
+		Locations: []agentconfig.Location{},
+		Knowledge: []agentconfig.KnowledgeEntry{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	id, err := platform.CreateAssistant(ctx, toAssistant(organization, document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	synced := getFakeAssistant(t, server.URL, id)
+	if synced.Name != "Maple Dental and Orthodontics of the Pla" {
+		t.Errorf("name = %q, want it cut at %d characters", synced.Name, maxAssistantNameLength)
+	}
+	if synced.FirstMessage != "Bonjour !" || synced.EndCallMessage != "Au revoir." {
+		t.Errorf("messages = %q, %q", synced.FirstMessage, synced.EndCallMessage)
+	}
+	if len(synced.Model.Messages) != 1 || synced.Model.Messages[0].Content != document.Prompt {
+		t.Errorf("system prompt is not the compiled one: %+v", synced.Model.Messages)
+	}
+	if synced.Transcriber.Language != "multi" {
+		t.Errorf("transcriber language = %q, want multi", synced.Transcriber.Language)
+	}
+	if synced.Metadata["organization_id"] != organization.ID.String() || synced.Metadata["checksum"] != document.Checksum {
+		t.Errorf("metadata = %v", synced.Metadata)
+	}
+
+	document.Config.Agent.InitialScript = "Bonjour, bienvenue !"
+	if err := platform.UpdateAssistant(ctx, id, toAssistant(organization, document)); err != nil {
+		t.Fatal(err)
+	}
+	if synced := getFakeAssistant(t, server.URL, id); synced.FirstMessage != "Bonjour, bienvenue !" {
+		t.Errorf("first message = %q after the update", synced.FirstMessage)
+	}
+	if err := platform.UpdateAssistant(ctx, uuid.NewString(), toAssistant(organization, document)); err != voice.ErrAssistantNotFound {
+		t.Errorf("UpdateAssistant() of a deleted assistant = %v, want ErrAssistantNotFound", err)
+	}
+}
+
+func getFakeAssistant(t *testing.T, baseURL string, id string) voice.VapiAssistant {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(baseURL, "/")+"/assistant/"+id, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET assistant %s returned %d", id, resp.StatusCode)
+	}
+	var assistant voice.VapiAssistant
+	if err := json.NewDecoder(resp.Body).Decode(&assistant); err != nil {
+		t.Fatal(err)
+	}
+	return assistant
+}
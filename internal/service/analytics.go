@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+)
+
+const (
+	// rollupRefreshInterval is how often changed calls are rolled up.
+	rollupRefreshInterval = time.Minute
+	// rollupOverlap re-reads the changes just before the watermark, which
+	// may have been committed after the previous refresh.
+	rollupOverlap = 5 * time.Minute
+	// defaultAnalyticsDays is the range when none is given.
+	defaultAnalyticsDays = 30
+	// maxAnalyticsDays bounds the range of a query.
+	maxAnalyticsDays = 366
+	// maxHourlyAnalyticsDays bounds the range of an hourly call volume.
+	maxHourlyAnalyticsDays = 31
+	// maxTopTags bounds the number of top tags returned.
+	maxTopTags = 50
+)
+
+// Intervals of a call volume.
+const (
+	AnalyticsHour = "hour"
+	AnalyticsDay  = "day"
+	AnalyticsWeek = "week"
+)
+
+// AnalyticsService reports on the calls of organizations. Reports read
+// rollups of the calls in 15 minute buckets, which are refreshed in the
+// background from the calls changed since the previous refresh, and grouped
+// by local hour, day or week in the time zone of the location or of the
+// organization.
+type AnalyticsService struct {
+	dao          *repository.AnalyticsRepository
+	availability *AvailabilityService
+}
+
+func NewAnalyticsService(dao *repository.AnalyticsRepository, availability *AvailabilityService) *AnalyticsService {
+	return &AnalyticsService{dao: dao, availability: availability}
+}
+
+// Run refreshes the call rollups until the context is done.
+func (s *AnalyticsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(rollupRefreshInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.dao.RefreshCallRollups(time.Now(), rollupOverlap); err != nil {
+			logger.Error("Failed to refresh call rollups", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// analyticsRange is a range of local dates resolved to rollup buckets.
+type analyticsRange struct {
+	scope    repository.RollupScope
+	location *time.Location
+	from     time.Time
+	to       time.Time
+}
+
+// CallSummary sums up the calls of an organization over a range of dates.
+func (s *AnalyticsService) CallSummary(organizationId string, filter types.AnalyticsFilter) (*types.CallSummaryResponse, error) {
+	r, err := s.resolveRange(organizationId, filter, maxAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.dao.SumCallRollups(r.scope)
+	if err != nil {
+		logger.Error("Error summing call rollups", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+	histogram, err := s.dao.DurationHistogram(r.scope)
+	if err != nil {
+		logger.Error("Error loading call durations", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+
+	summary := &types.CallSummaryResponse{
+		TimeZone:        r.location.String(),
+		From:            r.from.Format(time.DateOnly),
+		To:              r.to.Format(time.DateOnly),
+		Calls:           totals.Calls,
+		Completed:       totals.Completed,
+		Missed:          totals.Missed,
+		Forwarded:       totals.Forwarded,
+		Voicemail:       totals.Voicemail,
+		Transferred:     totals.Transferred,
+		TransferRate:    ratio(int64(totals.Transferred), int64(totals.Calls)),
+		AfterHours:      totals.AfterHours,
+		AfterHoursShare: ratio(int64(totals.AfterHours), int64(totals.Calls)),
+		NewCallers:      totals.NewCallers,
+		ReturningCalls:  totals.ReturningCalls,
+		Duration: types.CallDurationResponse{
+			Average: ratio(totals.TotalDuration, int64(totals.Calls)),
+			P50:     durationPercentile(histogram, totals.MaxDuration, 0.5),
+			P90:     durationPercentile(histogram, totals.MaxDuration, 0.9),
+			P95:     durationPercentile(histogram, totals.MaxDuration, 0.95),
+			Max:     totals.MaxDuration,
+		},
+	}
+	return summary, nil
+}
+
+// CallVolume counts the calls of an organization by local hour, day or week.
+// Every period of the range is returned, with no calls when none were made.
+func (s *AnalyticsService) CallVolume(organizationId string, filter types.AnalyticsFilter, interval string) (*types.CallVolumeResponse, error) {
+	maxDays := maxAnalyticsDays
+	switch interval {
+	case AnalyticsHour:
+		maxDays = maxHourlyAnalyticsDays
+	case AnalyticsDay, AnalyticsWeek:
+	default:
+		return nil, errors.BadRequest("The interval must be hour, day or week")
+	}
+	r, err := s.resolveRange(organizationId, filter, maxDays)
+	if err != nil {
+		return nil, err
+	}
+	volumes, err := s.dao.CallVolumes(r.scope, interval, r.location.String())
+	if err != nil {
+		logger.Error("Error loading call volumes", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+
+	byStart := make(map[string]repository.CallVolume, len(volumes))
+	for _, volume := range volumes {
+		byStart[wallClock(volume.LocalStart)] = volume
+	}
+	response := &types.CallVolumeResponse{
+		TimeZone: r.location.String(),
+		Interval: interval,
+		Buckets:  []types.CallVolumeBucket{},
+	}
+	for _, start := range periodStarts(r, interval) {
+		volume := byStart[wallClock(start)]
+		response.Buckets = append(response.Buckets, types.CallVolumeBucket{
+			Start:           start,
+			Calls:           volume.Calls,
+			Completed:       volume.Completed,
+			Missed:          volume.Missed,
+			Forwarded:       volume.Forwarded,
+			Voicemail:       volume.Voicemail,
+			Transferred:     volume.Transferred,
+			AfterHours:      volume.AfterHours,
+			AverageDuration: ratio(volume.TotalDuration, int64(volume.Calls)),
+		})
+	}
+	return response, nil
+}
+
+// TopTags returns the tags set on the most calls of an organization over a
+// range of dates.
+func (s *AnalyticsService) TopTags(organizationId string, filter types.AnalyticsFilter, limit int) (*types.TopTagsResponse, error) {
+	if limit <= 0 || limit > maxTopTags {
+		return nil, errors.BadRequest("Invalid limit")
+	}
+	r, err := s.resolveRange(organizationId, filter, maxAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.dao.SumCallRollups(r.scope)
+	if err != nil {
+		logger.Error("Error summing call rollups", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+	tags, err := s.dao.TopTags(r.scope, limit)
+	if err != nil {
+		logger.Error("Error loading top tags", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+
+	response := &types.TopTagsResponse{
+		TimeZone: r.location.String(),
+		Tags:     make([]types.TagCountResponse, 0, len(tags)),
+	}
+	for _, tag := range tags {
+		response.Tags = append(response.Tags, types.TagCountResponse{
+			Tag:   tag.Tag,
+			Calls: tag.Calls,
+			Share: ratio(int64(tag.Calls), int64(totals.Calls)),
+		})
+	}
+	return response, nil
+}
+
+// CallDispositions counts the calls of an organization per disposition over a
+// range of dates, most set first.
+func (s *AnalyticsService) CallDispositions(organizationId string, filter types.AnalyticsFilter) (*types.CallDispositionsResponse, error) {
+	r, err := s.resolveRange(organizationId, filter, maxAnalyticsDays)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.dao.SumCallRollups(r.scope)
+	if err != nil {
+		logger.Error("Error summing call rollups", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+	counts, err := s.dao.DispositionCounts(r.scope)
+	if err != nil {
+		logger.Error("Error loading disposition counts", err)
+		return nil, errors.InternalServerError("Error loading call analytics")
+	}
+
+	response := &types.CallDispositionsResponse{
+		TimeZone:     r.location.String(),
+		Dispositions: make([]types.DispositionCountResponse, 0, len(counts)),
+		Undisposed:   totals.Calls,
+	}
+	for _, count := range counts {
+		label := count.Label
+		if label == "" {
+			label = count.Disposition
+		}
+		response.Dispositions = append(response.Dispositions, types.DispositionCountResponse{
+			Code:  count.Disposition,
+			Label: label,
+			Calls: count.Calls,
+			Share: ratio(int64(count.Calls), int64(totals.Calls)),
+		})
+		response.Undisposed -= count.Calls
+	}
+	response.Undisposed = max(response.Undisposed, 0)
+	return response, nil
+}
+
+// resolveRange resolves the dates of a filter in the time zone of the
+// location or of the organization. The range defaults to the last 30 days.
+func (s *AnalyticsService) resolveRange(organizationId string, filter types.AnalyticsFilter, maxDays int) (analyticsRange, error) {
+	var r analyticsRange
+	locationId := ""
+	if filter.LocationId != nil {
+		locationId = filter.LocationId.String()
+	}
+	hours, err := s.availability.OpeningHours(organizationId, locationId, "")
+	if err != nil {
+		return r, err
+	}
+	r.location = hours.Location
+
+	now := time.Now().In(r.location)
+	r.to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, r.location)
+	if filter.To != "" {
+		if r.to, err = time.ParseInLocation(time.DateOnly, filter.To, r.location); err != nil {
+			return r, errors.BadRequest("Invalid end date")
+		}
+	}
+	r.from = r.to.AddDate(0, 0, 1-defaultAnalyticsDays)
+	if filter.From != "" {
+		if r.from, err = time.ParseInLocation(time.DateOnly, filter.From, r.location); err != nil {
+			return r, errors.BadRequest("Invalid start date")
+		}
+	}
+	if r.from.After(r.to) {
+		return r, errors.BadRequest("The start date must not be after the end date")
+	}
+	if r.from.AddDate(0, 0, maxDays).Before(r.to.AddDate(0, 0, 1)) {
+		return r, errors.BadRequest(fmt.Sprintf("The range must not exceed %d days", maxDays))
+	}
+
+	r.scope = repository.RollupScope{
+		OrganizationId: organizationId,
+		LocationId:     filter.LocationId,
+		From:           r.from,
+		To:             r.to.AddDate(0, 0, 1),
+	}
+	return r, nil
+}
+
+// periodStarts returns the start of every local hour, day or week of a range.
+// Weeks start on Monday, the first one on or before the start of the range.
+func periodStarts(r analyticsRange, interval string) []time.Time {
+	var starts []time.Time
+	switch interval {
+	case AnalyticsHour:
+		seen := make(map[string]bool)
+		for at := r.scope.From; at.Before(r.scope.To); at = at.Add(time.Hour) {
+			local := at.In(r.location)
+			start := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, r.location)
+			// the hour repeated when clocks fall back is a single period
+			if key := wallClock(start); !seen[key] {
+				seen[key] = true
+				starts = append(starts, start)
+			}
+		}
+	case AnalyticsDay:
+		for day := r.from; !day.After(r.to); day = day.AddDate(0, 0, 1) {
+			starts = append(starts, day)
+		}
+	case AnalyticsWeek:
+		week := r.from.AddDate(0, 0, -((int(r.from.Weekday()) + 6) % 7))
+		for ; !week.After(r.to); week = week.AddDate(0, 0, 7) {
+			starts = append(starts, week)
+		}
+	}
+	return starts
+}
+
+// wallClock formats the wall clock time of an instant, ignoring its zone.
+func wallClock(t time.Time) string {
+	return t.Format("2006-01-02T15:04")
+}
+
+// durationPercentile estimates a percentile of call durations from their
+// histogram, interpolating linearly within the bin it falls in. The last bin
+// is bounded by the longest call.
+func durationPercentile(histogram []int64, longest int, percentile float64) int {
+	var total int64
+	for _, calls := range histogram {
+		total += calls
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := percentile * float64(total)
+	var below int64
+	for i, calls := range histogram {
+		if calls == 0 || float64(below+calls) < rank {
+			below += calls
+			continue
+		}
+		lower := float64(entity.CallDurationBins[i])
+		upper := float64(longest)
+		if i+1 < len(entity.CallDurationBins) {
+			upper = math.Min(upper, float64(entity.CallDurationBins[i+1]))
+		}
+		upper = math.Max(upper, lower)
+		return int(math.Round(lower + (upper-lower)*(rank-float64(below))/float64(calls)))
+	}
+	return longest
+}
+
+// ratio divides two counts, rounded to 4 decimals, or is 0 without a total.
+func ratio(count int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(count)/float64(total)*10000) / 10000
+}
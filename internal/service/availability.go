@@ -0,0 +1,366 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/validator"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// availabilityHorizonDays bounds how far ahead the next opening is searched.
+const availabilityHorizonDays = 35
+
+// Interval is a half-open [Start, End) range of absolute time.
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Sources of the opening hours of a day.
+const (
+	ScheduleSourceWeekly       = "weekly"
+	ScheduleSourceOrganization = "organization"
+	ScheduleSourceLocation     = "location"
+	ScheduleSourceUser         = "user"
+)
+
+// OpeningHours resolves weekly wall-clock schedules into absolute intervals
+// in a given time zone. Building every day from its own calendar date keeps
+// "09:00" at nine o'clock local time across DST transitions.
+//
+// Exceptions are applied before the weekly hours: on a date with exceptions,
+// the most specific scope (user, then location, then organization) replaces
+// the weekly schedule entirely.
+type OpeningHours struct {
+	Location   *time.Location
+	Schedules  []entity.DailySchedule
+	Exceptions []entity.ScheduleException
+}
+
+// Day is the effective schedule of a single local date.
+type Day struct {
+	Date      string
+	Weekday   time.Weekday
+	Closed    bool
+	Intervals []Interval
+	Source    string
+	Reason    string
+}
+
+// Day resolves the effective opening hours of a local date.
+func (h OpeningHours) Day(year int, month time.Month, day int) Day {
+	date := time.Date(year, month, day, 0, 0, 0, 0, h.Location)
+	result := Day{Date: date.Format(time.DateOnly), Weekday: date.Weekday(), Source: ScheduleSourceWeekly}
+
+	if exceptions := h.exceptionsOn(result.Date); len(exceptions) > 0 {
+		result.Source = exceptionSource(exceptions[0])
+		var intervals []Interval
+		for _, exception := range exceptions {
+			if result.Reason == "" {
+				result.Reason = exception.Reason
+			}
+			if exception.Closed {
+				result.Closed = true
+				continue
+			}
+			if interval, ok := h.interval(year, month, day, exception.FromTime, exception.ToTime); ok {
+				intervals = append(intervals, interval)
+			}
+		}
+		if !result.Closed {
+			result.Intervals = mergeIntervals(intervals)
+		}
+		result.Closed = len(result.Intervals) == 0
+		return result
+	}
+
+	var intervals []Interval
+	for _, schedule := range h.Schedules {
+		if schedule.DayOfWeek != int(date.Weekday()) {
+			continue
+		}
+		if interval, ok := h.interval(year, month, day, schedule.FromTime, schedule.ToTime); ok {
+			intervals = append(intervals, interval)
+		}
+	}
+	result.Intervals = mergeIntervals(intervals)
+	result.Closed = len(result.Intervals) == 0
+	return result
+}
+
+// IntervalsOn returns the sorted, merged opening intervals of a local date.
+func (h OpeningHours) IntervalsOn(year int, month time.Month, day int) []Interval {
+	return h.Day(year, month, day).Intervals
+}
+
+// Calendar resolves every local date between from and to, both included.
+func (h OpeningHours) Calendar(from time.Time, to time.Time) []Day {
+	var days []Day
+	year, month, day := from.Date()
+	for {
+		date := time.Date(year, month, day, 0, 0, 0, 0, h.Location)
+		if date.After(time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, h.Location)) {
+			break
+		}
+		days = append(days, h.Day(year, month, day))
+		year, month, day = time.Date(year, month, day+1, 0, 0, 0, 0, h.Location).Date()
+	}
+	return days
+}
+
+// exceptionsOn returns the exceptions of a date for the most specific scope.
+func (h OpeningHours) exceptionsOn(date string) []entity.ScheduleException {
+	var result []entity.ScheduleException
+	best := -1
+	for _, exception := range h.Exceptions {
+		if exception.Date.Format(time.DateOnly) != date {
+			continue
+		}
+		rank := exceptionRank(exception)
+		if rank > best {
+			best = rank
+			result = result[:0]
+		}
+		if rank == best {
+			result = append(result, exception)
+		}
+	}
+	return result
+}
+
+func exceptionRank(exception entity.ScheduleException) int {
+	switch {
+	case exception.UserID != nil:
+		return 2
+	case exception.LocationID != nil:
+		return 1
+	}
+	return 0
+}
+
+func exceptionSource(exception entity.ScheduleException) string {
+	switch exceptionRank(exception) {
+	case 2:
+		return ScheduleSourceUser
+	case 1:
+		return ScheduleSourceLocation
+	}
+	return ScheduleSourceOrganization
+}
+
+// Intervals returns the merged opening intervals overlapping [from, to).
+func (h OpeningHours) Intervals(from time.Time, to time.Time) []Interval {
+	local := from.In(h.Location).AddDate(0, 0, -1)
+	year, month, day := local.Date()
+
+	var intervals []Interval
+	for {
+		date := time.Date(year, month, day, 0, 0, 0, 0, h.Location)
+		if !date.Before(to) {
+			break
+		}
+		for _, interval := range h.IntervalsOn(year, month, day) {
+			if interval.End.After(from) && interval.Start.Before(to) {
+				intervals = append(intervals, interval)
+			}
+		}
+		year, month, day = time.Date(year, month, day+1, 0, 0, 0, 0, h.Location).Date()
+	}
+	return mergeIntervals(intervals)
+}
+
+// Status tells whether at falls within opening hours, and when the state
+// changes next. NextOpen is nil when no opening is found within the horizon.
+func (h OpeningHours) Status(at time.Time) (bool, *time.Time, *time.Time) {
+	intervals := h.Intervals(at, at.AddDate(0, 0, availabilityHorizonDays))
+
+	var open bool
+	var nextOpen, nextClose *time.Time
+	for _, interval := range intervals {
+		if !interval.Start.After(at) && interval.End.After(at) {
+			open = true
+			end := interval.End
+			nextClose = &end
+			continue
+		}
+		if interval.Start.After(at) {
+			start := interval.Start
+			nextOpen = &start
+			if !open {
+				end := interval.End
+				nextClose = &end
+			}
+			break
+		}
+	}
+	return open, nextOpen, nextClose
+}
+
+// AddOpenTime returns when d of opening hours will have elapsed after from,
+// skipping the time the clinic is closed. It is false when the hours do not
+// open long enough within the horizon.
+func (h OpeningHours) AddOpenTime(from time.Time, d time.Duration) (time.Time, bool) {
+	for _, interval := range h.Intervals(from, from.AddDate(0, 0, availabilityHorizonDays)) {
+		start := interval.Start
+		if start.Before(from) {
+			start = from
+		}
+		if open := interval.End.Sub(start); open < d {
+			d -= open
+			continue
+		}
+		return start.Add(d), true
+	}
+	return time.Time{}, false
+}
+
+func (h OpeningHours) interval(year int, month time.Month, day int, from string, to string) (Interval, bool) {
+	fromMinutes, err := validator.ClockMinutes(from)
+	if err != nil {
+		return Interval{}, false
+	}
+	toMinutes, err := validator.ClockMinutes(to)
+	if err != nil {
+		return Interval{}, false
+	}
+	start := time.Date(year, month, day, fromMinutes/60, fromMinutes%60, 0, 0, h.Location)
+	end := time.Date(year, month, day, toMinutes/60, toMinutes%60, 0, 0, h.Location)
+	if !end.After(start) {
+		return Interval{}, false
+	}
+	return Interval{Start: start, End: end}, true
+}
+
+func mergeIntervals(intervals []Interval) []Interval {
+	if len(intervals) < 2 {
+		return intervals
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Start.Before(intervals[j].Start) })
+
+	merged := []Interval{intervals[0]}
+	for _, interval := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if !interval.Start.After(last.End) {
+			if interval.End.After(last.End) {
+				last.End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+type AvailabilityService struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityService(db *gorm.DB) *AvailabilityService {
+	return &AvailabilityService{db: db}
+}
+
+// OpeningHours returns the opening hours of an organization, narrowed to a
+// location and/or a staff member when their ids are given. Locations fall back
+// to the organization's time zone and hours when they have none of their own;
+// staff members are only available during their own schedule.
+func (s *AvailabilityService) OpeningHours(organizationId string, locationId string, userId string) (*OpeningHours, error) {
+	var organization entity.Organization
+	if err := s.db.Preload("DailySchedules").First(&organization, "id = ?", organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+
+	timeZone := organization.TimeZone
+	schedules := organization.DailySchedules
+
+	if locationId != "" {
+		var location entity.Location
+		err := s.db.Preload("DailySchedules").
+			First(&location, "id = ? AND organization_id = ?", locationId, organizationId).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.NotFound("location not found")
+			}
+			return nil, err
+		}
+		if location.TimeZone != "" {
+			timeZone = location.TimeZone
+		}
+		if len(location.DailySchedules) > 0 {
+			schedules = location.DailySchedules
+		}
+	}
+
+	if userId != "" {
+		var user entity.User
+		err := s.db.Preload("DailySchedules").
+			First(&user, "id = ? AND organization_id = ?", userId, organizationId).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.NotFound("user not found")
+			}
+			return nil, err
+		}
+		schedules = user.DailySchedules
+	}
+
+	exceptions := s.db.Where("organization_id = ?", organizationId)
+	if locationId != "" {
+		exceptions = exceptions.Where("location_id IS NULL OR location_id = ?", locationId)
+	} else {
+		exceptions = exceptions.Where("location_id IS NULL")
+	}
+	if userId != "" {
+		exceptions = exceptions.Where("user_id IS NULL OR user_id = ?", userId)
+	} else {
+		exceptions = exceptions.Where("user_id IS NULL")
+	}
+	var scheduleExceptions []entity.ScheduleException
+	if err := exceptions.Order("date").Find(&scheduleExceptions).Error; err != nil {
+		return nil, err
+	}
+
+	if timeZone == "" {
+		timeZone = entity.DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, errors.InternalServerError(fmt.Sprintf("invalid time zone %q", timeZone))
+	}
+
+	return &OpeningHours{Location: loc, Schedules: schedules, Exceptions: scheduleExceptions}, nil
+}
+
+// Availability answers whether the clinic, one of its locations or a staff
+// member is open at the given instant.
+func (s *AvailabilityService) Availability(organizationId string, locationId string, userId string, at time.Time) (*types.AvailabilityResponse, error) {
+	hours, err := s.OpeningHours(organizationId, locationId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	open, nextOpen, nextClose := hours.Status(at)
+	response := &types.AvailabilityResponse{
+		At:        at.UTC(),
+		TimeZone:  hours.Location.String(),
+		LocalTime: at.In(hours.Location).Format(time.RFC3339),
+		Open:      open,
+		NextOpen:  nextOpen,
+		NextClose: nextClose,
+	}
+	if id, err := uuid.Parse(locationId); err == nil {
+		response.LocationId = &id
+	}
+	if id, err := uuid.Parse(userId); err == nil {
+		response.UserId = &id
+	}
+	return response, nil
+}
@@ -0,0 +1,671 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/notification"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Headers of a signed call report. The signature is the hex HMAC-SHA256 of
+// the timestamp, a dot and the raw body, keyed with the webhook secret of the
+// organization, optionally prefixed with "sha256=".
+const (
+	WebhookSignatureHeader = "X-Comvoca-Signature"
+	WebhookTimestampHeader = "X-Comvoca-Timestamp"
+)
+
+const (
+	// endOfCallReport is the only report type processed.
+	endOfCallReport = "end-of-call-report"
+	// callEventBatchSize bounds the reports processed per worker pass.
+	callEventBatchSize = 20
+	// callEventLease is how long a claimed report is hidden from other workers.
+	callEventLease = 2 * time.Minute
+	// maxTranscriptMatches bounds the matching turns returned per call.
+	maxTranscriptMatches = 3
+)
+
+// CallService records the calls handled by the voice agent. Reports of the
+// voice platform are verified and stored on receipt, then processed in the
+// background, retrying failures with an exponential backoff. Recorded calls
+// are linked to the contact of their caller and handed to the callback rules
+// of their organization.
+type CallService struct {
+	db            *gorm.DB
+	dao           *repository.CallRepository
+	notifications *NotificationService
+	availability  *AvailabilityService
+	contacts      *ContactService
+	callbacks     *CallbackService
+	platform      string
+	tolerance     time.Duration
+	retry         retryPolicy
+	worker        *worker[entity.CallEvent]
+}
+
+func NewCallService(db *gorm.DB, dao *repository.CallRepository, notifications *NotificationService, availability *AvailabilityService, contacts *ContactService, callbacks *CallbackService, cfg config.VoicePlatformConfig) *CallService {
+	s := &CallService{
+		db:            db,
+		dao:           dao,
+		notifications: notifications,
+		availability:  availability,
+		contacts:      contacts,
+		callbacks:     callbacks,
+		platform:      cfg.Driver,
+		tolerance:     time.Duration(cfg.WebhookToleranceSeconds) * time.Second,
+		retry:         newRetryPolicy(cfg.MaxAttempts, 8, time.Duration(cfg.BackoffSeconds)*time.Second),
+	}
+	s.worker = newWorker("call events", callEventBatchSize, callEventLease, time.Duration(cfg.PollIntervalSeconds)*time.Second, dao.ClaimDue, s.process)
+	if s.platform == "" || s.platform == "none" {
+		s.platform = "vapi"
+	}
+	if s.tolerance <= 0 {
+		s.tolerance = 5 * time.Minute
+	}
+	return s
+}
+
+// RotateWebhookSecret generates a new secret for signing the call reports of
+// an organization. The previous secret stops working right away.
+func (s *CallService) RotateWebhookSecret(organizationId string) (*types.WebhookSecretResponse, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	secret := "whsec_" + hex.EncodeToString(key)
+	if err := s.dao.SetWebhookSecret(orgID, secret); err != nil {
+		return nil, err
+	}
+	return &types.WebhookSecretResponse{
+		Secret:          secret,
+		SignatureHeader: WebhookSignatureHeader,
+		TimestampHeader: WebhookTimestampHeader,
+	}, nil
+}
+
+// ReceiveWebhook verifies a report of the voice platform and queues it for
+// processing. A report already received is acknowledged without being queued
+// again, and reports other than end-of-call ones are ignored.
+func (s *CallService) ReceiveWebhook(organizationId string, timestamp string, signature string, body []byte) (string, error) {
+	var organization entity.Organization
+	if err := s.db.First(&organization, "id = ?", organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", errors.NotFound("organization not found")
+		}
+		return "", err
+	}
+	sentAt, err := s.verifySignature(organization.WebhookSecret, timestamp, signature, body, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	var report types.VoiceWebhookRequest
+	if err := json.Unmarshal(body, &report); err != nil {
+		return "", errors.BadRequest("Invalid report")
+	}
+	if report.Message.Type != endOfCallReport {
+		return types.WebhookIgnored, nil
+	}
+	if report.Message.Call.Id == "" {
+		return "", errors.BadRequest("The report has no call id")
+	}
+
+	occurredAt := sentAt
+	if report.Message.Timestamp > 0 {
+		occurredAt = time.UnixMilli(report.Message.Timestamp)
+	}
+	digest := sha256.Sum256(append([]byte(organization.ID.String()+"."), body...))
+	now := time.Now()
+	event := entity.CallEvent{
+		OrganizationID: organization.ID,
+		Digest:         hex.EncodeToString(digest[:]),
+		Type:           report.Message.Type,
+		ExternalCallID: report.Message.Call.Id,
+		OccurredAt:     occurredAt,
+		Payload:        string(body),
+		Status:         entity.CallEventPending,
+		NextAttemptAt:  &now,
+	}
+	created, err := s.dao.Enqueue(&event)
+	if err != nil {
+		return "", err
+	}
+	if !created {
+		return types.WebhookDuplicate, nil
+	}
+	s.worker.wakeUp()
+	return types.WebhookAccepted, nil
+}
+
+// verifySignature checks that a report was signed with the secret of the
+// organization recently, and returns when it was signed.
+func (s *CallService) verifySignature(secret string, timestamp string, signature string, body []byte, now time.Time) (time.Time, error) {
+	if secret == "" {
+		return time.Time{}, errors.Unauthorized("Call reports are not enabled for this organization")
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Unauthorized("Missing or invalid timestamp")
+	}
+	sentAt := time.Unix(seconds, 0)
+	if sentAt.Before(now.Add(-s.tolerance)) || sentAt.After(now.Add(s.tolerance)) {
+		return time.Time{}, errors.Unauthorized("The timestamp is too old or in the future")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	given, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "sha256="))
+	if err != nil || !hmac.Equal(given, expected) {
+		return time.Time{}, errors.Unauthorized("Invalid signature")
+	}
+	return sentAt, nil
+}
+
+// Run processes the queued reports until the context is cancelled.
+func (s *CallService) Run(ctx context.Context) {
+	s.worker.run(ctx)
+}
+
+func (s *CallService) process(_ context.Context, event *entity.CallEvent) {
+	event.Attempts++
+	call, created, err := s.record(event)
+	now := time.Now()
+	switch {
+	case err == nil:
+		event.Status = entity.CallEventProcessed
+		event.ProcessedAt = &now
+		event.NextAttemptAt = nil
+		event.LastError = ""
+	default:
+		event.NextAttemptAt = s.retry.nextAttempt(now, event.Attempts)
+		if event.NextAttemptAt == nil {
+			event.Status = entity.CallEventFailed
+		}
+		event.LastError = err.Error()
+	}
+	if err != nil {
+		logger.Warn("Failed to process call event:", event.ExternalCallID, "attempt", event.Attempts, err)
+	}
+	if err := s.dao.UpdateEvent(event); err != nil {
+		logger.Error("Failed to update call event:", event.ExternalCallID, err)
+	}
+	if created {
+		s.notifyCall(call)
+	}
+	if err == nil {
+		s.callbacks.FromCall(call)
+	}
+}
+
+// record creates or updates the call a report is about. A report older than
+// the one the call was last updated from is skipped, so reports processed out
+// of order never overwrite newer data. It tells whether the call was created.
+func (s *CallService) record(event *entity.CallEvent) (*entity.Call, bool, error) {
+	var report types.VoiceWebhookRequest
+	if err := json.Unmarshal([]byte(event.Payload), &report); err != nil {
+		return nil, false, err
+	}
+	call, turns := toCall(report.Message, s.platform)
+	call.OrganizationID = event.OrganizationID
+	call.ExternalCallID = event.ExternalCallID
+	call.ReportedAt = event.OccurredAt
+	if call.StartedAt.IsZero() {
+		call.StartedAt = event.OccurredAt
+	}
+
+	created := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if call.LocationID, err = s.dao.LocationByNumber(tx, call.OrganizationID, call.CalledNumber); err != nil {
+			return err
+		}
+		call.AfterHours = s.afterHours(&call)
+		if err := s.contacts.Attach(tx, &call); err != nil {
+			return err
+		}
+		if created, err = s.dao.Insert(tx, &call); err != nil {
+			return err
+		}
+		if created {
+			return s.dao.ReplaceTranscript(tx, call.ID, turns)
+		}
+
+		existing, err := s.dao.LockByExternalID(tx, call.ExternalCallID)
+		if err != nil {
+			return err
+		}
+		if existing.OrganizationID != call.OrganizationID {
+			return fmt.Errorf("call %s belongs to another organization", call.ExternalCallID)
+		}
+		if existing.ReportedAt.After(call.ReportedAt) {
+			call = existing
+			return nil
+		}
+		call.Base = existing.Base
+		call.Disposition = existing.Disposition
+		call.Tags = existing.Tags
+		if !call.StartedAt.Equal(existing.StartedAt) || call.CallerNumber != existing.CallerNumber {
+			if err := s.dao.RecordMove(tx, existing); err != nil {
+				return err
+			}
+		}
+		if err := s.dao.Update(tx, &call); err != nil {
+			return err
+		}
+		return s.dao.ReplaceTranscript(tx, call.ID, turns)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &call, created, nil
+}
+
+// afterHours tells whether a call started outside of the opening hours of the
+// location called, or of the organization when no location is known. Calls
+// whose opening hours cannot be resolved count as within them.
+func (s *CallService) afterHours(call *entity.Call) bool {
+	locationId := ""
+	if call.LocationID != nil {
+		locationId = call.LocationID.String()
+	}
+	hours, err := s.availability.OpeningHours(call.OrganizationID.String(), locationId, "")
+	if err != nil {
+		logger.Error("Failed to resolve the opening hours of call:", call.ExternalCallID, err)
+		return false
+	}
+	open, _, _ := hours.Status(call.StartedAt)
+	return !open
+}
+
+// notifyCall tells the staff of an organization about a new call.
+func (s *CallService) notifyCall(call *entity.Call) {
+	event := notification.EventNewCall
+	if call.Status == entity.CallMissed {
+		event = notification.EventMissedCall
+	}
+	callerName := ""
+	if call.Contact != nil {
+		callerName = call.Contact.Name
+	}
+	_, err := s.notifications.Notify(call.OrganizationID, event, nil, map[string]interface{}{
+		"CallerName":   callerName,
+		"CallerNumber": call.CallerNumber,
+		"CalledAt":     formatCallTime(s.db, call.OrganizationID, call.StartedAt),
+		"Summary":      call.Summary,
+	})
+	if err != nil {
+		logger.Error("Failed to notify call:", call.ExternalCallID, err)
+	}
+}
+
+// formatCallTime writes a time about a call in the time zone of its
+// organization, e.g. "Mon Jan 2, 3:04 PM".
+func formatCallTime(db *gorm.DB, organizationId uuid.UUID, at time.Time) string {
+	var organization entity.Organization
+	timeZone := entity.DefaultTimeZone
+	if err := db.Select("time_zone").First(&organization, "id = ?", organizationId).Error; err == nil && organization.TimeZone != "" {
+		timeZone = organization.TimeZone
+	}
+	if loc, err := time.LoadLocation(timeZone); err == nil {
+		at = at.In(loc)
+	}
+	return at.Format("Mon Jan 2, 3:04 PM")
+}
+
+// callCursor is the position after the last call of a page, encoded as
+// base64url JSON. It records the sort it was issued for, so that it is not
+// used with another one.
+type callCursor struct {
+	Sort      string     `json:"s"`
+	Order     string     `json:"o"`
+	StartedAt *time.Time `json:"t,omitempty"`
+	Duration  *int       `json:"d,omitempty"`
+	Id        uuid.UUID  `json:"i"`
+}
+
+// ListCalls returns a page of the calls of an organization.
+func (s *CallService) ListCalls(organizationId string, filter types.CallFilter) (*types.CallPageResponse, error) {
+	conditions, err := callConditions(organizationId, filter.CallCriteria)
+	if err != nil {
+		return nil, err
+	}
+	query := repository.CallQuery{
+		CallConditions: conditions,
+		SortColumn:     "started_at",
+		Descending:     filter.Order == "desc",
+		Limit:          filter.Limit + 1,
+	}
+	if filter.Sort == "duration" {
+		query.SortColumn = "duration_seconds"
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeCallCursor(filter.Cursor)
+		if err != nil || cursor.Sort != filter.Sort || cursor.Order != filter.Order {
+			return nil, errors.BadRequest("Invalid cursor")
+		}
+		switch {
+		case cursor.Sort == "duration" && cursor.Duration != nil:
+			query.AfterValue = *cursor.Duration
+		case cursor.Sort == "started_at" && cursor.StartedAt != nil:
+			query.AfterValue = *cursor.StartedAt
+		default:
+			return nil, errors.BadRequest("Invalid cursor")
+		}
+		query.AfterID = &cursor.Id
+	}
+
+	calls, err := s.dao.List(query)
+	if err != nil {
+		logger.Error("Error listing calls", err)
+		return nil, errors.InternalServerError("Error listing calls")
+	}
+
+	page := &types.CallPageResponse{Calls: make([]types.CallResponse, 0, len(calls))}
+	if len(calls) > filter.Limit {
+		calls = calls[:filter.Limit]
+		last := calls[len(calls)-1]
+		cursor := callCursor{Sort: filter.Sort, Order: filter.Order, Id: last.ID}
+		if filter.Sort == "duration" {
+			cursor.Duration = &last.DurationSeconds
+		} else {
+			cursor.StartedAt = &last.StartedAt
+		}
+		page.NextCursor = encodeCallCursor(cursor)
+	}
+	for _, call := range calls {
+		page.Calls = append(page.Calls, ToCallResponse(call))
+	}
+	return page, nil
+}
+
+// SearchCalls returns a page of the calls of an organization whose transcript
+// matches a query, with the matching turns of each.
+func (s *CallService) SearchCalls(organizationId string, filter types.CallSearchFilter) (*types.CallSearchResponse, error) {
+	conditions, err := callConditions(organizationId, filter.CallCriteria)
+	if err != nil {
+		return nil, err
+	}
+	page := &types.CallSearchResponse{
+		Results: []types.CallSearchResult{},
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}
+
+	matches, total, err := s.dao.Search(conditions, filter.Query, filter.Limit, filter.Offset)
+	if err != nil {
+		logger.Error("Error searching calls", err)
+		return nil, errors.InternalServerError("Error searching calls")
+	}
+	page.Total = total
+	if len(matches) == 0 {
+		return page, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.CallID)
+	}
+	calls, err := s.dao.GetByIds(organizationId, ids)
+	if err != nil {
+		logger.Error("Error loading calls", err)
+		return nil, errors.InternalServerError("Error searching calls")
+	}
+	turns, err := s.dao.GetTranscriptMatches(ids, filter.Query)
+	if err != nil {
+		logger.Error("Error highlighting transcripts", err)
+		return nil, errors.InternalServerError("Error searching calls")
+	}
+
+	byId := make(map[uuid.UUID]entity.Call, len(calls))
+	for _, call := range calls {
+		byId[call.ID] = call
+	}
+	turnsByCall := make(map[uuid.UUID][]types.TranscriptMatchResponse, len(ids))
+	for _, turn := range turns {
+		if len(turnsByCall[turn.CallID]) == maxTranscriptMatches {
+			continue
+		}
+		turnsByCall[turn.CallID] = append(turnsByCall[turn.CallID], types.TranscriptMatchResponse{
+			Position:     turn.Position,
+			Speaker:      turn.Speaker,
+			Snippet:      turn.Snippet,
+			OffsetMillis: turn.OffsetMillis,
+		})
+	}
+	for _, match := range matches {
+		call, ok := byId[match.CallID]
+		if !ok {
+			continue
+		}
+		result := types.CallSearchResult{
+			Call:    ToCallResponse(call),
+			Rank:    match.Rank,
+			Matches: turnsByCall[match.CallID],
+		}
+		if result.Matches == nil {
+			result.Matches = []types.TranscriptMatchResponse{}
+		}
+		page.Results = append(page.Results, result)
+	}
+	return page, nil
+}
+
+// GetCall returns a call of an organization with its transcript and notes.
+func (s *CallService) GetCall(organizationId string, id string) (*types.CallDetailResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.BadRequest("Invalid call ID")
+	}
+	call, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound("Call not found")
+	}
+
+	response := &types.CallDetailResponse{
+		CallResponse: ToCallResponse(call),
+		Transcript:   make([]types.TranscriptTurnResponse, 0, len(call.Transcript)),
+		Notes:        make([]types.CallNoteResponse, 0, len(call.Notes)),
+	}
+	for _, turn := range call.Transcript {
+		response.Transcript = append(response.Transcript, types.TranscriptTurnResponse{
+			Speaker:      turn.Speaker,
+			Text:         turn.Text,
+			OffsetMillis: turn.OffsetMillis,
+		})
+	}
+	for _, note := range call.Notes {
+		response.Notes = append(response.Notes, ToCallNoteResponse(note))
+	}
+	return response, nil
+}
+
+func ToCallResponse(call entity.Call) types.CallResponse {
+	tags := []string(call.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	response := types.CallResponse{
+		Id:              call.ID,
+		LocationId:      call.LocationID,
+		ExternalCallId:  call.ExternalCallID,
+		CallerNumber:    call.CallerNumber,
+		ContactId:       call.ContactID,
+		CalledNumber:    call.CalledNumber,
+		Status:          call.Status,
+		EndedReason:     call.EndedReason,
+		StartedAt:       call.StartedAt,
+		EndedAt:         call.EndedAt,
+		DurationSeconds: call.DurationSeconds,
+		Summary:         call.Summary,
+		TransferredTo:   call.TransferredTo,
+		AfterHours:      call.AfterHours,
+		Disposition:     call.Disposition,
+		Tags:            tags,
+	}
+	if call.Contact != nil {
+		response.ContactName = call.Contact.Name
+	}
+	return response
+}
+
+// callConditions validates call criteria and maps them to the conditions of
+// the calls of an organization.
+func callConditions(organizationId string, criteria types.CallCriteria) (repository.CallConditions, error) {
+	conditions := repository.CallConditions{
+		OrganizationId: organizationId,
+		From:           criteria.From,
+		To:             criteria.To,
+		MinDuration:    criteria.MinDuration,
+		MaxDuration:    criteria.MaxDuration,
+		Transferred:    criteria.Transferred,
+		Tags:           criteria.Tags,
+		Statuses:       criteria.Statuses,
+		Dispositions:   criteria.Dispositions,
+		LocationId:     criteria.LocationId,
+		ContactId:      criteria.ContactId,
+	}
+	if criteria.From != nil && criteria.To != nil && !criteria.From.Before(*criteria.To) {
+		return conditions, errors.BadRequest("The start of the date range must be before its end")
+	}
+	if criteria.MinDuration != nil && criteria.MaxDuration != nil && *criteria.MinDuration > *criteria.MaxDuration {
+		return conditions, errors.BadRequest("The minimum duration must not exceed the maximum duration")
+	}
+	if criteria.Caller != "" {
+		conditions.CallerDigits = digitsOf(criteria.Caller)
+		if conditions.CallerDigits == "" {
+			return conditions, errors.BadRequest("The caller number must contain digits")
+		}
+	}
+	return conditions, nil
+}
+
+func encodeCallCursor(cursor callCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCallCursor(value string) (callCursor, error) {
+	var cursor callCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
+
+// digitsOf returns the digits of a phone number.
+func digitsOf(number string) string {
+	var digits strings.Builder
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
+
+// toCall maps an end-of-call report to a call and its transcript.
+func toCall(message types.VoiceWebhookMessage, platform string) (entity.Call, []entity.TranscriptTurn) {
+	call := entity.Call{
+		Platform:      platform,
+		CallerNumber:  firstNonEmpty(message.Customer.Number, message.Call.Customer.Number),
+		CalledNumber:  firstNonEmpty(message.PhoneNumber.Number, message.Call.PhoneNumber.Number),
+		EndedReason:   message.EndedReason,
+		EndedAt:       message.EndedAt,
+		Summary:       firstNonEmpty(message.Summary, message.Analysis.Summary),
+		TransferredTo: message.ForwardedPhoneNumber,
+	}
+	if message.StartedAt != nil {
+		call.StartedAt = *message.StartedAt
+	} else if message.Call.StartedAt != nil {
+		call.StartedAt = *message.Call.StartedAt
+	}
+	if call.EndedAt == nil {
+		call.EndedAt = message.Call.EndedAt
+	}
+	call.DurationSeconds = int(math.Round(message.DurationSeconds))
+	if call.DurationSeconds == 0 && !call.StartedAt.IsZero() && call.EndedAt != nil {
+		call.DurationSeconds = int(call.EndedAt.Sub(call.StartedAt).Round(time.Second) / time.Second)
+	}
+
+	var turns []entity.TranscriptTurn
+	callerSpoke := false
+	for _, message := range message.Artifact.Messages {
+		var speaker string
+		switch message.Role {
+		case "bot", "assistant":
+			speaker = entity.SpeakerAgent
+		case "user":
+			speaker = entity.SpeakerCaller
+			callerSpoke = true
+		default:
+			continue
+		}
+		text := strings.TrimSpace(message.Message)
+		if text == "" {
+			continue
+		}
+		turns = append(turns, entity.TranscriptTurn{
+			Position:     len(turns),
+			Speaker:      speaker,
+			Text:         text,
+			OffsetMillis: int64(math.Round(message.SecondsFromStart * 1000)),
+		})
+	}
+
+	reason := strings.ToLower(message.EndedReason)
+	switch {
+	case call.TransferredTo != "" || strings.Contains(reason, "forwarded"):
+		call.Status = entity.CallForwarded
+	case strings.Contains(reason, "voicemail"):
+		call.Status = entity.CallVoicemail
+	case !callerSpoke || strings.Contains(reason, "error"):
+		call.Status = entity.CallMissed
+	default:
+		call.Status = entity.CallCompleted
+	}
+	return call, turns
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// dispositionCode is the form of a disposition code once normalized.
+var dispositionCode = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// CallReviewService lets the staff record what happened after a call: its
+// disposition, tags and notes. Every edit of a call is kept in its history.
+type CallReviewService struct {
+	db  *gorm.DB
+	dao *repository.CallReviewRepository
+}
+
+func NewCallReviewService(db *gorm.DB, dao *repository.CallReviewRepository) *CallReviewService {
+	return &CallReviewService{db: db, dao: dao}
+}
+
+// GetDispositions returns the dispositions of an organization in order. An
+// organization without any is given the default ones.
+func (s *CallReviewService) GetDispositions(organizationId string) ([]entity.CallDisposition, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	dispositions, err := s.dao.GetDispositions(organizationId)
+	if err != nil || len(dispositions) > 0 {
+		return dispositions, err
+	}
+
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+	defaults := make([]entity.CallDisposition, len(entity.DefaultCallDispositions))
+	for i, disposition := range entity.DefaultCallDispositions {
+		disposition.OrganizationID = orgID
+		disposition.Position = i
+		defaults[i] = disposition
+	}
+	if err := s.dao.CreateDispositions(defaults); err != nil {
+		return nil, err
+	}
+	return s.dao.GetDispositions(organizationId)
+}
+
+func (s *CallReviewService) CreateDisposition(organizationId string, dto types.CallDispositionRequest) (*entity.CallDisposition, error) {
+	dispositions, err := s.GetDispositions(organizationId)
+	if err != nil {
+		return nil, err
+	}
+	code, err := normalizeDispositionCode(dto.Code)
+	if err != nil {
+		return nil, err
+	}
+	for _, disposition := range dispositions {
+		if disposition.Code == code {
+			return nil, errors.BadRequest("A disposition with this code already exists")
+		}
+	}
+
+	disposition := entity.CallDisposition{
+		OrganizationID: uuid.MustParse(organizationId),
+		Code:           code,
+		Label:          strings.TrimSpace(dto.Label),
+		Position:       dto.Position,
+		Archived:       dto.Archived,
+	}
+	if err := s.dao.SaveDisposition(&disposition); err != nil {
+		return nil, err
+	}
+	return &disposition, nil
+}
+
+func (s *CallReviewService) UpdateDisposition(organizationId string, id string, dto types.CallDispositionRequest) (*entity.CallDisposition, error) {
+	disposition, err := s.dao.GetDispositionById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	code, err := normalizeDispositionCode(dto.Code)
+	if err != nil {
+		return nil, err
+	}
+	if code != disposition.Code {
+		return nil, errors.BadRequest("The code of a disposition cannot be changed")
+	}
+
+	disposition.Label = strings.TrimSpace(dto.Label)
+	disposition.Position = dto.Position
+	disposition.Archived = dto.Archived
+	if err := s.dao.SaveDisposition(&disposition); err != nil {
+		return nil, err
+	}
+	return &disposition, nil
+}
+
+// DeleteDisposition deletes a disposition set on no call. One in use can only
+// be archived.
+func (s *CallReviewService) DeleteDisposition(organizationId string, id string) error {
+	disposition, err := s.dao.GetDispositionById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	inUse, err := s.dao.DispositionInUse(disposition.OrganizationID, disposition.Code)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.BadRequest("The disposition is set on calls, archive it instead")
+	}
+	return s.dao.DeleteDisposition(&disposition)
+}
+
+func (s *CallReviewService) GetTags(organizationId string) ([]entity.CallTag, error) {
+	return s.dao.GetTags(organizationId)
+}
+
+func (s *CallReviewService) CreateTag(organizationId string, dto types.CallTagRequest) (*entity.CallTag, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+
+	tag := entity.CallTag{OrganizationID: orgID, Name: strings.TrimSpace(dto.Name)}
+	if err := s.checkTagName(tag); err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.SaveTag(tx, &tag, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// UpdateTag renames a tag, on the calls it is set on too.
+func (s *CallReviewService) UpdateTag(organizationId string, id string, dto types.CallTagRequest) (*entity.CallTag, error) {
+	tag, err := s.dao.GetTagById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	previousName := tag.Name
+	tag.Name = strings.TrimSpace(dto.Name)
+	if err := s.checkTagName(tag); err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.SaveTag(tx, &tag, previousName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// DeleteTag deletes a tag and removes it from the calls it is set on.
+func (s *CallReviewService) DeleteTag(organizationId string, id string) error {
+	tag, err := s.dao.GetTagById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.DeleteTag(tx, &tag)
+	})
+}
+
+// checkTagName rejects a blank tag name or one used by another tag.
+func (s *CallReviewService) checkTagName(tag entity.CallTag) error {
+	if tag.Name == "" {
+		return errors.BadRequest("The name of a tag is required")
+	}
+	tags, err := s.dao.GetTags(tag.OrganizationID.String())
+	if err != nil {
+		return err
+	}
+	for _, other := range tags {
+		if other.Name == tag.Name && other.ID != tag.ID {
+			return errors.BadRequest("A tag with this name already exists")
+		}
+	}
+	return nil
+}
+
+// SetDisposition sets the disposition of a call, or clears it with an empty
+// code. Archived dispositions cannot be set.
+func (s *CallReviewService) SetDisposition(organizationId string, callId string, user *entity.User, dto types.SetCallDispositionRequest) (*entity.Call, error) {
+	code := ""
+	if strings.TrimSpace(dto.Code) != "" {
+		var err error
+		if code, err = normalizeDispositionCode(dto.Code); err != nil {
+			return nil, err
+		}
+		dispositions, err := s.GetDispositions(organizationId)
+		if err != nil {
+			return nil, err
+		}
+		valid := slices.ContainsFunc(dispositions, func(disposition entity.CallDisposition) bool {
+			return disposition.Code == code && !disposition.Archived
+		})
+		if !valid {
+			return nil, errors.BadRequest("Unknown disposition " + code)
+		}
+	}
+
+	var call entity.Call
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if call, err = s.dao.LockCall(tx, organizationId, callId); err != nil {
+			return errors.NotFound("Call not found")
+		}
+		if call.Disposition == code {
+			return nil
+		}
+		change := entity.CallChange{CallID: call.ID, UserID: user.ID, Field: entity.CallFieldDisposition, Previous: call.Disposition, Current: code}
+		call.Disposition = code
+		if err := s.dao.UpdateCall(tx, &call); err != nil {
+			return err
+		}
+		return s.dao.AddChange(tx, &change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &call, nil
+}
+
+// SetTags replaces the tags of a call. Every tag must be one of the
+// organization.
+func (s *CallReviewService) SetTags(organizationId string, callId string, user *entity.User, dto types.SetCallTagsRequest) (*entity.Call, error) {
+	tags := []string{}
+	for _, tag := range dto.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	var call entity.Call
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if call, err = s.dao.LockCall(tx, organizationId, callId); err != nil {
+			return errors.NotFound("Call not found")
+		}
+		if len(tags) > 0 {
+			count, err := s.dao.CountTags(call.OrganizationID, tags)
+			if err != nil {
+				return err
+			}
+			if count != int64(len(tags)) {
+				return errors.BadRequest("Every tag must be a tag of the organization")
+			}
+		}
+		if slices.Equal([]string(call.Tags), tags) {
+			return nil
+		}
+		change := entity.CallChange{CallID: call.ID, UserID: user.ID, Field: entity.CallFieldTags,
+			Previous: strings.Join(call.Tags, ", "), Current: strings.Join(tags, ", ")}
+		call.Tags = tags
+		if err := s.dao.UpdateCall(tx, &call); err != nil {
+			return err
+		}
+		return s.dao.AddChange(tx, &change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &call, nil
+}
+
+// AddNote leaves a note on a call.
+func (s *CallReviewService) AddNote(organizationId string, callId string, user *entity.User, dto types.CallNoteRequest) (*entity.CallNote, error) {
+	note := entity.CallNote{AuthorID: user.ID, Body: strings.TrimSpace(dto.Body)}
+	if note.Body == "" {
+		return nil, errors.BadRequest("The note is empty")
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		call, err := s.dao.LockCall(tx, organizationId, callId)
+		if err != nil {
+			return errors.NotFound("Call not found")
+		}
+		note.CallID = call.ID
+		if err := s.dao.SaveNote(tx, &note); err != nil {
+			return err
+		}
+		return s.dao.AddChange(tx, &entity.CallChange{CallID: call.ID, UserID: user.ID, Field: entity.CallFieldNote, NoteID: &note.ID, Current: note.Body})
+	})
+	if err != nil {
+		return nil, err
+	}
+	note.Author = user
+	return &note, nil
+}
+
+// UpdateNote edits a note. Only its author and admins can.
+func (s *CallReviewService) UpdateNote(organizationId string, callId string, noteId string, user *entity.User, dto types.CallNoteRequest) (*entity.CallNote, error) {
+	body := strings.TrimSpace(dto.Body)
+	if body == "" {
+		return nil, errors.BadRequest("The note is empty")
+	}
+	var note entity.CallNote
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if note, err = s.lockNote(tx, organizationId, callId, noteId, user); err != nil {
+			return err
+		}
+		if note.Body == body {
+			return nil
+		}
+		change := entity.CallChange{CallID: note.CallID, UserID: user.ID, Field: entity.CallFieldNote, NoteID: &note.ID, Previous: note.Body, Current: body}
+		now := time.Now()
+		note.Body = body
+		note.EditedAt = &now
+		if err := s.dao.SaveNote(tx, &note); err != nil {
+			return err
+		}
+		return s.dao.AddChange(tx, &change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteNote deletes a note. Only its author and admins can.
+func (s *CallReviewService) DeleteNote(organizationId string, callId string, noteId string, user *entity.User) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		note, err := s.lockNote(tx, organizationId, callId, noteId, user)
+		if err != nil {
+			return err
+		}
+		if err := s.dao.DeleteNote(tx, &note); err != nil {
+			return err
+		}
+		return s.dao.AddChange(tx, &entity.CallChange{CallID: note.CallID, UserID: user.ID, Field: entity.CallFieldNote, NoteID: &note.ID, Previous: note.Body})
+	})
+}
+
+// lockNote locks the call of a note and returns the note, provided the user
+// may edit it.
+func (s *CallReviewService) lockNote(tx *gorm.DB, organizationId string, callId string, noteId string, user *entity.User) (entity.CallNote, error) {
+	call, err := s.dao.LockCall(tx, organizationId, callId)
+	if err != nil {
+		return entity.CallNote{}, errors.NotFound("Call not found")
+	}
+	note, err := s.dao.GetNote(call.ID, noteId)
+	if err != nil {
+		return note, errors.NotFound("Note not found")
+	}
+	isAdmin := user.Role != nil && *user.Role == entity.Admin
+	if note.AuthorID != user.ID && !isAdmin {
+		return note, errors.Forbidden("Only the author of a note can change it")
+	}
+	return note, nil
+}
+
+// GetHistory returns the edits of a call, newest first.
+func (s *CallReviewService) GetHistory(organizationId string, callId string) ([]entity.CallChange, error) {
+	var call entity.Call
+	if err := s.db.Select("id").First(&call, "id = ? AND organization_id = ?", callId, organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("Call not found")
+		}
+		logger.Error("Error loading call", err)
+		return nil, errors.InternalServerError("Error loading call history")
+	}
+	return s.dao.GetChanges(call.ID)
+}
+
+// normalizeDispositionCode lowercases a code and joins its words with
+// underscores.
+func normalizeDispositionCode(code string) (string, error) {
+	code = strings.Join(strings.FieldsFunc(strings.ToLower(code), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+	if !dispositionCode.MatchString(code) {
+		return "", errors.BadRequest("A disposition code is made of letters, digits and underscores")
+	}
+	return code, nil
+}
+
+func ToCallDispositionResponse(disposition entity.CallDisposition) types.CallDispositionResponse {
+	return types.CallDispositionResponse{
+		Id:       disposition.ID,
+		Code:     disposition.Code,
+		Label:    disposition.Label,
+		Position: disposition.Position,
+		Archived: disposition.Archived,
+	}
+}
+
+func ToCallTagResponse(tag entity.CallTag) types.CallTagResponse {
+	return types.CallTagResponse{Id: tag.ID, Name: tag.Name}
+}
+
+func ToCallNoteResponse(note entity.CallNote) types.CallNoteResponse {
+	response := types.CallNoteResponse{
+		Id:        note.ID,
+		AuthorId:  note.AuthorID,
+		Body:      note.Body,
+		CreatedAt: note.CreatedAt,
+		EditedAt:  note.EditedAt,
+	}
+	if note.Author != nil {
+		response.AuthorName = note.Author.DisplayName()
+	}
+	return response
+}
+
+func ToCallChangeResponse(change entity.CallChange) types.CallChangeResponse {
+	response := types.CallChangeResponse{
+		Id:        change.ID,
+		UserId:    change.UserID,
+		Field:     change.Field,
+		NoteId:    change.NoteID,
+		Previous:  change.Previous,
+		Current:   change.Current,
+		CreatedAt: change.CreatedAt,
+	}
+	if change.User != nil {
+		response.UserName = change.User.DisplayName()
+	}
+	return response
+}
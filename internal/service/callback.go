@@ -0,0 +1,513 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/notification"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// callbackBatchSize bounds the breached tasks notified per worker pass.
+	callbackBatchSize = 50
+	// callbackPollInterval is how often breached tasks are looked for.
+	callbackPollInterval = time.Minute
+)
+
+// CallbackService creates callback tasks from the calls the agent could not
+// resolve, by the rules of their organization, and lets the staff assign and
+// close them. Tasks are due after a number of minutes of opening hours; in
+// the background, the assignee of a task still active past its due time, or
+// every user when it has none, is told once.
+type CallbackService struct {
+	db            *gorm.DB
+	dao           *repository.CallbackRepository
+	notifications *NotificationService
+	availability  *AvailabilityService
+	worker        *worker[entity.CallbackTask]
+}
+
+func NewCallbackService(db *gorm.DB, dao *repository.CallbackRepository, notifications *NotificationService, availability *AvailabilityService) *CallbackService {
+	s := &CallbackService{
+		db:            db,
+		dao:           dao,
+		notifications: notifications,
+		availability:  availability,
+	}
+	s.worker = newWorker("breached callbacks", callbackBatchSize, 0, callbackPollInterval, s.claimBreached, s.notifyBreached)
+	return s
+}
+
+// GetRules returns the callback rules of an organization in order. An
+// organization without any is given the default ones.
+func (s *CallbackService) GetRules(organizationId string) ([]entity.CallbackRule, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	rules, err := s.dao.GetRules(organizationId)
+	if err != nil || len(rules) > 0 {
+		return rules, err
+	}
+
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+	defaults := make([]entity.CallbackRule, len(entity.DefaultCallbackRules))
+	for i, rule := range entity.DefaultCallbackRules {
+		rule.OrganizationID = orgID
+		defaults[i] = rule
+	}
+	if err := s.dao.CreateRules(defaults); err != nil {
+		return nil, err
+	}
+	return s.dao.GetRules(organizationId)
+}
+
+func (s *CallbackService) CreateRule(organizationId string, dto types.CallbackRuleRequest) (*entity.CallbackRule, error) {
+	rules, err := s.GetRules(organizationId)
+	if err != nil {
+		return nil, err
+	}
+	rule := entity.CallbackRule{OrganizationID: uuid.MustParse(organizationId)}
+	if err := s.applyRule(&rule, dto); err != nil {
+		return nil, err
+	}
+	if err := checkRuleName(rules, &rule); err != nil {
+		return nil, err
+	}
+	if err := s.dao.SaveRule(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *CallbackService) UpdateRule(organizationId string, id string, dto types.CallbackRuleRequest) (*entity.CallbackRule, error) {
+	rule, err := s.dao.GetRuleById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := s.applyRule(&rule, dto); err != nil {
+		return nil, err
+	}
+	rules, err := s.dao.GetRules(organizationId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRuleName(rules, &rule); err != nil {
+		return nil, err
+	}
+	if err := s.dao.SaveRule(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// DeleteRule deletes a rule. The tasks it created are kept. Deleting every
+// rule brings back the default ones; disable them instead.
+func (s *CallbackService) DeleteRule(organizationId string, id string) error {
+	rule, err := s.dao.GetRuleById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	return s.dao.DeleteRule(&rule)
+}
+
+func (s *CallbackService) applyRule(rule *entity.CallbackRule, dto types.CallbackRuleRequest) error {
+	if dto.AssigneeId != nil {
+		if err := s.checkAssignee(rule.OrganizationID, *dto.AssigneeId); err != nil {
+			return err
+		}
+	}
+	rule.Name = strings.TrimSpace(dto.Name)
+	rule.Position = dto.Position
+	rule.Enabled = dto.Enabled
+	rule.Statuses = compactStrings(dto.Statuses)
+	rule.EndReasons = compactStrings(dto.EndReasons)
+	rule.Keywords = compactStrings(dto.Keywords)
+	rule.AfterHours = dto.AfterHours
+	rule.Transferred = dto.Transferred
+	rule.Reason = strings.TrimSpace(dto.Reason)
+	rule.DueMinutes = dto.DueMinutes
+	rule.AssigneeID = dto.AssigneeId
+	return nil
+}
+
+// checkRuleName makes sure no other rule of the organization of a rule has its
+// name.
+func checkRuleName(rules []entity.CallbackRule, rule *entity.CallbackRule) error {
+	for _, other := range rules {
+		if other.ID != rule.ID && other.Name == rule.Name {
+			return errors.BadRequest("A callback rule with this name already exists")
+		}
+	}
+	return nil
+}
+
+// checkAssignee makes sure a user belongs to an organization.
+func (s *CallbackService) checkAssignee(organizationId uuid.UUID, userId uuid.UUID) error {
+	var count int64
+	if err := s.db.Model(&entity.User{}).Where("id = ? AND organization_id = ?", userId, organizationId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.BadRequest("The assignee is not a user of the organization")
+	}
+	return nil
+}
+
+// FromCall creates the callback task of a recorded call when a rule of its
+// organization matches it, unless the caller already has an active task.
+// Reports processed again for the same call never create a second task.
+func (s *CallbackService) FromCall(call *entity.Call) {
+	if call.CallerNumber == "" {
+		return
+	}
+	rule, err := s.matchingRule(call)
+	if err != nil {
+		logger.Error("Failed to match the callback rules of call:", call.ExternalCallID, err)
+		return
+	}
+	if rule == nil {
+		return
+	}
+	active, err := s.dao.HasActiveTask(call.OrganizationID, call.CallerNumber)
+	if err != nil {
+		logger.Error("Failed to look up the callbacks of call:", call.ExternalCallID, err)
+		return
+	}
+	if active {
+		return
+	}
+
+	task := entity.CallbackTask{
+		OrganizationID: call.OrganizationID,
+		LocationID:     call.LocationID,
+		CallID:         call.ID,
+		RuleID:         &rule.ID,
+		CallerNumber:   call.CallerNumber,
+		Reason:         rule.Reason,
+		Status:         entity.CallbackOpen,
+		DueAt:          s.dueAt(call, time.Duration(rule.DueMinutes)*time.Minute),
+	}
+	if rule.AssigneeID != nil && s.checkAssignee(call.OrganizationID, *rule.AssigneeID) == nil {
+		task.AssigneeID = rule.AssigneeID
+	}
+	created, err := s.dao.InsertTask(&task)
+	if err != nil {
+		logger.Error("Failed to create the callback of call:", call.ExternalCallID, err)
+		return
+	}
+	if created {
+		s.notify(task, notification.EventCallbackRequested)
+	}
+}
+
+// matchingRule returns the first enabled rule of the organization of a call
+// matching it, or nil.
+func (s *CallbackService) matchingRule(call *entity.Call) (*entity.CallbackRule, error) {
+	rules, err := s.GetRules(call.OrganizationID.String())
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Enabled {
+			continue
+		}
+		if len(rule.Statuses) > 0 && !slices.Contains(rule.Statuses, call.Status) {
+			continue
+		}
+		if len(rule.EndReasons) > 0 && !containsAny(call.EndedReason, rule.EndReasons) {
+			continue
+		}
+		if rule.AfterHours != nil && *rule.AfterHours != call.AfterHours {
+			continue
+		}
+		if rule.Transferred != nil && *rule.Transferred != call.Transferred() {
+			continue
+		}
+		if len(rule.Keywords) > 0 && !containsAny(call.Summary, rule.Keywords) {
+			said, err := s.dao.CallerSaid(call.ID, rule.Keywords)
+			if err != nil {
+				return nil, err
+			}
+			if !said {
+				continue
+			}
+		}
+		return rule, nil
+	}
+	return nil, nil
+}
+
+// dueAt adds d of opening hours of the location called, or of the
+// organization, to the end of a call. Without opening hours to count, d is
+// added as is.
+func (s *CallbackService) dueAt(call *entity.Call, d time.Duration) time.Time {
+	from := call.StartedAt
+	if call.EndedAt != nil {
+		from = *call.EndedAt
+	}
+	locationId := ""
+	if call.LocationID != nil {
+		locationId = call.LocationID.String()
+	}
+	hours, err := s.availability.OpeningHours(call.OrganizationID.String(), locationId, "")
+	if err != nil {
+		logger.Error("Failed to resolve the opening hours of call:", call.ExternalCallID, err)
+		return from.Add(d)
+	}
+	if due, ok := hours.AddOpenTime(from, d); ok {
+		return due
+	}
+	return from.Add(d)
+}
+
+// ListTasks returns a page of the callback tasks of the organization of a
+// user, the soonest due first. Only active tasks are listed unless statuses
+// are given.
+func (s *CallbackService) ListTasks(user *entity.User, filter types.CallbackFilter) (*types.CallbackPageResponse, error) {
+	query := repository.CallbackQuery{
+		OrganizationID: user.OrganizationID,
+		Overdue:        filter.Overdue,
+		Now:            time.Now(),
+		Limit:          filter.Limit,
+		Offset:         filter.Offset,
+		Statuses:       []entity.CallbackStatus{entity.CallbackOpen, entity.CallbackInProgress},
+	}
+	if len(filter.Statuses) > 0 {
+		query.Statuses = nil
+		for _, status := range filter.Statuses {
+			query.Statuses = append(query.Statuses, entity.CallbackStatus(status))
+		}
+	}
+	switch filter.Assignee {
+	case "":
+	case "me":
+		query.AssigneeID = &user.ID
+	case "unassigned":
+		query.Unassigned = true
+	default:
+		assigneeId, err := uuid.Parse(filter.Assignee)
+		if err != nil {
+			return nil, errors.BadRequest("Invalid assignee, expected me, unassigned or a user ID")
+		}
+		query.AssigneeID = &assigneeId
+	}
+
+	tasks, total, err := s.dao.ListTasks(query)
+	if err != nil {
+		logger.Error("Error listing callback tasks", err)
+		return nil, errors.InternalServerError("Error listing callback tasks")
+	}
+	response := &types.CallbackPageResponse{
+		Items:  make([]types.CallbackTaskResponse, 0, len(tasks)),
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for _, task := range tasks {
+		response.Items = append(response.Items, ToCallbackTaskResponse(task, query.Now))
+	}
+	return response, nil
+}
+
+func (s *CallbackService) GetTask(organizationId string, id string) (*entity.CallbackTask, error) {
+	task, err := s.dao.GetTaskById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	return &task, nil
+}
+
+// AssignTask assigns a task to a user of its organization, or unassigns it.
+// The new assignee is told, unless they assigned it to themselves.
+func (s *CallbackService) AssignTask(organizationId string, id string, user *entity.User, dto types.AssignCallbackRequest) (*entity.CallbackTask, error) {
+	var task entity.CallbackTask
+	changed := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if task, err = s.dao.LockTask(tx, organizationId, id); err != nil {
+			return errors.NotFound(err.Error())
+		}
+		if dto.AssigneeId != nil {
+			if err := s.checkAssignee(task.OrganizationID, *dto.AssigneeId); err != nil {
+				return err
+			}
+		}
+		if equalIds(task.AssigneeID, dto.AssigneeId) {
+			return nil
+		}
+		changed = true
+		task.AssigneeID = dto.AssigneeId
+		return s.dao.SaveTask(tx, &task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changed && task.AssigneeID != nil && *task.AssigneeID != user.ID && task.Status.Active() {
+		s.notify(task, notification.EventCallbackRequested)
+	}
+	return s.GetTask(organizationId, id)
+}
+
+// SetTaskStatus moves a task to another status, as allowed by
+// entity.CallbackTransitions. Starting an unassigned task assigns it to the
+// user; completing or cancelling it records who did and the resolution, which
+// reopening clears.
+func (s *CallbackService) SetTaskStatus(organizationId string, id string, user *entity.User, dto types.CallbackStatusRequest) (*entity.CallbackTask, error) {
+	status := entity.CallbackStatus(dto.Status)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		task, err := s.dao.LockTask(tx, organizationId, id)
+		if err != nil {
+			return errors.NotFound(err.Error())
+		}
+		if task.Status == status {
+			return nil
+		}
+		if !slices.Contains(entity.CallbackTransitions[task.Status], status) {
+			return errors.BadRequest("A " + string(task.Status) + " callback cannot become " + string(status))
+		}
+
+		task.Status = status
+		if status.Active() {
+			task.CompletedAt = nil
+			task.CompletedByID = nil
+			task.Resolution = ""
+		} else {
+			now := time.Now()
+			task.CompletedAt = &now
+			task.CompletedByID = &user.ID
+			task.Resolution = strings.TrimSpace(dto.Resolution)
+		}
+		if status == entity.CallbackInProgress && task.AssigneeID == nil {
+			task.AssigneeID = &user.ID
+		}
+		return s.dao.SaveTask(tx, &task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTask(organizationId, id)
+}
+
+// Run tells about the tasks breaching their due time until the context is
+// cancelled.
+func (s *CallbackService) Run(ctx context.Context) {
+	s.worker.run(ctx)
+}
+
+// claimBreached claims the tasks breaching their due time. Breached tasks are
+// claimed once and for all, so they need no lease.
+func (s *CallbackService) claimBreached(now time.Time, _ time.Duration, limit int) ([]entity.CallbackTask, error) {
+	return s.dao.ClaimBreached(now, limit)
+}
+
+func (s *CallbackService) notifyBreached(_ context.Context, task *entity.CallbackTask) {
+	s.notify(*task, notification.EventCallbackOverdue)
+}
+
+// notify tells the assignee of a task about it, or every user of its
+// organization when it has none.
+func (s *CallbackService) notify(task entity.CallbackTask, event string) {
+	var userIds []uuid.UUID
+	if task.AssigneeID != nil {
+		userIds = []uuid.UUID{*task.AssigneeID}
+	}
+	callerName, err := s.dao.CallerName(task.CallID)
+	if err != nil {
+		logger.Error("Failed to load the caller name of callback:", task.ID, err)
+	}
+	_, err = s.notifications.Notify(task.OrganizationID, event, userIds, map[string]interface{}{
+		"CallerName":   callerName,
+		"CallerNumber": task.CallerNumber,
+		"Reason":       task.Reason,
+		"DueAt":        formatCallTime(s.db, task.OrganizationID, task.DueAt),
+	})
+	if err != nil {
+		logger.Error("Failed to notify callback:", task.ID, err)
+	}
+}
+
+func ToCallbackRuleResponse(rule entity.CallbackRule) types.CallbackRuleResponse {
+	return types.CallbackRuleResponse{
+		Id:          rule.ID,
+		Name:        rule.Name,
+		Position:    rule.Position,
+		Enabled:     rule.Enabled,
+		Statuses:    append([]string{}, rule.Statuses...),
+		EndReasons:  append([]string{}, rule.EndReasons...),
+		Keywords:    append([]string{}, rule.Keywords...),
+		AfterHours:  rule.AfterHours,
+		Transferred: rule.Transferred,
+		Reason:      rule.Reason,
+		DueMinutes:  rule.DueMinutes,
+		AssigneeId:  rule.AssigneeID,
+	}
+}
+
+// ToCallbackTaskResponse maps a task, telling whether it is overdue at now.
+func ToCallbackTaskResponse(task entity.CallbackTask, now time.Time) types.CallbackTaskResponse {
+	response := types.CallbackTaskResponse{
+		Id:            task.ID,
+		CallId:        task.CallID,
+		LocationId:    task.LocationID,
+		RuleId:        task.RuleID,
+		CallerNumber:  task.CallerNumber,
+		Reason:        task.Reason,
+		Status:        string(task.Status),
+		AssigneeId:    task.AssigneeID,
+		DueAt:         task.DueAt,
+		Overdue:       task.Status.Active() && task.DueAt.Before(now),
+		Breached:      task.BreachedAt != nil || (task.CompletedAt != nil && task.CompletedAt.After(task.DueAt)),
+		CompletedAt:   task.CompletedAt,
+		CompletedById: task.CompletedByID,
+		Resolution:    task.Resolution,
+		CreatedAt:     task.CreatedAt,
+	}
+	response.Breached = response.Breached || response.Overdue
+	if task.Assignee != nil {
+		response.AssigneeName = task.Assignee.DisplayName()
+	}
+	return response
+}
+
+// containsAny tells whether text contains one of words, ignoring case.
+func containsAny(text string, words []string) bool {
+	text = strings.ToLower(text)
+	return slices.ContainsFunc(words, func(word string) bool {
+		return strings.Contains(text, strings.ToLower(word))
+	})
+}
+
+// compactStrings trims values and drops the empty and repeated ones.
+func compactStrings(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func equalIds(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AgentKeyHeader is the header carrying the API key of the voice agent.
+const AgentKeyHeader = "X-Api-Key"
+
+const (
+	// callerRecentCalls bounds the past calls returned by a lookup.
+	callerRecentCalls = 5
+	// callerOpenCallbacks bounds the callbacks returned by a lookup.
+	callerOpenCallbacks = 5
+)
+
+// CallerLookupService tells the voice agent what is known about a caller
+// when a call starts. A lookup is bounded by a time budget: what cannot be
+// loaded in time is left out rather than delaying the call.
+type CallerLookupService struct {
+	db        *gorm.DB
+	contacts  *repository.ContactRepository
+	callbacks *repository.CallbackRepository
+	budget    time.Duration
+}
+
+func NewCallerLookupService(db *gorm.DB, contacts *repository.ContactRepository, callbacks *repository.CallbackRepository, cfg config.VoicePlatformConfig) *CallerLookupService {
+	s := &CallerLookupService{
+		db:        db,
+		contacts:  contacts,
+		callbacks: callbacks,
+		budget:    time.Duration(cfg.CallerLookupTimeoutMillis) * time.Millisecond,
+	}
+	if s.budget <= 0 {
+		s.budget = 300 * time.Millisecond
+	}
+	return s
+}
+
+// RotateAgentKey generates a new API key for the voice agent of an
+// organization. Only its hash is kept, and the previous key stops working
+// right away.
+func (s *CallerLookupService) RotateAgentKey(organizationId string) (*types.AgentKeyResponse, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	key := "ak_" + hex.EncodeToString(secret)
+	err = s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Update("agent_key_hash", hashAgentKey(key)).Error
+	if err != nil {
+		return nil, err
+	}
+	return &types.AgentKeyResponse{Key: key, Header: AgentKeyHeader}, nil
+}
+
+// LookupCaller returns what is known about the caller of a number. Unknown
+// and unusable numbers are not errors; the response then only tells that
+// the caller is not known.
+func (s *CallerLookupService) LookupCaller(ctx context.Context, organizationId uuid.UUID, key string, number string) (*types.CallerLookupResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.budget)
+	defer cancel()
+	if err := s.AuthorizeAgent(ctx, organizationId, key); err != nil {
+		return nil, err
+	}
+
+	response := &types.CallerLookupResponse{
+		Complete:      true,
+		RecentCalls:   []types.CallerCallResponse{},
+		OpenCallbacks: []types.CallerCallbackResponse{},
+	}
+	normalized := normalizePhoneNumber(number)
+	if normalized == "" {
+		return response, nil
+	}
+	contact, err := s.contacts.FindContact(ctx, organizationId, normalized)
+	if err == gorm.ErrRecordNotFound {
+		return response, nil
+	}
+	if err != nil {
+		logger.Warn("Failed to look up caller:", organizationId, err)
+		response.Complete = false
+		return response, nil
+	}
+	response.Known = true
+	response.ContactId = &contact.ID
+	response.Name = contact.Name
+	response.VIP = contact.VIP
+	response.Blocked = contact.Blocked
+	if contact.PreferredLanguage != nil {
+		response.PreferredLanguage = contact.PreferredLanguage.String()
+	}
+
+	var (
+		wg                 sync.WaitGroup
+		calls              []entity.Call
+		tasks              []entity.CallbackTask
+		callsErr, tasksErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		calls, callsErr = s.contacts.GetRecentCalls(ctx, contact.ID, callerRecentCalls)
+	}()
+	go func() {
+		defer wg.Done()
+		tasks, tasksErr = s.callbacks.GetActiveTasksOfContact(ctx, contact.ID, callerOpenCallbacks)
+	}()
+	wg.Wait()
+
+	if callsErr != nil {
+		logger.Warn("Failed to load the recent calls of caller:", contact.ID, callsErr)
+		response.Complete = false
+	}
+	for _, call := range calls {
+		response.RecentCalls = append(response.RecentCalls, types.CallerCallResponse{
+			StartedAt: call.StartedAt,
+			Status:    call.Status,
+			Summary:   call.Summary,
+		})
+	}
+	if tasksErr != nil {
+		logger.Warn("Failed to load the callbacks of caller:", contact.ID, tasksErr)
+		response.Complete = false
+	}
+	now := time.Now()
+	for _, task := range tasks {
+		response.OpenCallbacks = append(response.OpenCallbacks, types.CallerCallbackResponse{
+			Reason:  task.Reason,
+			Status:  string(task.Status),
+			DueAt:   task.DueAt,
+			Overdue: task.DueAt.Before(now),
+		})
+	}
+	return response, nil
+}
+
+// AuthorizeAgent checks that a key is the API key of the voice agent of an
+// organization. When the key cannot be checked in time, the agent is told to
+// go on without the lookup rather than getting a server error.
+func (s *CallerLookupService) AuthorizeAgent(ctx context.Context, organizationId uuid.UUID, key string) error {
+	if key == "" {
+		return errors.Unauthorized("Missing API key")
+	}
+	var hashes []string
+	err := s.db.WithContext(ctx).Model(&entity.Organization{}).Where("id = ?", organizationId).Pluck("agent_key_hash", &hashes).Error
+	if err != nil {
+		logger.Error("Failed to load the agent key of organization:", organizationId, err)
+		return errors.ServiceUnavailable("Caller lookup is unavailable")
+	}
+	if len(hashes) == 0 || hashes[0] == "" ||
+		subtle.ConstantTimeCompare([]byte(hashes[0]), []byte(hashAgentKey(key))) != 1 {
+		return errors.Unauthorized("Invalid API key")
+	}
+	return nil
+}
+
+func hashAgentKey(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// contactBackfillBatchSize bounds the calls linked per backfill pass.
+	contactBackfillBatchSize = 200
+	// contactTimelineSize bounds the calls returned with a contact.
+	contactTimelineSize = 50
+)
+
+// ContactService keeps a directory of the callers of each organization.
+// Every recorded call is linked to the contact of its normalized caller
+// number, which is created on the first call from it.
+type ContactService struct {
+	db  *gorm.DB
+	dao *repository.ContactRepository
+}
+
+func NewContactService(db *gorm.DB, dao *repository.ContactRepository) *ContactService {
+	return &ContactService{db: db, dao: dao}
+}
+
+// Attach links a call being recorded to the contact of its caller number.
+// Calls without a usable number are left without a contact.
+func (s *ContactService) Attach(tx *gorm.DB, call *entity.Call) error {
+	number := normalizePhoneNumber(call.CallerNumber)
+	if number == "" {
+		return nil
+	}
+	contact, err := s.dao.ContactForNumber(tx, call.OrganizationID, number)
+	if err != nil {
+		return err
+	}
+	call.ContactID = &contact.ID
+	call.Contact = &contact
+	return nil
+}
+
+// Run links the calls recorded before the directory existed to their
+// contacts, then returns. It stops early when the context is cancelled.
+func (s *ContactService) Run(ctx context.Context) {
+	after := uuid.Nil
+	for ctx.Err() == nil {
+		calls, err := s.dao.GetUnlinkedCalls(after, contactBackfillBatchSize)
+		if err != nil {
+			logger.Error("Failed to load the calls without contact:", err)
+			return
+		}
+		for i := range calls {
+			call := &calls[i]
+			err := s.db.Transaction(func(tx *gorm.DB) error {
+				if err := s.Attach(tx, call); err != nil || call.ContactID == nil {
+					return err
+				}
+				return s.dao.LinkCalls(tx, []uuid.UUID{call.ID}, *call.ContactID)
+			})
+			if err != nil {
+				logger.Error("Failed to link call to its contact:", call.ID, err)
+			}
+			after = call.ID
+		}
+		if len(calls) < contactBackfillBatchSize {
+			return
+		}
+	}
+}
+
+// ListContacts returns a page of the contacts of an organization, the most
+// recent callers first.
+func (s *ContactService) ListContacts(organizationId uuid.UUID, filter types.ContactFilter) (*types.ContactPageResponse, error) {
+	query := repository.ContactQuery{
+		OrganizationID: organizationId,
+		Name:           strings.TrimSpace(filter.Query),
+		Digits:         digitsOf(filter.Query),
+		Limit:          filter.Limit,
+		Offset:         filter.Offset,
+	}
+	contacts, total, err := s.dao.ListContacts(query)
+	if err != nil {
+		logger.Error("Error listing contacts", err)
+		return nil, errors.InternalServerError("Error listing contacts")
+	}
+	response := &types.ContactPageResponse{
+		Items:  make([]types.ContactResponse, 0, len(contacts)),
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for _, contact := range contacts {
+		response.Items = append(response.Items, ToContactResponse(contact))
+	}
+	return response, nil
+}
+
+// GetContact returns a contact with their latest calls.
+func (s *ContactService) GetContact(organizationId string, id string) (*types.ContactDetailResponse, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.NotFound("contact not found")
+	}
+	contact, err := s.dao.GetContactById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	calls, err := s.dao.GetCalls(contact.ID, contactTimelineSize)
+	if err != nil {
+		logger.Error("Error loading the calls of contact", err)
+		return nil, errors.InternalServerError("Error loading contact")
+	}
+
+	response := &types.ContactDetailResponse{
+		ContactResponse: ToContactResponse(contact),
+		Timeline:        make([]types.CallResponse, 0, len(calls)),
+	}
+	for _, call := range calls {
+		call.Contact = &contact.Contact
+		response.Timeline = append(response.Timeline, ToCallResponse(call))
+	}
+	return response, nil
+}
+
+func (s *ContactService) UpdateContact(organizationId string, id string, dto types.ContactRequest) (*types.ContactDetailResponse, error) {
+	contactId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NotFound("contact not found")
+	}
+	var language *entity.LanguageType
+	if dto.PreferredLanguage != "" {
+		parsed, err := entity.ParseLanguageType(dto.PreferredLanguage)
+		if err != nil {
+			return nil, errors.BadRequest(err.Error())
+		}
+		language = &parsed
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		contacts, err := s.dao.LockContacts(tx, organizationId, []uuid.UUID{contactId})
+		if err != nil {
+			return err
+		}
+		if len(contacts) == 0 {
+			return errors.NotFound("contact not found")
+		}
+		contact := contacts[0]
+		contact.Name = strings.TrimSpace(dto.Name)
+		contact.Notes = strings.TrimSpace(dto.Notes)
+		contact.PreferredLanguage = language
+		contact.VIP = dto.VIP
+		contact.Blocked = dto.Blocked
+		return s.dao.SaveContact(tx, &contact)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetContact(organizationId, id)
+}
+
+// MergeContacts merges contacts into another one: their numbers and calls
+// move to it and they are deleted. The name and preferred language of the
+// contact merged into are kept when set, the notes of all are joined, and
+// the contact is VIP or blocked when any of them was.
+func (s *ContactService) MergeContacts(organizationId string, id string, dto types.MergeContactsRequest) (*types.ContactDetailResponse, error) {
+	targetId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NotFound("contact not found")
+	}
+	sources := []uuid.UUID{}
+	for _, sourceId := range dto.ContactIds {
+		if sourceId != targetId && !slices.Contains(sources, sourceId) {
+			sources = append(sources, sourceId)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, errors.BadRequest("A contact cannot be merged into itself")
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		contacts, err := s.dao.LockContacts(tx, organizationId, append([]uuid.UUID{targetId}, sources...))
+		if err != nil {
+			return err
+		}
+		if len(contacts) != len(sources)+1 {
+			return errors.NotFound("contact not found")
+		}
+		target := contacts[slices.IndexFunc(contacts, func(contact entity.Contact) bool { return contact.ID == targetId })]
+		notes := []string{}
+		if target.Notes != "" {
+			notes = append(notes, target.Notes)
+		}
+		for _, contact := range contacts {
+			if contact.ID == targetId {
+				continue
+			}
+			if target.Name == "" {
+				target.Name = contact.Name
+			}
+			if target.PreferredLanguage == nil {
+				target.PreferredLanguage = contact.PreferredLanguage
+			}
+			if contact.Notes != "" {
+				notes = append(notes, contact.Notes)
+			}
+			target.VIP = target.VIP || contact.VIP
+			target.Blocked = target.Blocked || contact.Blocked
+		}
+		target.Notes = strings.Join(notes, "\n\n")
+
+		if err := s.dao.Merge(tx, targetId, sources); err != nil {
+			return err
+		}
+		return s.dao.SaveContact(tx, &target)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetContact(organizationId, id)
+}
+
+// SplitContact moves numbers of a contact, with the calls from them, to a
+// new contact. The contact keeps at least one number.
+func (s *ContactService) SplitContact(organizationId string, id string, dto types.SplitContactRequest) (*types.ContactDetailResponse, error) {
+	contactId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NotFound("contact not found")
+	}
+	numbers := []string{}
+	for _, number := range dto.PhoneNumbers {
+		normalized := normalizePhoneNumber(number)
+		if normalized == "" {
+			return nil, errors.BadRequest("Invalid phone number " + number)
+		}
+		if !slices.Contains(numbers, normalized) {
+			numbers = append(numbers, normalized)
+		}
+	}
+
+	created := entity.Contact{Name: strings.TrimSpace(dto.Name)}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		contacts, err := s.dao.LockContacts(tx, organizationId, []uuid.UUID{contactId})
+		if err != nil {
+			return err
+		}
+		if len(contacts) == 0 {
+			return errors.NotFound("contact not found")
+		}
+		contact := contacts[0]
+		for _, number := range numbers {
+			if !slices.ContainsFunc(contact.Phones, func(phone entity.ContactPhone) bool { return phone.Number == number }) {
+				return errors.BadRequest("The contact has no number " + number)
+			}
+		}
+		if len(numbers) == len(contact.Phones) {
+			return errors.BadRequest("The contact must keep at least one number")
+		}
+
+		created.OrganizationID = contact.OrganizationID
+		if err := s.dao.CreateContact(tx, &created); err != nil {
+			return err
+		}
+		if err := s.dao.MovePhones(tx, contact.ID, created.ID, numbers); err != nil {
+			return err
+		}
+		calls, err := s.dao.GetCallNumbers(tx, contact.ID)
+		if err != nil {
+			return err
+		}
+		var moved []uuid.UUID
+		for _, call := range calls {
+			if slices.Contains(numbers, normalizePhoneNumber(call.CallerNumber)) {
+				moved = append(moved, call.ID)
+			}
+		}
+		return s.dao.LinkCalls(tx, moved, created.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetContact(organizationId, created.ID.String())
+}
+
+func ToContactResponse(contact repository.ContactSummary) types.ContactResponse {
+	response := types.ContactResponse{
+		Id:           contact.ID,
+		Name:         contact.Name,
+		Notes:        contact.Notes,
+		VIP:          contact.VIP,
+		Blocked:      contact.Blocked,
+		PhoneNumbers: make([]string, 0, len(contact.Phones)),
+		Calls:        contact.Calls,
+		FirstCallAt:  contact.FirstCallAt,
+		LastCallAt:   contact.LastCallAt,
+		CreatedAt:    contact.CreatedAt,
+	}
+	if contact.PreferredLanguage != nil {
+		response.PreferredLanguage = contact.PreferredLanguage.String()
+	}
+	for _, phone := range contact.Phones {
+		response.PhoneNumbers = append(response.PhoneNumbers, phone.Number)
+	}
+	return response
+}
+
+// normalizePhoneNumber reduces a phone number to the E.164 form, so that the
+// same number written differently is recognized. Ten-digit numbers are taken
+// as North American. Numbers too short to be dialed from elsewhere, such as
+// short codes, keep their digits only; numbers without digits or with more
+// than E.164 allows are empty.
+func normalizePhoneNumber(number string) string {
+	digits := digitsOf(number)
+	switch {
+	case digits == "" || len(digits) > 15:
+		return ""
+	case len(digits) == 10 && !strings.HasPrefix(strings.TrimSpace(number), "+"):
+		return "+1" + digits
+	case len(digits) >= 11 || strings.HasPrefix(strings.TrimSpace(number), "+"):
+		return "+" + digits
+	}
+	return digits
+}
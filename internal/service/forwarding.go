@@ -0,0 +1,367 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/validator"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Sources of a forwarding decision.
+const (
+	ForwardingSourceRule         = "rule"
+	ForwardingSourceLocation     = "location"
+	ForwardingSourceOrganization = "organization"
+	ForwardingSourceVoicemail    = "voicemail"
+)
+
+type ForwardingService struct {
+	db           *gorm.DB
+	dao          *repository.ForwardingRuleRepository
+	availability *AvailabilityService
+}
+
+func NewForwardingService(db *gorm.DB, dao *repository.ForwardingRuleRepository, availability *AvailabilityService) *ForwardingService {
+	return &ForwardingService{db: db, dao: dao, availability: availability}
+}
+
+func (s *ForwardingService) GetRules(organizationId string) ([]entity.ForwardingRule, error) {
+	return s.dao.GetByOrganization(organizationId)
+}
+
+// CreateRule adds a rule after the existing ones.
+func (s *ForwardingService) CreateRule(organizationId string, dto types.ForwardingRuleRequest) (*entity.ForwardingRule, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+
+	rule := entity.ForwardingRule{OrganizationID: orgID}
+	if err := s.applyRuleRequest(&rule, dto); err != nil {
+		return nil, err
+	}
+	if rule.Priority, err = s.dao.NextPriority(orgID); err != nil {
+		return nil, err
+	}
+	if err := s.dao.Save(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *ForwardingService) UpdateRule(organizationId string, id string, dto types.ForwardingRuleRequest) (*entity.ForwardingRule, error) {
+	rule, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := s.applyRuleRequest(&rule, dto); err != nil {
+		return nil, err
+	}
+	if err := s.dao.Update(&rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *ForwardingService) DeleteRule(organizationId string, id string) error {
+	rule, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	return s.dao.Delete(&rule)
+}
+
+// ReorderRules changes the evaluation order. Every rule of the organization
+// must be listed exactly once.
+func (s *ForwardingService) ReorderRules(organizationId string, ids []uuid.UUID) ([]entity.ForwardingRule, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	rules, err := s.dao.GetByOrganization(organizationId)
+	if err != nil {
+		return nil, err
+	}
+	listed := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		listed[id] = true
+	}
+	if len(listed) != len(ids) || len(ids) != len(rules) {
+		return nil, errors.BadRequest("Every forwarding rule must be listed exactly once")
+	}
+	for _, rule := range rules {
+		if !listed[rule.ID] {
+			return nil, errors.BadRequest("Every forwarding rule must be listed exactly once")
+		}
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Reorder(tx, orgID, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.dao.GetByOrganization(organizationId)
+}
+
+// Resolve decides where to transfer a call: the first enabled rule matching
+// the call wins. Without a match the call goes to the forwarding number of
+// its location, then of the organization, and to voicemail last. With
+// explain, every rule evaluated is reported in the decision steps.
+func (s *ForwardingService) Resolve(organizationId string, call types.ForwardingCall, explain bool) (*types.ForwardingDecision, error) {
+	var organization entity.Organization
+	if err := s.db.First(&organization, "id = ?", organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+	locationId := ""
+	var location entity.Location
+	if call.LocationId != nil {
+		locationId = call.LocationId.String()
+		err := s.db.First(&location, "id = ? AND organization_id = ?", locationId, organizationId).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.NotFound("location not found")
+			}
+			return nil, err
+		}
+	}
+
+	at := time.Now()
+	if call.At != nil {
+		at = *call.At
+	}
+	hours, err := s.availability.OpeningHours(organizationId, locationId, "")
+	if err != nil {
+		return nil, err
+	}
+	open, _, _ := hours.Status(at)
+
+	rules, err := s.dao.GetByOrganization(organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &types.ForwardingDecision{At: at.UTC()}
+	for _, rule := range rules {
+		step := types.ForwardingStep{RuleId: &rule.ID, Name: rule.Name}
+		matched, reason := ruleMatches(rule, call, hours, open, at)
+		if matched {
+			matched, reason, err = s.applyRule(decision, rule, organizationId, locationId, at)
+			if err != nil {
+				return nil, err
+			}
+		}
+		step.Matched = matched
+		step.Reason = reason
+		if explain {
+			decision.Steps = append(decision.Steps, step)
+		}
+		if matched {
+			decision.Source = ForwardingSourceRule
+			decision.RuleId = &rule.ID
+			decision.RuleName = rule.Name
+			decision.Reason = reason
+			return decision, nil
+		}
+	}
+
+	decision.Action = entity.ForwardingActionForward
+	switch {
+	case location.CallForwardingNumber != "":
+		decision.Number = location.CallForwardingNumber
+		decision.Source = ForwardingSourceLocation
+		decision.Reason = "No rule matched, using the location forwarding number"
+	case organization.CallForwardingNumber != "":
+		decision.Number = organization.CallForwardingNumber
+		decision.Source = ForwardingSourceOrganization
+		decision.Reason = "No rule matched, using the organization forwarding number"
+	default:
+		decision.Action = entity.ForwardingActionVoicemail
+		decision.Source = ForwardingSourceVoicemail
+		decision.Reason = "No rule matched and no forwarding number is configured"
+	}
+	return decision, nil
+}
+
+// ruleMatches checks the conditions of a rule against a call.
+func ruleMatches(rule entity.ForwardingRule, call types.ForwardingCall, hours *OpeningHours, open bool, at time.Time) (bool, string) {
+	if !rule.Enabled {
+		return false, "Rule is disabled"
+	}
+	if rule.LocationID != nil && (call.LocationId == nil || *rule.LocationID != *call.LocationId) {
+		return false, "Rule applies to another location"
+	}
+	if len(rule.Intents) > 0 && !slices.Contains(rule.Intents, call.Intent) {
+		return false, fmt.Sprintf("Intent is not one of %s", strings.Join(rule.Intents, ", "))
+	}
+	switch rule.Schedule {
+	case entity.ForwardingOpen:
+		if !open {
+			return false, "Office is closed"
+		}
+	case entity.ForwardingClosed:
+		if open {
+			return false, "Office is open"
+		}
+	}
+
+	local := at.In(hours.Location)
+	if len(rule.Weekdays) > 0 && !slices.Contains(rule.Weekdays, int64(local.Weekday())) {
+		return false, fmt.Sprintf("Rule does not apply on %s", local.Weekday())
+	}
+	if rule.FromTime != "" && rule.ToTime != "" {
+		from, errFrom := validator.ClockMinutes(rule.FromTime)
+		to, errTo := validator.ClockMinutes(rule.ToTime)
+		minutes := local.Hour()*60 + local.Minute()
+		if errFrom != nil || errTo != nil || minutes < from || minutes >= to {
+			return false, fmt.Sprintf("Outside %s-%s", rule.FromTime, rule.ToTime)
+		}
+	}
+	return true, "Conditions matched"
+}
+
+// applyRule fills the decision with the action of a matching rule. A rule
+// forwarding to an unavailable staff member without fallback does not apply.
+func (s *ForwardingService) applyRule(decision *types.ForwardingDecision, rule entity.ForwardingRule, organizationId string, locationId string, at time.Time) (bool, string, error) {
+	decision.Action = rule.Action
+	if rule.Action == entity.ForwardingActionVoicemail {
+		return true, "Sending to voicemail", nil
+	}
+	if rule.TargetUserID == nil {
+		decision.Number = rule.TargetNumber
+		return true, "Forwarding to " + rule.TargetNumber, nil
+	}
+
+	var user entity.User
+	if err := s.db.First(&user, "id = ?", *rule.TargetUserID).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return false, "", err
+	}
+	available := false
+	reason := "Staff member no longer exists"
+	if user.ID != uuid.Nil {
+		staffHours, err := s.availability.OpeningHours(organizationId, locationId, user.ID.String())
+		if err != nil {
+			return false, "", err
+		}
+		available, _, _ = staffHours.Status(at)
+		switch {
+		case !available:
+			reason = "Staff member is not working"
+		case derefString(user.PhoneNumber) == "":
+			available = false
+			reason = "Staff member has no phone number"
+		}
+	}
+	if available {
+		decision.Number = *user.PhoneNumber
+		decision.UserId = &user.ID
+		return true, "Forwarding to the staff member", nil
+	}
+	if rule.FallbackNumber != "" {
+		decision.Number = rule.FallbackNumber
+		return true, reason + ", forwarding to the fallback number", nil
+	}
+	decision.Action = ""
+	return false, reason + " and the rule has no fallback number", nil
+}
+
+func (s *ForwardingService) applyRuleRequest(rule *entity.ForwardingRule, dto types.ForwardingRuleRequest) error {
+	organizationId := rule.OrganizationID.String()
+	if dto.Action == entity.ForwardingActionForward && dto.TargetNumber == "" && dto.TargetUserId == nil {
+		return errors.BadRequest("A forwarding rule needs a target number or a target user")
+	}
+	if dto.Action == entity.ForwardingActionForward && dto.TargetNumber != "" && dto.TargetUserId != nil {
+		return errors.BadRequest("A forwarding rule targets either a number or a user, not both")
+	}
+	if dto.LocationId != nil {
+		var count int64
+		if err := s.db.Model(&entity.Location{}).Where("id = ? AND organization_id = ?", *dto.LocationId, organizationId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.NotFound("location not found")
+		}
+	}
+	if dto.Action == entity.ForwardingActionForward && dto.TargetUserId != nil {
+		var count int64
+		if err := s.db.Model(&entity.User{}).Where("id = ? AND organization_id = ?", *dto.TargetUserId, organizationId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.NotFound("user not found")
+		}
+	}
+
+	rule.Name = strings.TrimSpace(dto.Name)
+	rule.LocationID = dto.LocationId
+	rule.Enabled = dto.Enabled == nil || *dto.Enabled
+	rule.Intents = dto.Intents
+	rule.Schedule = dto.Schedule
+	if rule.Schedule == "" {
+		rule.Schedule = entity.ForwardingAnyTime
+	}
+	rule.Weekdays = nil
+	for _, day := range dto.Weekdays {
+		rule.Weekdays = append(rule.Weekdays, int64(day))
+	}
+	rule.FromTime = dto.FromTime
+	rule.ToTime = dto.ToTime
+	rule.Action = dto.Action
+	rule.TargetNumber = ""
+	rule.TargetUserID = nil
+	rule.FallbackNumber = ""
+	if dto.Action == entity.ForwardingActionForward {
+		rule.TargetNumber = dto.TargetNumber
+		rule.TargetUserID = dto.TargetUserId
+		if dto.TargetUserId != nil {
+			rule.FallbackNumber = dto.FallbackNumber
+		}
+	}
+	return nil
+}
+
+// ToForwardingRuleResponse maps a forwarding rule to its API representation.
+func ToForwardingRuleResponse(rule entity.ForwardingRule) types.ForwardingRuleResponse {
+	intents := []string(rule.Intents)
+	if intents == nil {
+		intents = []string{}
+	}
+	weekdays := make([]string, 0, len(rule.Weekdays))
+	for _, day := range rule.Weekdays {
+		weekdays = append(weekdays, types.Weekday(day).String())
+	}
+	return types.ForwardingRuleResponse{
+		Id:             rule.ID,
+		Name:           rule.Name,
+		Priority:       rule.Priority,
+		LocationId:     rule.LocationID,
+		Enabled:        rule.Enabled,
+		Intents:        intents,
+		Schedule:       rule.Schedule,
+		Weekdays:       weekdays,
+		FromTime:       rule.FromTime,
+		ToTime:         rule.ToTime,
+		Action:         rule.Action,
+		TargetNumber:   rule.TargetNumber,
+		TargetUserId:   rule.TargetUserID,
+		FallbackNumber: rule.FallbackNumber,
+	}
+}
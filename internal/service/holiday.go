@@ -0,0 +1,161 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed data/holidays.json
+var holidayCatalogData []byte
+
+type holidayRule struct {
+	Type    string `json:"type"`
+	Month   int    `json:"month"`
+	Day     int    `json:"day"`
+	Weekday int    `json:"weekday"`
+	N       int    `json:"n"`
+	Offset  int    `json:"offset"`
+}
+
+type holidayDefinition struct {
+	Code           string      `json:"code"`
+	Name           string      `json:"name"`
+	Rule           holidayRule `json:"rule"`
+	ObserveWeekend bool        `json:"observe_weekend"`
+	Provinces      []string    `json:"provinces"`
+}
+
+type holidayCatalog struct {
+	Version  int                 `json:"version"`
+	Country  string              `json:"country"`
+	Holidays []holidayDefinition `json:"holidays"`
+}
+
+// Holiday is a statutory holiday observed on a given date.
+type Holiday struct {
+	Code string
+	Name string
+	Date time.Time
+}
+
+func loadHolidayCatalog() (*holidayCatalog, error) {
+	var catalog holidayCatalog
+	if err := json.Unmarshal(holidayCatalogData, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to read holiday catalog: %w", err)
+	}
+	return &catalog, nil
+}
+
+// HolidaysFor returns the statutory holidays of a province for a year, sorted
+// by date. Holidays falling on a weekend are moved to the next free weekday
+// when their definition says they are observed.
+func HolidaysFor(year int, province string) ([]Holiday, error) {
+	catalog, err := loadHolidayCatalog()
+	if err != nil {
+		return nil, err
+	}
+	province = strings.ToUpper(province)
+
+	var holidays []Holiday
+	for _, definition := range catalog.Holidays {
+		if !appliesToProvince(definition.Provinces, province) {
+			continue
+		}
+		date, err := definition.Rule.date(year)
+		if err != nil {
+			return nil, fmt.Errorf("holiday %s: %w", definition.Code, err)
+		}
+		holidays = append(holidays, Holiday{Code: definition.Code, Name: definition.Name, Date: date})
+	}
+	if len(holidays) == 0 {
+		return nil, fmt.Errorf("no holidays found for province %q", province)
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+
+	observed := make(map[time.Time]bool, len(holidays))
+	for i := range holidays {
+		definition := findHolidayDefinition(catalog, holidays[i].Code)
+		date := holidays[i].Date
+		if definition.ObserveWeekend {
+			for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday || observed[date] {
+				date = date.AddDate(0, 0, 1)
+			}
+		}
+		holidays[i].Date = date
+		observed[date] = true
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays, nil
+}
+
+func appliesToProvince(provinces []string, province string) bool {
+	for _, p := range provinces {
+		if p == "*" || p == province {
+			return true
+		}
+	}
+	return false
+}
+
+func findHolidayDefinition(catalog *holidayCatalog, code string) holidayDefinition {
+	for _, definition := range catalog.Holidays {
+		if definition.Code == code {
+			return definition
+		}
+	}
+	return holidayDefinition{}
+}
+
+func (r holidayRule) date(year int) (time.Time, error) {
+	switch r.Type {
+	case "fixed":
+		return time.Date(year, time.Month(r.Month), r.Day, 0, 0, 0, 0, time.UTC), nil
+	case "nth_weekday":
+		// n-th occurrence of a weekday in the month, or the last one when n is -1
+		if r.N == -1 {
+			date := time.Date(year, time.Month(r.Month)+1, 0, 0, 0, 0, 0, time.UTC)
+			for int(date.Weekday()) != r.Weekday {
+				date = date.AddDate(0, 0, -1)
+			}
+			return date, nil
+		}
+		date := time.Date(year, time.Month(r.Month), 1, 0, 0, 0, 0, time.UTC)
+		for int(date.Weekday()) != r.Weekday {
+			date = date.AddDate(0, 0, 1)
+		}
+		return date.AddDate(0, 0, 7*(r.N-1)), nil
+	case "weekday_before":
+		// last given weekday strictly before month/day, e.g. Victoria Day
+		date := time.Date(year, time.Month(r.Month), r.Day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		for int(date.Weekday()) != r.Weekday {
+			date = date.AddDate(0, 0, -1)
+		}
+		return date, nil
+	case "easter":
+		return easterSunday(year).AddDate(0, 0, r.Offset), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown rule type %q", r.Type)
+}
+
+// easterSunday computes Western Easter with the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
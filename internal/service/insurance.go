@@ -0,0 +1,417 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/textmatch"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Scopes of an organization insurance.
+const (
+	InsuranceScopeOrganization = "organization"
+	InsuranceScopeLocation     = "location"
+)
+
+const (
+	// insuranceMatchThreshold is the lowest score reported as a match.
+	insuranceMatchThreshold = 0.6
+	// insuranceMatchLimit bounds the number of matches returned.
+	insuranceMatchLimit = 5
+	// insuranceSpellingThreshold is the lowest similarity of a whole name
+	// accepted as a misspelling.
+	insuranceSpellingThreshold = 0.85
+	// insuranceAmbiguityMargin is how close two scores must be to be ambiguous.
+	insuranceAmbiguityMargin = 0.05
+)
+
+// insuranceStopWords are dropped from carrier names and caller phrasing alike,
+// so "do you take Sun Life insurance?" compares as "sun life".
+var insuranceStopWords = map[string]bool{
+	"insurance": true, "insurances": true, "insurer": true, "assurance": true, "assurances": true,
+	"financial": true, "financiere": true, "group": true, "groupe": true, "company": true,
+	"inc": true, "ltd": true, "co": true, "the": true, "of": true, "de": true, "du": true,
+	"la": true, "le": true, "des": true, "my": true, "i": true, "have": true, "do": true,
+	"you": true, "take": true, "accept": true, "with": true, "through": true, "is": true,
+	"it": true, "vous": true, "acceptez": true, "prenez": true, "j": true, "ai": true, "est": true,
+	"ce": true, "que": true, "avec": true, "plan": true, "coverage": true, "covered": true,
+}
+
+type InsuranceService struct {
+	db  *gorm.DB
+	dao *repository.InsuranceRepository
+}
+
+func NewInsuranceService(db *gorm.DB, dao *repository.InsuranceRepository) *InsuranceService {
+	return &InsuranceService{db: db, dao: dao}
+}
+
+// GetCarriers returns the carrier catalog, filtered by fuzzy matching when a
+// search is given.
+func (s *InsuranceService) GetCarriers(search string, includeRetired bool) ([]types.InsuranceCarrierResponse, error) {
+	carriers, err := s.dao.GetCarriers(includeRetired)
+	if err != nil {
+		return nil, err
+	}
+	result := []types.InsuranceCarrierResponse{}
+	for _, carrier := range carriers {
+		if search != "" && carrierScore(search, carrier) < insuranceMatchThreshold {
+			continue
+		}
+		result = append(result, toInsuranceCarrierResponse(carrier))
+	}
+	return result, nil
+}
+
+func (s *InsuranceService) CreateCarrier(dto types.InsuranceCarrierRequest) (*types.InsuranceCarrierResponse, error) {
+	code := strings.TrimSpace(dto.Code)
+	if code == "" {
+		code = slugify(dto.Name)
+	}
+	candidate := code
+	for i := 2; ; i++ {
+		exists, err := s.dao.ExistsCarrierCode(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			break
+		}
+		if dto.Code != "" {
+			return nil, errors.BadRequest("an insurance carrier with this code already exists")
+		}
+		candidate = fmt.Sprintf("%s_%d", code, i)
+	}
+
+	carrier := entity.InsuranceCarrier{
+		Code:      candidate,
+		Name:      strings.TrimSpace(dto.Name),
+		Aliases:   dto.Aliases,
+		PlanTypes: dto.PlanTypes,
+		Retired:   dto.Retired,
+	}
+	if err := s.dao.SaveCarrier(&carrier); err != nil {
+		return nil, err
+	}
+	response := toInsuranceCarrierResponse(carrier)
+	return &response, nil
+}
+
+func (s *InsuranceService) UpdateCarrier(id int, dto types.InsuranceCarrierRequest) (*types.InsuranceCarrierResponse, error) {
+	carrier, err := s.dao.GetCarrierById(id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	carrier.Name = strings.TrimSpace(dto.Name)
+	carrier.Aliases = dto.Aliases
+	carrier.PlanTypes = dto.PlanTypes
+	carrier.Retired = dto.Retired
+	if err := s.dao.UpdateCarrier(&carrier); err != nil {
+		return nil, err
+	}
+	response := toInsuranceCarrierResponse(carrier)
+	return &response, nil
+}
+
+// GetInsurances returns the carriers of an organization. For a location, its
+// own entries replace the organization-wide ones for the same carrier.
+func (s *InsuranceService) GetInsurances(organizationId string, locationId string) ([]types.OrganizationInsuranceResponse, error) {
+	insurances, err := s.effectiveInsurances(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]types.OrganizationInsuranceResponse, 0, len(insurances))
+	for _, insurance := range insurances {
+		result = append(result, toOrganizationInsuranceResponse(insurance))
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Carrier.Name < result[j].Carrier.Name })
+	return result, nil
+}
+
+// ReplaceInsurances replaces the carriers of an organization, or of one of its
+// locations, and returns the resulting effective list.
+func (s *InsuranceService) ReplaceInsurances(organizationId string, dto types.OrganizationInsurancesRequest) ([]types.OrganizationInsuranceResponse, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+	if dto.LocationId != nil {
+		if err := s.db.Model(&entity.Location{}).Where("id = ? AND organization_id = ?", *dto.LocationId, orgID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, errors.NotFound("location not found")
+		}
+	}
+
+	insurances := make([]entity.OrganizationInsurance, 0, len(dto.Insurances))
+	seen := map[int]bool{}
+	for _, item := range dto.Insurances {
+		carrier, err := s.findCarrier(item)
+		if err != nil {
+			return nil, err
+		}
+		if seen[carrier.ID] {
+			return nil, errors.BadRequest(fmt.Sprintf("%s is listed more than once", carrier.Name))
+		}
+		seen[carrier.ID] = true
+		for _, planType := range item.PlanTypes {
+			if !slices.Contains(carrier.PlanTypes, planType) {
+				return nil, errors.BadRequest(fmt.Sprintf("%s has no %q plan type", carrier.Name, planType))
+			}
+		}
+		insurances = append(insurances, entity.OrganizationInsurance{
+			OrganizationID: orgID,
+			LocationID:     dto.LocationId,
+			CarrierID:      carrier.ID,
+			Accepted:       item.Accepted,
+			PlanTypes:      item.PlanTypes,
+			Notes:          strings.TrimSpace(item.Notes),
+		})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Replace(tx, orgID, dto.LocationId, insurances)
+	})
+	if err != nil {
+		return nil, err
+	}
+	locationId := ""
+	if dto.LocationId != nil {
+		locationId = dto.LocationId.String()
+	}
+	return s.GetInsurances(organizationId, locationId)
+}
+
+// Match answers whether the organization, or one of its locations, takes the
+// insurance the caller named, however loosely. Carriers the organization did
+// not configure still match, with an unknown answer.
+func (s *InsuranceService) Match(organizationId string, locationId string, query string) (*types.InsuranceMatchResponse, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.BadRequest("q is required")
+	}
+	carriers, err := s.dao.GetCarriers(false)
+	if err != nil {
+		return nil, err
+	}
+	insurances, err := s.effectiveInsurances(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+	byCarrier := make(map[int]entity.OrganizationInsurance, len(insurances))
+	for _, insurance := range insurances {
+		byCarrier[insurance.CarrierID] = insurance
+	}
+
+	response := &types.InsuranceMatchResponse{Query: query, Answer: types.InsuranceNoMatch, Matches: []types.InsuranceMatch{}}
+	for _, carrier := range carriers {
+		score := carrierScore(query, carrier)
+		if score < insuranceMatchThreshold {
+			continue
+		}
+		match := types.InsuranceMatch{
+			Carrier:   toInsuranceCarrierResponse(carrier),
+			Score:     float64(int(score*100+0.5)) / 100,
+			PlanTypes: []string(carrier.PlanTypes),
+		}
+		if insurance, ok := byCarrier[carrier.ID]; ok {
+			accepted := insurance.Accepted
+			match.Accepted = &accepted
+			match.Scope = insuranceScope(insurance)
+			match.Notes = insurance.Notes
+			if len(insurance.PlanTypes) > 0 {
+				match.PlanTypes = []string(insurance.PlanTypes)
+			}
+		}
+		response.Matches = append(response.Matches, match)
+	}
+	sort.SliceStable(response.Matches, func(i, j int) bool { return response.Matches[i].Score > response.Matches[j].Score })
+	if len(response.Matches) > insuranceMatchLimit {
+		response.Matches = response.Matches[:insuranceMatchLimit]
+	}
+
+	if len(response.Matches) > 0 {
+		best := response.Matches[0]
+		response.Answer = matchAnswer(best)
+		for _, match := range response.Matches[1:] {
+			if best.Score-match.Score <= insuranceAmbiguityMargin && matchAnswer(match) != response.Answer {
+				response.Ambiguous = true
+			}
+		}
+	}
+	return response, nil
+}
+
+// effectiveInsurances returns the organization-wide entries, replaced by the
+// location ones for the same carrier when a location is given.
+func (s *InsuranceService) effectiveInsurances(organizationId string, locationId string) ([]entity.OrganizationInsurance, error) {
+	insurances, err := s.dao.GetByOrganization(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+	byCarrier := map[int]entity.OrganizationInsurance{}
+	var order []int
+	for _, insurance := range insurances {
+		current, ok := byCarrier[insurance.CarrierID]
+		if !ok {
+			order = append(order, insurance.CarrierID)
+		}
+		if !ok || (current.LocationID == nil && insurance.LocationID != nil) {
+			byCarrier[insurance.CarrierID] = insurance
+		}
+	}
+	result := make([]entity.OrganizationInsurance, 0, len(order))
+	for _, id := range order {
+		result = append(result, byCarrier[id])
+	}
+	return result, nil
+}
+
+func (s *InsuranceService) findCarrier(item types.OrganizationInsuranceRequest) (entity.InsuranceCarrier, error) {
+	var carrier entity.InsuranceCarrier
+	var err error
+	if item.CarrierId != nil {
+		carrier, err = s.dao.GetCarrierById(*item.CarrierId)
+	} else {
+		carrier, err = s.dao.GetCarrierByCode(item.CarrierCode)
+	}
+	if err != nil {
+		return carrier, errors.BadRequest(err.Error())
+	}
+	return carrier, nil
+}
+
+// carrierScore rates how well a query names a carrier, from 0 to 1, keeping
+// the best score among the carrier's name and aliases.
+func carrierScore(query string, carrier entity.InsuranceCarrier) float64 {
+	queryTokens := textmatch.Tokens(query, insuranceStopWords)
+	if len(queryTokens) == 0 {
+		queryTokens = textmatch.Tokens(query, nil)
+	}
+	joinedQuery := strings.Join(queryTokens, "")
+
+	best := 0.0
+	for _, name := range append([]string{carrier.Name, carrier.Code}, carrier.Aliases...) {
+		nameTokens := textmatch.Tokens(strings.ReplaceAll(name, "_", " "), insuranceStopWords)
+		if len(nameTokens) == 0 {
+			nameTokens = textmatch.Tokens(name, nil)
+		}
+		if len(nameTokens) == 0 {
+			continue
+		}
+		if similarity := textmatch.Similarity(joinedQuery, strings.Join(nameTokens, "")); similarity >= insuranceSpellingThreshold {
+			best = max(best, similarity)
+		}
+		best = max(best, tokenCoverage(queryTokens, joinedQuery, nameTokens))
+	}
+	return best
+}
+
+// tokenCoverage is the share of the name's words found in the query. Long
+// words may be misspelled or glued together ("sunlife"); short ones such as
+// acronyms must match exactly. A query made only of words of the name, such
+// as "empire" for "Empire Life", is rated halfway to a full match.
+func tokenCoverage(queryTokens []string, joinedQuery string, nameTokens []string) float64 {
+	var total float64
+	for _, nameToken := range nameTokens {
+		best := 0.0
+		if len([]rune(nameToken)) >= 4 && strings.Contains(joinedQuery, nameToken) {
+			best = 1
+		}
+		for _, queryToken := range queryTokens {
+			best = max(best, tokenSimilarity(queryToken, nameToken))
+		}
+		total += best
+	}
+	coverage := total / float64(len(nameTokens))
+
+	for _, queryToken := range queryTokens {
+		found := false
+		for _, nameToken := range nameTokens {
+			if tokenSimilarity(queryToken, nameToken) > 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return coverage
+		}
+	}
+	return (coverage + 1) / 2
+}
+
+// tokenSimilarity compares two words, returning 0 unless they are close.
+func tokenSimilarity(a string, b string) float64 {
+	similarity := textmatch.Similarity(a, b)
+	if similarity == 1 || (len([]rune(b)) >= 4 && similarity >= 0.8) {
+		return similarity
+	}
+	return 0
+}
+
+func matchAnswer(match types.InsuranceMatch) string {
+	switch {
+	case match.Accepted == nil:
+		return types.InsuranceUnknown
+	case *match.Accepted:
+		return types.InsuranceAccepted
+	}
+	return types.InsuranceNotAccepted
+}
+
+func insuranceScope(insurance entity.OrganizationInsurance) string {
+	if insurance.LocationID != nil {
+		return InsuranceScopeLocation
+	}
+	return InsuranceScopeOrganization
+}
+
+func toInsuranceCarrierResponse(carrier entity.InsuranceCarrier) types.InsuranceCarrierResponse {
+	aliases := []string(carrier.Aliases)
+	if aliases == nil {
+		aliases = []string{}
+	}
+	planTypes := []string(carrier.PlanTypes)
+	if planTypes == nil {
+		planTypes = []string{}
+	}
+	return types.InsuranceCarrierResponse{
+		Id:        carrier.ID,
+		Code:      carrier.Code,
+		Name:      carrier.Name,
+		Aliases:   aliases,
+		PlanTypes: planTypes,
+		Retired:   carrier.Retired,
+	}
+}
+
+func toOrganizationInsuranceResponse(insurance entity.OrganizationInsurance) types.OrganizationInsuranceResponse {
+	planTypes := []string(insurance.PlanTypes)
+	if planTypes == nil {
+		planTypes = []string{}
+	}
+	return types.OrganizationInsuranceResponse{
+		Id:         insurance.ID,
+		Carrier:    toInsuranceCarrierResponse(insurance.Carrier),
+		LocationId: insurance.LocationID,
+		Scope:      insuranceScope(insurance),
+		Accepted:   insurance.Accepted,
+		PlanTypes:  planTypes,
+		Notes:      insurance.Notes,
+	}
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultKnowledgeResults is how many entries a search returns by default.
+	defaultKnowledgeResults = 5
+	// maxKnowledgeResults bounds the entries a search returns.
+	maxKnowledgeResults = 20
+)
+
+// KnowledgeService manages the knowledge base the voice agent answers callers
+// from, and searches it during calls.
+type KnowledgeService struct {
+	db       *gorm.DB
+	dao      *repository.KnowledgeRepository
+	onChange []func(organizationId uuid.UUID) error
+}
+
+func NewKnowledgeService(db *gorm.DB, dao *repository.KnowledgeRepository) *KnowledgeService {
+	return &KnowledgeService{db: db, dao: dao}
+}
+
+// OnChange registers a function called whenever the knowledge base of an
+// organization changes.
+func (s *KnowledgeService) OnChange(fn func(organizationId uuid.UUID) error) {
+	s.onChange = append(s.onChange, fn)
+}
+
+func (s *KnowledgeService) changed(organizationId uuid.UUID) {
+	for _, fn := range s.onChange {
+		if err := fn(organizationId); err != nil {
+			logger.Error("Failed to handle knowledge base change:", organizationId, err)
+		}
+	}
+}
+
+func (s *KnowledgeService) GetEntries(organizationId string, kind string, category string) ([]entity.KnowledgeEntry, error) {
+	return s.dao.GetByOrganization(organizationId, kind, category, false)
+}
+
+// GetEnabledEntries returns the entries the agent uses, in order.
+func (s *KnowledgeService) GetEnabledEntries(organizationId string) ([]entity.KnowledgeEntry, error) {
+	return s.dao.GetByOrganization(organizationId, "", "", true)
+}
+
+func (s *KnowledgeService) GetEntry(organizationId string, id string) (*entity.KnowledgeEntry, error) {
+	entry, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	return &entry, nil
+}
+
+func (s *KnowledgeService) GetCategories(organizationId string) ([]string, error) {
+	return s.dao.GetCategories(organizationId)
+}
+
+// CreateEntry adds an entry after the existing ones.
+func (s *KnowledgeService) CreateEntry(organizationId string, dto types.KnowledgeEntryRequest) (*entity.KnowledgeEntry, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var count int64
+	if err := s.db.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.NotFound("organization not found")
+	}
+
+	entry := entity.KnowledgeEntry{OrganizationID: orgID, Enabled: true}
+	if err := applyKnowledgeRequest(&entry, dto); err != nil {
+		return nil, err
+	}
+	if entry.Position, err = s.dao.NextPosition(orgID); err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Save(tx, &entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.changed(orgID)
+	return &entry, nil
+}
+
+func (s *KnowledgeService) UpdateEntry(organizationId string, id string, dto types.KnowledgeEntryRequest) (*entity.KnowledgeEntry, error) {
+	entry, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := applyKnowledgeRequest(&entry, dto); err != nil {
+		return nil, err
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Update(tx, &entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.changed(entry.OrganizationID)
+	return &entry, nil
+}
+
+func (s *KnowledgeService) DeleteEntry(organizationId string, id string) error {
+	entry, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Delete(tx, &entry)
+	})
+	if err != nil {
+		return err
+	}
+	s.changed(entry.OrganizationID)
+	return nil
+}
+
+// ReorderEntries changes the order of the entries. Every entry of the
+// organization must be listed exactly once.
+func (s *KnowledgeService) ReorderEntries(organizationId string, ids []uuid.UUID) ([]entity.KnowledgeEntry, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	entries, err := s.dao.GetByOrganization(organizationId, "", "", false)
+	if err != nil {
+		return nil, err
+	}
+	listed := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		listed[id] = true
+	}
+	if len(listed) != len(ids) || len(ids) != len(entries) {
+		return nil, errors.BadRequest("Every knowledge entry must be listed exactly once")
+	}
+	for _, entry := range entries {
+		if !listed[entry.ID] {
+			return nil, errors.BadRequest("Every knowledge entry must be listed exactly once")
+		}
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Reorder(tx, orgID, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.changed(orgID)
+	return s.dao.GetByOrganization(organizationId, "", "", false)
+}
+
+// Search finds the enabled entries answering a caller's question, most
+// relevant first. Any word of the query may match, in any language; entries
+// are returned in the requested language when they have it.
+func (s *KnowledgeService) Search(organizationId string, query string, language string, category string, limit int) (*types.KnowledgeSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.BadRequest("The query is required")
+	}
+	var wanted *entity.LanguageType
+	if language != "" {
+		parsed, err := entity.ParseLanguageType(language)
+		if err != nil {
+			return nil, errors.BadRequest(err.Error())
+		}
+		wanted = &parsed
+	}
+	if limit <= 0 {
+		limit = defaultKnowledgeResults
+	}
+	limit = min(limit, maxKnowledgeResults)
+
+	matches, err := s.dao.Search(organizationId, query, category, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(matches))
+	scores := make(map[uuid.UUID]float64, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.EntryID)
+		scores[match.EntryID] = match.Rank
+	}
+	entries, err := s.dao.GetByIds(organizationId, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.KnowledgeSearchResponse{Query: query, Language: language, Results: []types.KnowledgeSearchResult{}}
+	for _, entry := range entries {
+		var variant entity.KnowledgeVariant
+		fallback := false
+		if wanted != nil {
+			variant, fallback = entry.Variant(*wanted)
+		} else if len(entry.Variants) > 0 {
+			variant = entry.Variants[0]
+		}
+		response.Results = append(response.Results, types.KnowledgeSearchResult{
+			EntryId:  entry.ID,
+			Kind:     entry.Kind,
+			Category: entry.Category,
+			Language: variant.Language.String(),
+			Title:    variant.Title,
+			Body:     variant.Body,
+			Score:    scores[entry.ID],
+			Fallback: fallback,
+		})
+	}
+	return response, nil
+}
+
+func applyKnowledgeRequest(entry *entity.KnowledgeEntry, dto types.KnowledgeEntryRequest) error {
+	variants := make([]entity.KnowledgeVariant, 0, len(dto.Variants))
+	for _, variant := range dto.Variants {
+		language, err := entity.ParseLanguageType(variant.Language)
+		if err != nil {
+			return errors.BadRequest(err.Error())
+		}
+		for _, other := range variants {
+			if other.Language == language {
+				return errors.BadRequest(fmt.Sprintf("The %s text is given twice", language))
+			}
+		}
+		variants = append(variants, entity.KnowledgeVariant{
+			EntryID:  entry.ID,
+			Language: language,
+			Title:    strings.TrimSpace(variant.Title),
+			Body:     strings.TrimSpace(variant.Body),
+			Keywords: variant.Keywords,
+		})
+	}
+
+	entry.Kind = dto.Kind
+	entry.Category = strings.TrimSpace(dto.Category)
+	if dto.Enabled != nil {
+		entry.Enabled = *dto.Enabled
+	}
+	entry.Variants = variants
+	return nil
+}
+
+func ToKnowledgeEntryResponse(entry entity.KnowledgeEntry) types.KnowledgeEntryResponse {
+	variants := make([]types.KnowledgeVariantResponse, 0, len(entry.Variants))
+	for _, variant := range entry.Variants {
+		variants = append(variants, types.KnowledgeVariantResponse{
+			Language: variant.Language.String(),
+			Title:    variant.Title,
+			Body:     variant.Body,
+			Keywords: toStrings(variant.Keywords),
+		})
+	}
+	return types.KnowledgeEntryResponse{
+		Id:        entry.ID,
+		Kind:      entry.Kind,
+		Category:  entry.Category,
+		Enabled:   entry.Enabled,
+		Position:  entry.Position,
+		Variants:  variants,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
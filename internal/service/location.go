@@ -0,0 +1,202 @@
+package service
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LocationService struct {
+	db  *gorm.DB
+	dao *repository.LocationRepository
+}
+
+func NewLocationService(db *gorm.DB, dao *repository.LocationRepository) *LocationService {
+	return &LocationService{db: db, dao: dao}
+}
+
+func (s *LocationService) GetLocations(organizationId string) ([]entity.Location, error) {
+	return s.dao.GetByOrganization(organizationId)
+}
+
+func (s *LocationService) GetLocationById(organizationId string, locationId string) (*entity.Location, error) {
+	location, err := s.dao.GetById(organizationId, locationId)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	return &location, nil
+}
+
+func (s *LocationService) CreateLocation(organizationId string, dto types.LocationRequest) (*entity.Location, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+
+	var location entity.Location
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.Organization{}).Where("id = ?", orgID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.NotFound("organization not found")
+		}
+
+		// The first location of an organization is always its default one
+		if err := tx.Model(&entity.Location{}).Where("organization_id = ?", orgID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		location = entity.Location{OrganizationID: orgID}
+		applyLocationRequest(&location, dto)
+		location.IsDefault = dto.IsDefault || count == 0
+		if location.IsDefault {
+			if err := clearDefaultLocation(tx, orgID); err != nil {
+				return err
+			}
+		}
+		if err := s.dao.Save(tx, &location); err != nil {
+			return err
+		}
+		return replaceLocationRelations(tx, &location, dto)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetLocationById(organizationId, location.ID.String())
+}
+
+func (s *LocationService) UpdateLocation(organizationId string, locationId string, dto types.LocationRequest) (*entity.Location, error) {
+	location, err := s.GetLocationById(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		wasDefault := location.IsDefault
+		applyLocationRequest(location, dto)
+		// A default location can only be replaced by promoting another one
+		location.IsDefault = wasDefault || dto.IsDefault
+		if location.IsDefault && !wasDefault {
+			if err := clearDefaultLocation(tx, location.OrganizationID); err != nil {
+				return err
+			}
+		}
+		if err := replaceLocationRelations(tx, location, dto); err != nil {
+			return err
+		}
+		return s.dao.Update(tx.Omit("Specialities", "DailySchedules", "Users"), location)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetLocationById(organizationId, locationId)
+}
+
+func (s *LocationService) DeleteLocation(organizationId string, locationId string) error {
+	location, err := s.GetLocationById(organizationId, locationId)
+	if err != nil {
+		return err
+	}
+	if location.IsDefault {
+		return errors.BadRequest("The default location cannot be deleted, promote another location first")
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.Delete(tx, location)
+	})
+}
+
+// AssignUsers replaces the users working at a location. Only users of the same
+// organization can be assigned.
+func (s *LocationService) AssignUsers(organizationId string, locationId string, userIds []uuid.UUID) (*entity.Location, error) {
+	location, err := s.GetLocationById(organizationId, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []entity.User
+	if len(userIds) > 0 {
+		if err := s.db.Where("id IN ? AND organization_id = ?", userIds, organizationId).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		if len(users) != len(userIds) {
+			return nil, errors.BadRequest("Some users do not belong to this organization")
+		}
+	}
+	if err := s.db.Model(location).Association("Users").Replace(users); err != nil {
+		return nil, err
+	}
+	return s.GetLocationById(organizationId, locationId)
+}
+
+func applyLocationRequest(location *entity.Location, dto types.LocationRequest) {
+	location.Name = dto.Name
+	location.AddressLine1 = dto.AddressLine1
+	location.AddressLine2 = dto.AddressLine2
+	location.City = dto.City
+	location.Province = dto.Province
+	location.PostalCode = dto.PostalCode
+	location.Country = dto.Country
+	location.PhoneNumber = dto.PhoneNumber
+	location.CallForwardingNumber = dto.CallForwardingNumber
+	location.TimeZone = dto.TimeZone
+}
+
+func replaceLocationRelations(tx *gorm.DB, location *entity.Location, dto types.LocationRequest) error {
+	specialities, err := findSpecialities(tx, dto.Specialities, dto.SpecialityCodes)
+	if err != nil {
+		return err
+	}
+	if err := tx.Model(location).Association("Specialities").Replace(specialities); err != nil {
+		return err
+	}
+
+	dailySchedules, err := createDailySchedules(tx, dto.DailySchedules)
+	if err != nil {
+		return err
+	}
+	return tx.Model(location).Association("DailySchedules").Replace(dailySchedules)
+}
+
+func clearDefaultLocation(tx *gorm.DB, organizationId uuid.UUID) error {
+	return tx.Model(&entity.Location{}).
+		Where("organization_id = ? AND is_default = ?", organizationId, true).
+		Update("is_default", false).Error
+}
+
+// ToLocationResponse maps a location entity to its API representation.
+func ToLocationResponse(location entity.Location) types.LocationResponse {
+	specialities := make([]int, 0, len(location.Specialities))
+	specialityCodes := make([]string, 0, len(location.Specialities))
+	for _, speciality := range location.Specialities {
+		specialities = append(specialities, speciality.ID)
+		specialityCodes = append(specialityCodes, speciality.Code)
+	}
+	userIds := make([]uuid.UUID, 0, len(location.Users))
+	for _, user := range location.Users {
+		userIds = append(userIds, user.ID)
+	}
+	return types.LocationResponse{
+		Id:                   location.ID,
+		OrganizationId:       location.OrganizationID,
+		Name:                 location.Name,
+		IsDefault:            location.IsDefault,
+		AddressLine1:         location.AddressLine1,
+		AddressLine2:         location.AddressLine2,
+		City:                 location.City,
+		Province:             location.Province,
+		PostalCode:           location.PostalCode,
+		Country:              location.Country,
+		PhoneNumber:          location.PhoneNumber,
+		CallForwardingNumber: location.CallForwardingNumber,
+		TimeZone:             location.TimeZone,
+		Specialities:         specialities,
+		SpecialityCodes:      specialityCodes,
+		DailySchedules:       toScheduleTypes(location.DailySchedules),
+		UserIds:              userIds,
+	}
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/notification"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// notificationBatchSize bounds the deliveries sent per worker pass.
+	notificationBatchSize = 50
+	// notificationLease is how long a claimed delivery is hidden from other workers.
+	notificationLease = 2 * time.Minute
+	// notificationSendTimeout bounds a single delivery attempt.
+	notificationSendTimeout = 30 * time.Second
+)
+
+// NotificationService resolves who gets notified on which channel, renders
+// the messages and delivers them in the background, retrying failures with an
+// exponential backoff. Every delivery is logged.
+type NotificationService struct {
+	db        *gorm.DB
+	dao       *repository.NotificationRepository
+	channels  map[entity.NotificationType]notification.Channel
+	templates *notification.Templates
+	broker    *notification.Broker
+	retry     retryPolicy
+	worker    *worker[entity.NotificationDelivery]
+}
+
+func NewNotificationService(db *gorm.DB, dao *repository.NotificationRepository, templates *notification.Templates, broker *notification.Broker, cfg config.NotificationConfig, channels ...notification.Channel) *NotificationService {
+	s := &NotificationService{
+		db:        db,
+		dao:       dao,
+		channels:  map[entity.NotificationType]notification.Channel{},
+		templates: templates,
+		broker:    broker,
+		retry:     newRetryPolicy(cfg.MaxAttempts, 5, time.Duration(cfg.BackoffSeconds)*time.Second),
+	}
+	s.worker = newWorker("notification deliveries", notificationBatchSize, notificationLease, time.Duration(cfg.PollIntervalSeconds)*time.Second, dao.ClaimDueDeliveries, s.deliver)
+	for _, channel := range channels {
+		s.channels[channel.Type()] = channel
+	}
+	return s
+}
+
+// Notify queues an event for the given users of an organization, or for all
+// of them when userIds is empty. Each user gets it on the channels enabled by
+// the organization, as overridden by their own preferences.
+func (s *NotificationService) Notify(organizationId uuid.UUID, event string, userIds []uuid.UUID, data map[string]interface{}) ([]entity.NotificationDelivery, error) {
+	if !s.templates.Has(event) {
+		return nil, fmt.Errorf("no template for event %q", event)
+	}
+	var organization entity.Organization
+	if err := s.db.First(&organization, "id = ?", organizationId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+
+	users := s.db.Where("organization_id = ?", organizationId)
+	if len(userIds) > 0 {
+		users = users.Where("id IN ?", userIds)
+	}
+	var recipients []entity.User
+	if err := users.Find(&recipients).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var deliveries []entity.NotificationDelivery
+	for _, user := range recipients {
+		preferences, err := s.dao.GetPreferences(user.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]interface{}{"Organization": organization.Name, "UserName": derefString(user.Name)}
+		for key, value := range data {
+			values[key] = value
+		}
+
+		userID := user.ID
+		for _, channel := range entity.NotificationTypes {
+			if !channelEnabled(organization.Notifications, preferences, event, channel) {
+				continue
+			}
+			subject, body, err := s.templates.Render(event, channel, values)
+			if err != nil {
+				return nil, err
+			}
+			delivery := entity.NotificationDelivery{
+				OrganizationID: organizationId,
+				UserID:         &userID,
+				Event:          event,
+				Channel:        channel,
+				Recipient:      recipientOf(user, channel),
+				Subject:        subject,
+				Body:           body,
+				Status:         entity.DeliveryPending,
+				NextAttemptAt:  &now,
+			}
+			switch {
+			case s.channels[channel] == nil:
+				delivery.Status = entity.DeliverySkipped
+				delivery.LastError = fmt.Sprintf("%s channel is not configured", channel)
+				delivery.NextAttemptAt = nil
+			case delivery.Recipient == "":
+				delivery.Status = entity.DeliverySkipped
+				delivery.LastError = fmt.Sprintf("user has no %s address", channel)
+				delivery.NextAttemptAt = nil
+			}
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	if err := s.dao.SaveDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	s.worker.wakeUp()
+	return deliveries, nil
+}
+
+// Run delivers the pending notifications until the context is cancelled.
+func (s *NotificationService) Run(ctx context.Context) {
+	s.worker.run(ctx)
+}
+
+func (s *NotificationService) deliver(ctx context.Context, delivery *entity.NotificationDelivery) {
+	delivery.Attempts++
+	err := s.send(ctx, *delivery)
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = entity.DeliverySent
+		delivery.SentAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	default:
+		delivery.NextAttemptAt = s.retry.nextAttempt(now, delivery.Attempts)
+		if delivery.NextAttemptAt == nil {
+			delivery.Status = entity.DeliveryFailed
+		}
+		delivery.LastError = err.Error()
+	}
+	if err != nil {
+		logger.Warn("Notification delivery failed:", delivery.ID, delivery.Channel, "attempt", delivery.Attempts, err)
+	}
+	if err := s.dao.UpdateDelivery(delivery); err != nil {
+		logger.Error("Failed to update notification delivery:", delivery.ID, err)
+	}
+}
+
+func (s *NotificationService) send(ctx context.Context, delivery entity.NotificationDelivery) error {
+	channel, ok := s.channels[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("%s channel is not configured", delivery.Channel)
+	}
+	ctx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+	defer cancel()
+	return channel.Send(ctx, notification.Message{
+		OrganizationID: delivery.OrganizationID,
+		UserID:         delivery.UserID,
+		Event:          delivery.Event,
+		Recipient:      delivery.Recipient,
+		Subject:        delivery.Subject,
+		Body:           delivery.Body,
+	})
+}
+
+// SendTest sends the test notification to a user, or to every user of the
+// organization.
+func (s *NotificationService) SendTest(organizationId string, userId *uuid.UUID) ([]types.NotificationDeliveryResponse, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	var userIds []uuid.UUID
+	if userId != nil {
+		userIds = []uuid.UUID{*userId}
+	}
+	deliveries, err := s.Notify(orgID, notification.EventTest, userIds, nil)
+	if err != nil {
+		return nil, err
+	}
+	if userId != nil && len(deliveries) == 0 {
+		return nil, errors.BadRequest("The user has every notification channel disabled or does not belong to this organization")
+	}
+	return toNotificationDeliveryResponses(deliveries), nil
+}
+
+func (s *NotificationService) GetDeliveries(organizationId string, status string, limit int, offset int) ([]types.NotificationDeliveryResponse, error) {
+	deliveries, err := s.dao.GetDeliveries(organizationId, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationDeliveryResponses(deliveries), nil
+}
+
+// GetPreferences returns the notification preferences of a user, as seen by
+// the user or an admin of their organization.
+func (s *NotificationService) GetPreferences(actor *entity.User, userId string) (*types.NotificationPreferencesResponse, error) {
+	var user entity.User
+	if err := s.db.Preload("Organization").First(&user, "id = ?", userId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("user not found")
+		}
+		return nil, err
+	}
+	if err := checkPreferencesAccess(actor, user); err != nil {
+		return nil, err
+	}
+	preferences, err := s.dao.GetPreferences(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.NotificationPreferencesResponse{Preferences: []types.NotificationPreference{}}
+	email := slices.Contains(user.Organization.Notifications, entity.Email)
+	sms := slices.Contains(user.Organization.Notifications, entity.SMS)
+	inApp := slices.Contains(user.Organization.Notifications, entity.InApp)
+	response.OrganizationDefaults = types.NotificationChannels{Email: &email, SMS: &sms, InApp: &inApp}
+	for _, preference := range preferences {
+		response.Preferences = append(response.Preferences, types.NotificationPreference{
+			Event:                preference.Event,
+			NotificationChannels: types.NotificationChannels{Email: preference.Email, SMS: preference.SMS, InApp: preference.InApp},
+		})
+	}
+	return response, nil
+}
+
+// UpdatePreferences replaces the notification preferences of a user, on
+// behalf of the user or an admin of their organization.
+func (s *NotificationService) UpdatePreferences(actor *entity.User, userId string, dto types.NotificationPreferencesRequest) (*types.NotificationPreferencesResponse, error) {
+	id, err := uuid.Parse(userId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid user ID")
+	}
+	var user entity.User
+	if err := s.db.First(&user, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("user not found")
+		}
+		return nil, err
+	}
+	if err := checkPreferencesAccess(actor, user); err != nil {
+		return nil, err
+	}
+
+	preferences := make([]entity.NotificationPreference, 0, len(dto.Preferences))
+	events := map[string]bool{}
+	for _, preference := range dto.Preferences {
+		if events[preference.Event] {
+			return nil, errors.BadRequest(fmt.Sprintf("Preferences for event %q are listed more than once", preference.Event))
+		}
+		if preference.Event != "" && !s.templates.Has(preference.Event) {
+			return nil, errors.BadRequest(fmt.Sprintf("Unknown notification event %q", preference.Event))
+		}
+		events[preference.Event] = true
+		preferences = append(preferences, entity.NotificationPreference{
+			UserID: id,
+			Event:  preference.Event,
+			Email:  preference.Email,
+			SMS:    preference.SMS,
+			InApp:  preference.InApp,
+		})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.dao.ReplacePreferences(tx, id, preferences)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(actor, userId)
+}
+
+// checkPreferencesAccess lets users manage their own notification
+// preferences, and the admins of their organization manage them too.
+func checkPreferencesAccess(actor *entity.User, user entity.User) error {
+	if actor.ID == user.ID || (isAdmin(actor) && actor.OrganizationID == user.OrganizationID) {
+		return nil
+	}
+	return errors.Forbidden("")
+}
+
+// GetInbox returns a page of the in-app notifications of a user, newest first.
+func (s *NotificationService) GetInbox(userId uuid.UUID, unreadOnly bool, limit int, offset int) (*types.NotificationPageResponse, error) {
+	notifications, total, err := s.dao.GetNotifications(userId.String(), unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.dao.CountUnread(userId.String())
+	if err != nil {
+		return nil, err
+	}
+	response := &types.NotificationPageResponse{
+		Items:       make([]types.NotificationResponse, 0, len(notifications)),
+		Total:       total,
+		UnreadCount: unread,
+		Limit:       limit,
+		Offset:      offset,
+	}
+	for _, item := range notifications {
+		response.Items = append(response.Items, ToNotificationResponse(item))
+	}
+	return response, nil
+}
+
+func (s *NotificationService) UnreadCount(userId uuid.UUID) (int64, error) {
+	return s.dao.CountUnread(userId.String())
+}
+
+// MarkRead marks one notification of a user as read.
+func (s *NotificationService) MarkRead(userId uuid.UUID, id string) (*types.NotificationResponse, error) {
+	item, err := s.dao.GetNotification(userId.String(), id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if item.ReadAt == nil {
+		now := time.Now()
+		item.ReadAt = &now
+		if err := s.dao.MarkRead(&item); err != nil {
+			return nil, err
+		}
+	}
+	response := ToNotificationResponse(item)
+	return &response, nil
+}
+
+// MarkAllRead marks every unread notification of a user as read and returns
+// how many were.
+func (s *NotificationService) MarkAllRead(userId uuid.UUID) (int64, error) {
+	return s.dao.MarkAllRead(userId.String(), time.Now())
+}
+
+// Subscribe streams the in-app notifications of a user as they arrive.
+func (s *NotificationService) Subscribe(userId uuid.UUID) (<-chan entity.Notification, func()) {
+	return s.broker.Subscribe(userId)
+}
+
+// channelEnabled layers the user preferences over the organization channels:
+// an event-specific preference wins over the user's general one, which wins
+// over the organization default.
+func channelEnabled(defaults []entity.NotificationType, preferences []entity.NotificationPreference, event string, channel entity.NotificationType) bool {
+	enabled := slices.Contains(defaults, channel)
+	for _, scope := range []string{"", event} {
+		for _, preference := range preferences {
+			if preference.Event != scope {
+				continue
+			}
+			if value, ok := preference.Enabled(channel); ok {
+				enabled = value
+			}
+		}
+	}
+	return enabled
+}
+
+func recipientOf(user entity.User, channel entity.NotificationType) string {
+	switch channel {
+	case entity.Email:
+		return derefString(user.Email)
+	case entity.SMS:
+		return derefString(user.PhoneNumber)
+	case entity.InApp:
+		return user.ID.String()
+	}
+	return ""
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func toNotificationDeliveryResponses(deliveries []entity.NotificationDelivery) []types.NotificationDeliveryResponse {
+	response := make([]types.NotificationDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, types.NotificationDeliveryResponse{
+			Id:            delivery.ID,
+			UserId:        delivery.UserID,
+			Event:         delivery.Event,
+			Channel:       delivery.Channel.String(),
+			Recipient:     delivery.Recipient,
+			Subject:       delivery.Subject,
+			Status:        string(delivery.Status),
+			Attempts:      delivery.Attempts,
+			LastError:     delivery.LastError,
+			NextAttemptAt: delivery.NextAttemptAt,
+			SentAt:        delivery.SentAt,
+			CreatedAt:     delivery.CreatedAt,
+		})
+	}
+	return response
+}
+
+// ToNotificationResponse maps an in-app notification to its API representation.
+func ToNotificationResponse(item entity.Notification) types.NotificationResponse {
+	return types.NotificationResponse{
+		Id:        item.ID,
+		Event:     item.Event,
+		Title:     item.Title,
+		Body:      item.Body,
+		Read:      item.ReadAt != nil,
+		ReadAt:    item.ReadAt,
+		CreatedAt: item.CreatedAt,
+	}
+}
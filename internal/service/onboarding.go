@@ -0,0 +1,164 @@
+package service
+
+import (
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/notification"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Keys of the onboarding steps.
+const (
+	OnboardingDetails      = "organization_details"
+	OnboardingLocations    = "locations"
+	OnboardingHours        = "opening_hours"
+	OnboardingSpecialities = "specialities"
+	OnboardingInsurances   = "insurances"
+	OnboardingForwarding   = "call_forwarding"
+	OnboardingAgentProfile = "agent_profile"
+	OnboardingStaff        = "staff"
+)
+
+// OnboardingService reports what an organization still has to configure
+// before its voice agent can answer calls.
+type OnboardingService struct {
+	db            *gorm.DB
+	notifications *NotificationService
+}
+
+func NewOnboardingService(db *gorm.DB, notifications *NotificationService) *OnboardingService {
+	return &OnboardingService{db: db, notifications: notifications}
+}
+
+func (s *OnboardingService) GetChecklist(organizationId string) (*types.OnboardingChecklistResponse, error) {
+	var organization entity.Organization
+	err := s.db.Preload("Specialities").Preload("DailySchedules").
+		Preload("Locations.DailySchedules").
+		First(&organization, "id = ?", organizationId).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NotFound("organization not found")
+		}
+		return nil, err
+	}
+
+	hasHours := len(organization.DailySchedules) > 0
+	hasAddress := false
+	hasForwarding := organization.CallForwardingNumber != ""
+	for _, location := range organization.Locations {
+		hasHours = hasHours || len(location.DailySchedules) > 0
+		hasAddress = hasAddress || (location.AddressLine1 != "" && location.City != "")
+		hasForwarding = hasForwarding || location.CallForwardingNumber != ""
+	}
+
+	count := func(model interface{}, query string, args ...interface{}) (int64, error) {
+		var n int64
+		err := s.db.Model(model).Where(query, args...).Count(&n).Error
+		return n, err
+	}
+	insurances, err := count(&entity.OrganizationInsurance{}, "organization_id = ?", organization.ID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := count(&entity.ForwardingRule{}, "organization_id = ? AND enabled", organization.ID)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := count(&entity.AgentProfile{}, "organization_id = ? AND location_id IS NULL AND published_version > 0", organization.ID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := count(&entity.User{}, "organization_id = ?", organization.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []types.OnboardingStep{
+		{
+			Key:   OnboardingDetails,
+			Title: "Fill in the organization name, email and phone number",
+			Done:  organization.Name != "" && organization.Email != "" && organization.PhoneNumber != "",
+		},
+		{
+			Key:   OnboardingLocations,
+			Title: "Add the address of your office",
+			Done:  hasAddress,
+		},
+		{
+			Key:   OnboardingHours,
+			Title: "Set your opening hours",
+			Done:  hasHours,
+		},
+		{
+			Key:   OnboardingSpecialities,
+			Title: "Choose the services you offer",
+			Done:  len(organization.Specialities) > 0,
+		},
+		{
+			Key:   OnboardingInsurances,
+			Title: "List the insurance carriers you accept",
+			Done:  insurances > 0,
+		},
+		{
+			Key:   OnboardingForwarding,
+			Title: "Set where calls are forwarded",
+			Done:  hasForwarding || rules > 0,
+		},
+		{
+			Key:    OnboardingAgentProfile,
+			Title:  "Configure the language, voice and scripts of your agent",
+			Done:   profiles > 0,
+			Detail: "The organization needs a published default agent profile; locations may override it",
+		},
+		{
+			Key:   OnboardingStaff,
+			Title: "Invite your staff",
+			Done:  users > 0,
+		},
+	}
+
+	checklist := &types.OnboardingChecklistResponse{
+		OrganizationId: organization.ID,
+		Total:          len(steps),
+		Steps:          steps,
+	}
+	for _, step := range steps {
+		if step.Done {
+			checklist.Completed++
+		}
+	}
+	checklist.Done = checklist.Completed == checklist.Total
+	return checklist, nil
+}
+
+// SendReminder notifies the users of an organization about the onboarding
+// steps left. Nothing is sent once onboarding is done.
+func (s *OnboardingService) SendReminder(organizationId string) ([]types.NotificationDeliveryResponse, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	checklist, err := s.GetChecklist(organizationId)
+	if err != nil {
+		return nil, err
+	}
+	if checklist.Done {
+		return nil, errors.BadRequest("Onboarding is already complete")
+	}
+
+	var remaining []string
+	for _, step := range checklist.Steps {
+		if !step.Done {
+			remaining = append(remaining, step.Title)
+		}
+	}
+	deliveries, err := s.notifications.Notify(orgID, notification.EventOnboarding, nil, map[string]interface{}{
+		"Remaining": remaining,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationDeliveryResponses(deliveries), nil
+}
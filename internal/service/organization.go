@@ -16,8 +16,17 @@ func NewOrganizationService(db *gorm.DB, dao *repository.OrganizationRepository)
 	return &OrganizationService{db: db, dao: dao}
 }
 
+// SaveOrganization creates the organization together with its default location.
 func (s *OrganizationService) SaveOrganization(tx *gorm.DB, organization *entity.Organization) error {
-	return s.dao.Save(tx, organization)
+	if err := s.dao.Save(tx, organization); err != nil {
+		return err
+	}
+	location := entity.Location{
+		OrganizationID: organization.ID,
+		Name:           entity.DefaultLocationName,
+		IsDefault:      true,
+	}
+	return tx.Create(&location).Error
 }
 
 func (s *OrganizationService) GetOrganizationById(organizationId string) (*entity.Organization, error) {
@@ -44,30 +53,26 @@ func (s *OrganizationService) UpdateOrganization(id string, dto types.Organizati
 	organization.InsuranceCompany = dto.InsuranceCompany
 	organization.CallForwardingNumber = dto.CallForwardingNumber
 	organization.Notifications = dto.Notifications
+	if dto.TimeZone != "" {
+		organization.TimeZone = dto.TimeZone
+	}
+	if dto.AgentApprovalRequired != nil {
+		organization.AgentApprovalRequired = *dto.AgentApprovalRequired
+	}
 
 	// Fetch and Assign Specialities
-	var specialities []entity.Speciality
-	if len(dto.Specialities) > 0 {
-		if err := s.db.Where("id IN ?", dto.Specialities).Find(&specialities).Error; err != nil {
-			return nil, err
-		}
+	specialities, err := findSpecialities(s.db, dto.Specialities, dto.SpecialityCodes)
+	if err != nil {
+		return nil, err
 	}
 	if err := s.db.Model(&organization).Association("Specialities").Replace(specialities); err != nil {
 		return nil, err
 	}
 
 	// Create and Assign DailySchedules
-	var dailySchedules []entity.DailySchedule
-	for _, schedule := range dto.DailySchedules {
-		newSchedule := entity.DailySchedule{
-			DayOfWeek: schedule.DayOfWeek,
-			FromTime:  schedule.FromTime,
-			ToTime:    schedule.ToTime,
-		}
-		if err := s.db.Create(&newSchedule).Error; err != nil {
-			return nil, err
-		}
-		dailySchedules = append(dailySchedules, newSchedule)
+	dailySchedules, err := createDailySchedules(s.db, dto.DailySchedules)
+	if err != nil {
+		return nil, err
 	}
 	if err := s.db.Model(&organization).Association("DailySchedules").Replace(dailySchedules); err != nil {
 		return nil, err
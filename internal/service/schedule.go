@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"gorm.io/gorm"
+)
+
+// createDailySchedules persists the given schedules so they can be attached to
+// an organization, location or user through their many2many association.
+// Ranges of the same day that touch are merged first.
+func createDailySchedules(tx *gorm.DB, schedules []types.DailySchedule) ([]entity.DailySchedule, error) {
+	var dailySchedules []entity.DailySchedule
+	for _, schedule := range mergeDailySchedules(schedules) {
+		newSchedule := entity.DailySchedule{
+			DayOfWeek: int(schedule.DayOfWeek),
+			FromTime:  schedule.FromTime,
+			ToTime:    schedule.ToTime,
+		}
+		if err := tx.Create(&newSchedule).Error; err != nil {
+			return nil, err
+		}
+		dailySchedules = append(dailySchedules, newSchedule)
+	}
+	return dailySchedules, nil
+}
+
+func toScheduleTypes(schedules []entity.DailySchedule) []types.DailySchedule {
+	result := make([]types.DailySchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, types.DailySchedule{
+			DayOfWeek: types.Weekday(schedule.DayOfWeek),
+			FromTime:  schedule.FromTime,
+			ToTime:    schedule.ToTime,
+		})
+	}
+	return result
+}
+
+// mergeDailySchedules sorts schedules by day and start time and joins the
+// ranges of a day that touch or overlap, keeping split shifts apart.
+func mergeDailySchedules(schedules []types.DailySchedule) []types.DailySchedule {
+	sorted := make([]types.DailySchedule, len(schedules))
+	copy(sorted, schedules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].DayOfWeek != sorted[j].DayOfWeek {
+			return sorted[i].DayOfWeek < sorted[j].DayOfWeek
+		}
+		return sorted[i].FromTime < sorted[j].FromTime
+	})
+
+	var merged []types.DailySchedule
+	for _, schedule := range sorted {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if last.DayOfWeek == schedule.DayOfWeek && schedule.FromTime <= last.ToTime {
+				if schedule.ToTime > last.ToTime {
+					last.ToTime = schedule.ToTime
+				}
+				continue
+			}
+		}
+		merged = append(merged, schedule)
+	}
+	return merged
+}
+
+// findSpecialities loads the specialities matching the given ids or codes.
+// Codes are stable across environments and preferred by integrations.
+func findSpecialities(tx *gorm.DB, ids []int, codes []string) ([]entity.Speciality, error) {
+	var specialities []entity.Speciality
+	if len(ids) == 0 && len(codes) == 0 {
+		return specialities, nil
+	}
+	// the placeholders keep both lists valid SQL when one of them is empty
+	if err := tx.Where("id IN ? OR code IN ?", append(slices.Clone(ids), 0), append(slices.Clone(codes), "")).Find(&specialities).Error; err != nil {
+		return nil, err
+	}
+	if len(codes) > 0 {
+		found := make(map[string]bool, len(specialities))
+		for _, speciality := range specialities {
+			found[speciality.Code] = true
+		}
+		for _, code := range codes {
+			if !found[code] {
+				return nil, errors.BadRequest(fmt.Sprintf("unknown speciality code %q", code))
+			}
+		}
+	}
+	return specialities, nil
+}
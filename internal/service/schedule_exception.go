@@ -0,0 +1,239 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxCalendarDays bounds the calendar preview range.
+const maxCalendarDays = 366
+
+type ScheduleExceptionService struct {
+	db           *gorm.DB
+	dao          *repository.ScheduleExceptionRepository
+	availability *AvailabilityService
+}
+
+func NewScheduleExceptionService(db *gorm.DB, dao *repository.ScheduleExceptionRepository, availability *AvailabilityService) *ScheduleExceptionService {
+	return &ScheduleExceptionService{db: db, dao: dao, availability: availability}
+}
+
+func (s *ScheduleExceptionService) GetExceptions(organizationId string, locationId string, userId string, from time.Time, to time.Time) ([]entity.ScheduleException, error) {
+	return s.dao.Find(organizationId, locationId, userId, from, to)
+}
+
+func (s *ScheduleExceptionService) CreateException(organizationId string, dto types.ScheduleExceptionRequest) (*entity.ScheduleException, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+
+	exception := entity.ScheduleException{OrganizationID: orgID}
+	if err := s.applyExceptionRequest(&exception, dto); err != nil {
+		return nil, err
+	}
+	if err := s.dao.Save(s.db, &exception); err != nil {
+		return nil, err
+	}
+	return &exception, nil
+}
+
+func (s *ScheduleExceptionService) UpdateException(organizationId string, id string, dto types.ScheduleExceptionRequest) (*entity.ScheduleException, error) {
+	exception, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+	if err := s.applyExceptionRequest(&exception, dto); err != nil {
+		return nil, err
+	}
+	if err := s.dao.Update(&exception); err != nil {
+		return nil, err
+	}
+	return &exception, nil
+}
+
+func (s *ScheduleExceptionService) DeleteException(organizationId string, id string) error {
+	exception, err := s.dao.GetById(organizationId, id)
+	if err != nil {
+		return errors.NotFound(err.Error())
+	}
+	return s.dao.Delete(&exception)
+}
+
+// ImportHolidays closes the organization, or one of its locations, on every
+// statutory holiday of a province for the given year. Holidays already
+// imported are skipped so the import can be repeated safely.
+func (s *ScheduleExceptionService) ImportHolidays(organizationId string, dto types.HolidayImportRequest) (*types.HolidayImportResponse, error) {
+	orgID, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid organization ID")
+	}
+	if dto.LocationId != nil {
+		if err := s.checkLocation(organizationId, dto.LocationId.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	holidays, err := HolidaysFor(dto.Year, dto.Province)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	catalog, err := loadHolidayCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.HolidayImportResponse{
+		Created:     []types.ScheduleExceptionResponse{},
+		Province:    dto.Province,
+		Year:        dto.Year,
+		DataVersion: catalog.Version,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, holiday := range holidays {
+			exception := entity.ScheduleException{
+				OrganizationID: orgID,
+				LocationID:     dto.LocationId,
+				Date:           holiday.Date,
+				Closed:         true,
+				Reason:         holiday.Name,
+				HolidayCode:    holiday.Code,
+			}
+			exists, err := s.dao.ExistsHoliday(tx, exception)
+			if err != nil {
+				return err
+			}
+			if exists {
+				response.Skipped++
+				continue
+			}
+			if err := s.dao.Save(tx, &exception); err != nil {
+				return err
+			}
+			response.Created = append(response.Created, ToScheduleExceptionResponse(exception))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Calendar previews the effective schedule of every date in a range, with
+// exceptions applied over the weekly hours.
+func (s *ScheduleExceptionService) Calendar(organizationId string, locationId string, userId string, from time.Time, to time.Time) (*types.CalendarResponse, error) {
+	if to.Before(from) {
+		return nil, errors.BadRequest("to must not be before from")
+	}
+	if to.Sub(from) > maxCalendarDays*24*time.Hour {
+		return nil, errors.BadRequest(fmt.Sprintf("The calendar range cannot exceed %d days", maxCalendarDays))
+	}
+
+	hours, err := s.availability.OpeningHours(organizationId, locationId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.CalendarResponse{TimeZone: hours.Location.String(), Days: []types.CalendarDay{}}
+	if id, err := uuid.Parse(locationId); err == nil {
+		response.LocationId = &id
+	}
+	if id, err := uuid.Parse(userId); err == nil {
+		response.UserId = &id
+	}
+	for _, day := range hours.Calendar(from, to) {
+		calendarDay := types.CalendarDay{
+			Date:    day.Date,
+			Weekday: day.Weekday.String(),
+			Closed:  day.Closed,
+			Hours:   []types.TimeRange{},
+			Source:  day.Source,
+			Reason:  day.Reason,
+		}
+		for _, interval := range day.Intervals {
+			toTime := interval.End.In(hours.Location).Format("15:04")
+			if toTime == "00:00" {
+				toTime = "24:00"
+			}
+			calendarDay.Hours = append(calendarDay.Hours, types.TimeRange{
+				FromTime: interval.Start.In(hours.Location).Format("15:04"),
+				ToTime:   toTime,
+			})
+		}
+		response.Days = append(response.Days, calendarDay)
+	}
+	return response, nil
+}
+
+func (s *ScheduleExceptionService) applyExceptionRequest(exception *entity.ScheduleException, dto types.ScheduleExceptionRequest) error {
+	date, err := time.Parse(time.DateOnly, dto.Date)
+	if err != nil {
+		return errors.BadRequest("Invalid date, expected YYYY-MM-DD")
+	}
+	if dto.LocationId != nil && dto.UserId != nil {
+		return errors.BadRequest("An exception applies either to a location or to a user, not both")
+	}
+
+	organizationId := exception.OrganizationID.String()
+	if dto.LocationId != nil {
+		if err := s.checkLocation(organizationId, dto.LocationId.String()); err != nil {
+			return err
+		}
+	}
+	if dto.UserId != nil {
+		var count int64
+		if err := s.db.Model(&entity.User{}).Where("id = ? AND organization_id = ?", *dto.UserId, organizationId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.NotFound("user not found")
+		}
+	}
+
+	exception.Date = date
+	exception.Closed = dto.Closed
+	exception.FromTime = ""
+	exception.ToTime = ""
+	if !dto.Closed {
+		exception.FromTime = dto.FromTime
+		exception.ToTime = dto.ToTime
+	}
+	exception.Reason = dto.Reason
+	exception.LocationID = dto.LocationId
+	exception.UserID = dto.UserId
+	return nil
+}
+
+func (s *ScheduleExceptionService) checkLocation(organizationId string, locationId string) error {
+	var count int64
+	if err := s.db.Model(&entity.Location{}).Where("id = ? AND organization_id = ?", locationId, organizationId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.NotFound("location not found")
+	}
+	return nil
+}
+
+// ToScheduleExceptionResponse maps a schedule exception to its API representation.
+func ToScheduleExceptionResponse(exception entity.ScheduleException) types.ScheduleExceptionResponse {
+	return types.ScheduleExceptionResponse{
+		Id:          exception.ID,
+		Date:        exception.Date.Format(time.DateOnly),
+		Closed:      exception.Closed,
+		FromTime:    exception.FromTime,
+		ToTime:      exception.ToTime,
+		Reason:      exception.Reason,
+		HolidayCode: exception.HolidayCode,
+		LocationId:  exception.LocationID,
+		UserId:      exception.UserID,
+	}
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+)
+
+type SpecialityService struct {
+	dao *repository.SpecialityRepository
+}
+
+func NewSpecialityService(dao *repository.SpecialityRepository) *SpecialityService {
+	return &SpecialityService{dao: dao}
+}
+
+// GetTree returns the speciality taxonomy as a tree. When searching, the
+// ancestors of every match are kept so the tree stays connected.
+func (s *SpecialityService) GetTree(search string, includeRetired bool) ([]types.SpecialityResponse, error) {
+	specialities, err := s.dao.GetAll(includeRetired)
+	if err != nil {
+		return nil, err
+	}
+	byID, children := indexSpecialities(specialities)
+	visible := matchSpecialities(specialities, byID, search)
+
+	var build func(parentID *int) []types.SpecialityResponse
+	build = func(parentID *int) []types.SpecialityResponse {
+		nodes := []types.SpecialityResponse{}
+		for _, speciality := range children[specialityKey(parentID)] {
+			if !visible[speciality.ID] {
+				continue
+			}
+			id := speciality.ID
+			node := toSpecialityResponse(speciality)
+			node.Children = build(&id)
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(nil), nil
+}
+
+// GetFlat returns the matching specialities as a flat list, each with its
+// full path such as "General Dentistry > Dental Cleaning".
+func (s *SpecialityService) GetFlat(search string, includeRetired bool) ([]types.SpecialityResponse, error) {
+	specialities, err := s.dao.GetAll(includeRetired)
+	if err != nil {
+		return nil, err
+	}
+	byID, _ := indexSpecialities(specialities)
+
+	result := []types.SpecialityResponse{}
+	for _, speciality := range specialities {
+		if search != "" && !strings.Contains(strings.ToLower(speciality.Name), strings.ToLower(search)) {
+			continue
+		}
+		response := toSpecialityResponse(speciality)
+		response.Path = specialityPath(speciality, byID)
+		result = append(result, response)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+func (s *SpecialityService) CreateSpeciality(dto types.SpecialityRequest) (*types.SpecialityResponse, error) {
+	code, err := s.specialityCode(dto)
+	if err != nil {
+		return nil, err
+	}
+	speciality := entity.Speciality{Code: code, Name: strings.TrimSpace(dto.Name), ParentID: parentID(dto.ParentId)}
+	if err := s.dao.Save(&speciality); err != nil {
+		return nil, err
+	}
+	response := toSpecialityResponse(speciality)
+	return &response, nil
+}
+
+// specialityCode returns the requested code, or derives one from the parent
+// code and the name, e.g. "general_dentistry.teeth_whitening".
+func (s *SpecialityService) specialityCode(dto types.SpecialityRequest) (string, error) {
+	var parentCode string
+	if parentId := parentID(dto.ParentId); parentId != nil {
+		parent, err := s.dao.GetById(*parentId)
+		if err != nil {
+			return "", errors.BadRequest("parent speciality not found")
+		}
+		parentCode = parent.Code
+	}
+
+	code := strings.TrimSpace(dto.Code)
+	if code == "" {
+		code = slugify(dto.Name)
+		if parentCode != "" {
+			code = parentCode + "." + code
+		}
+	}
+
+	candidate := code
+	for i := 2; ; i++ {
+		exists, err := s.dao.ExistsCode(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		if dto.Code != "" {
+			return "", errors.BadRequest("a speciality with this code already exists")
+		}
+		candidate = fmt.Sprintf("%s_%d", code, i)
+	}
+}
+
+// slugify lowercases a name and joins its words with underscores.
+func slugify(name string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words = append(words, word)
+	}
+	return strings.Join(words, "_")
+}
+
+// UpdateSpeciality renames, reparents, retires or restores a speciality.
+// Retiring it retires its descendants too, as RetireSpeciality does, while
+// restoring it only restores the speciality itself. Organizations keep
+// referencing it by id, so none of these changes break them.
+func (s *SpecialityService) UpdateSpeciality(id int, dto types.SpecialityRequest) (*types.SpecialityResponse, error) {
+	speciality, err := s.dao.GetById(id)
+	if err != nil {
+		return nil, errors.NotFound(err.Error())
+	}
+
+	if parentID(dto.ParentId) != nil {
+		all, err := s.dao.GetAll(true)
+		if err != nil {
+			return nil, err
+		}
+		byID, _ := indexSpecialities(all)
+		if _, ok := byID[*dto.ParentId]; !ok {
+			return nil, errors.BadRequest("parent speciality not found")
+		}
+		for parent := dto.ParentId; parent != nil; parent = byID[*parent].ParentID {
+			if *parent == id {
+				return nil, errors.BadRequest("a speciality cannot be moved under itself or one of its descendants")
+			}
+		}
+	}
+
+	applySpecialityRequest(&speciality, dto)
+	if err := s.dao.Update(&speciality); err != nil {
+		return nil, err
+	}
+	if dto.Retired != nil && *dto.Retired && !speciality.Retired {
+		if err := s.RetireSpeciality(id); err != nil {
+			return nil, err
+		}
+		speciality.Retired = true
+	}
+	response := toSpecialityResponse(speciality)
+	return &response, nil
+}
+
+// applySpecialityRequest changes a speciality as requested, leaving the parent
+// and the retired flag alone when omitted. Retiring is left to
+// RetireSpeciality, which retires the descendants too.
+func applySpecialityRequest(speciality *entity.Speciality, dto types.SpecialityRequest) {
+	speciality.Name = strings.TrimSpace(dto.Name)
+	if dto.ParentId != nil {
+		speciality.ParentID = parentID(dto.ParentId)
+	}
+	if dto.Retired != nil && !*dto.Retired {
+		speciality.Retired = false
+	}
+}
+
+// parentID returns the requested parent, or nil for a root speciality,
+// requested with a parent id of 0.
+func parentID(requested *int) *int {
+	if requested == nil || *requested == 0 {
+		return nil
+	}
+	return requested
+}
+
+// RetireSpeciality hides a speciality and its descendants from the pickers.
+// They are not deleted so existing organizations keep their selection.
+func (s *SpecialityService) RetireSpeciality(id int) error {
+	if _, err := s.dao.GetById(id); err != nil {
+		return errors.NotFound(err.Error())
+	}
+	all, err := s.dao.GetAll(true)
+	if err != nil {
+		return err
+	}
+	_, children := indexSpecialities(all)
+
+	ids := []int{id}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			ids = append(ids, child.ID)
+		}
+	}
+	return s.dao.Retire(ids)
+}
+
+// indexSpecialities indexes specialities by id and by parent id, roots being
+// stored under the key 0.
+func indexSpecialities(specialities []entity.Speciality) (map[int]entity.Speciality, map[int][]entity.Speciality) {
+	byID := make(map[int]entity.Speciality, len(specialities))
+	children := make(map[int][]entity.Speciality)
+	for _, speciality := range specialities {
+		byID[speciality.ID] = speciality
+	}
+	for _, speciality := range specialities {
+		key := specialityKey(speciality.ParentID)
+		if _, ok := byID[key]; key != 0 && !ok {
+			// the parent is retired and filtered out, show the child as a root
+			key = 0
+		}
+		children[key] = append(children[key], speciality)
+	}
+	return byID, children
+}
+
+func specialityKey(parentID *int) int {
+	if parentID == nil {
+		return 0
+	}
+	return *parentID
+}
+
+// matchSpecialities returns the ids of specialities matching the search and
+// of all their ancestors.
+func matchSpecialities(specialities []entity.Speciality, byID map[int]entity.Speciality, search string) map[int]bool {
+	visible := make(map[int]bool, len(specialities))
+	search = strings.ToLower(strings.TrimSpace(search))
+	for _, speciality := range specialities {
+		if search != "" && !strings.Contains(strings.ToLower(speciality.Name), search) {
+			continue
+		}
+		for current, ok := speciality, true; ok; current, ok = byID[specialityKey(current.ParentID)] {
+			visible[current.ID] = true
+			if current.ParentID == nil {
+				break
+			}
+		}
+	}
+	return visible
+}
+
+func specialityPath(speciality entity.Speciality, byID map[int]entity.Speciality) string {
+	names := []string{speciality.Name}
+	for parentID := speciality.ParentID; parentID != nil; {
+		parent, ok := byID[*parentID]
+		if !ok {
+			break
+		}
+		names = append([]string{parent.Name}, names...)
+		parentID = parent.ParentID
+	}
+	return strings.Join(names, " > ")
+}
+
+func toSpecialityResponse(speciality entity.Speciality) types.SpecialityResponse {
+	return types.SpecialityResponse{
+		Id:       speciality.ID,
+		Code:     speciality.Code,
+		Name:     speciality.Name,
+		ParentId: speciality.ParentID,
+		Retired:  speciality.Retired,
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
+)
+
+func TestApplySpecialityRequest(t *testing.T) {
+	parent, other, root := 1, 7, 0
+	retired, restored := true, false
+
+	tests := []struct {
+		name        string
+		dto         types.SpecialityRequest
+		wantParent  *int
+		wantRetired bool
+	}{
+		{"rename keeps the parent", types.SpecialityRequest{Name: " Teeth whitening "}, &parent, true},
+		{"retire keeps the parent", types.SpecialityRequest{Name: "Teeth whitening", Retired: &retired}, &parent, true},
+		{"restore keeps the parent", types.SpecialityRequest{Name: "Teeth whitening", Retired: &restored}, &parent, false},
+		{"move", types.SpecialityRequest{Name: "Teeth whitening", ParentId: &other}, &other, true},
+		{"move to the root", types.SpecialityRequest{Name: "Teeth whitening", ParentId: &root}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			speciality := entity.Speciality{Code: "cosmetic.teeth_whitening", Name: "Whitening", ParentID: &parent, Retired: true}
+			applySpecialityRequest(&speciality, tt.dto)
+
+			if speciality.Name != "Teeth whitening" {
+				t.Errorf("name = %q, want %q", speciality.Name, "Teeth whitening")
+			}
+			switch {
+			case tt.wantParent == nil && speciality.ParentID != nil:
+				t.Errorf("parent = %d, want none", *speciality.ParentID)
+			case tt.wantParent != nil && (speciality.ParentID == nil || *speciality.ParentID != *tt.wantParent):
+				t.Errorf("parent = %v, want %d", speciality.ParentID, *tt.wantParent)
+			}
+			// retiring is left to RetireSpeciality
+			if speciality.Retired != tt.wantRetired {
+				t.Errorf("retired = %v, want %v", speciality.Retired, tt.wantRetired)
+			}
+		})
+	}
+}
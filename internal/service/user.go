@@ -2,16 +2,19 @@ package service
 
 import (
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/repository"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/types"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
+	db  *gorm.DB
 	dao *repository.UserRepository
 }
 
-func NewUserService(dao *repository.UserRepository) *UserService {
-	return &UserService{dao: dao}
+func NewUserService(db *gorm.DB, dao *repository.UserRepository) *UserService {
+	return &UserService{db: db, dao: dao}
 }
 
 func (s *UserService) SaveUser(tx *gorm.DB, user *entity.User) error {
@@ -21,3 +24,42 @@ func (s *UserService) SaveUser(tx *gorm.DB, user *entity.User) error {
 func (s *UserService) GetUserById(id string) (entity.User, error) {
 	return s.dao.GetById(id)
 }
+
+func (s *UserService) GetUserByEmail(email string) (entity.User, error) {
+	return s.dao.GetByEmail(email)
+}
+
+// UpdateSchedules replaces the weekly schedule of a staff member on behalf of
+// actor.
+func (s *UserService) UpdateSchedules(actor *entity.User, id string, schedules []types.DailySchedule) ([]types.DailySchedule, error) {
+	user, err := s.dao.GetById(id)
+	if err != nil {
+		return nil, errors.NotFound("user not found")
+	}
+	if err := checkScheduleAccess(actor, user); err != nil {
+		return nil, err
+	}
+
+	var dailySchedules []entity.DailySchedule
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		dailySchedules, err = createDailySchedules(tx, schedules)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&user).Association("DailySchedules").Replace(dailySchedules)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toScheduleTypes(dailySchedules), nil
+}
+
+// checkScheduleAccess lets users change their own schedule, and the admins of
+// their organization change it too. Availability, forwarding and callback due
+// times all follow it.
+func checkScheduleAccess(actor *entity.User, user entity.User) error {
+	if actor.ID == user.ID || (isAdmin(actor) && actor.OrganizationID == user.OrganizationID) {
+		return nil
+	}
+	return errors.Forbidden("Only the user or an Admin of their organization can change their schedule")
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/errors"
+	"github.com/google/uuid"
+)
+
+func TestCheckScheduleAccess(t *testing.T) {
+	clinic, other := uuid.New(), uuid.New()
+	user := func(organizationId uuid.UUID, role entity.UserRole) *entity.User {
+		u := &entity.User{OrganizationID: organizationId, Role: &role}
+		u.ID = uuid.New()
+		return u
+	}
+	staff := user(clinic, entity.Staff)
+
+	tests := []struct {
+		name    string
+		actor   *entity.User
+		allowed bool
+	}{
+		{"the user", staff, true},
+		{"admin of the organization", user(clinic, entity.Admin), true},
+		{"staff of the organization", user(clinic, entity.Staff), false},
+		{"support of the organization", user(clinic, entity.Support), false},
+		{"admin of another organization", user(other, entity.Admin), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkScheduleAccess(tt.actor, *staff)
+			if tt.allowed {
+				if err != nil {
+					t.Errorf("checkScheduleAccess() = %v, want nil", err)
+				}
+				return
+			}
+			response, ok := err.(errors.ErrorResponse)
+			if !ok || response.Status != http.StatusForbidden {
+				t.Errorf("checkScheduleAccess() = %v, want a 403", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/logger"
+)
+
+const (
+	// defaultPollInterval is how often a worker looks for due jobs when its
+	// service does not configure it.
+	defaultPollInterval = 5 * time.Second
+	// defaultRetryBackoff is the delay before the second attempt of a job when
+	// its service does not configure it.
+	defaultRetryBackoff = 30 * time.Second
+	// maxRetryBackoff caps the delay between two attempts of a job.
+	maxRetryBackoff = time.Hour
+)
+
+// worker runs the queue of a service in the background. It claims the due
+// jobs in batches, hiding them from other workers for the lease, and hands
+// them one by one to process. It looks for due jobs every poll interval, or
+// right away when woken up.
+type worker[T any] struct {
+	name         string
+	batchSize    int
+	lease        time.Duration
+	pollInterval time.Duration
+	claim        func(now time.Time, lease time.Duration, limit int) ([]T, error)
+	process      func(ctx context.Context, job *T)
+	wake         chan struct{}
+}
+
+// newWorker creates a worker claiming its jobs with claim. name tells what the
+// jobs are in the logs.
+func newWorker[T any](name string, batchSize int, lease time.Duration, pollInterval time.Duration, claim func(now time.Time, lease time.Duration, limit int) ([]T, error), process func(ctx context.Context, job *T)) *worker[T] {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	return &worker[T]{
+		name:         name,
+		batchSize:    batchSize,
+		lease:        lease,
+		pollInterval: pollInterval,
+		claim:        claim,
+		process:      process,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// run processes the due jobs until the context is cancelled.
+func (w *worker[T]) run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		for ctx.Err() == nil && w.processDue(ctx) == w.batchSize {
+			// a full batch means more jobs are probably due
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// processDue processes one batch of due jobs and returns its size.
+func (w *worker[T]) processDue(ctx context.Context) int {
+	jobs, err := w.claim(time.Now(), w.lease, w.batchSize)
+	if err != nil {
+		logger.Error("Failed to claim "+w.name+":", err)
+		return 0
+	}
+	for i := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		w.process(ctx, &jobs[i])
+	}
+	return len(jobs)
+}
+
+// wakeUp makes the worker look for due jobs without waiting for the next
+// poll, e.g. after queueing one.
+func (w *worker[T]) wakeUp() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// retryPolicy spaces the attempts of a failing job with an exponential
+// backoff, and gives the job up after maxAttempts.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// newRetryPolicy creates a retryPolicy. defaultAttempts is used when
+// maxAttempts is not set.
+func newRetryPolicy(maxAttempts int, defaultAttempts int, backoff time.Duration) retryPolicy {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	return retryPolicy{maxAttempts: maxAttempts, backoff: backoff}
+}
+
+// nextAttempt returns when to try a job again after its attempts-th attempt
+// failed, or nil when the job is given up.
+func (p retryPolicy) nextAttempt(now time.Time, attempts int) *time.Time {
+	if attempts >= p.maxAttempts {
+		return nil
+	}
+	next := now.Add(p.retryDelay(attempts))
+	return &next
+}
+
+// retryDelay doubles the backoff after every failed attempt.
+func (p retryPolicy) retryDelay(attempts int) time.Duration {
+	delay := p.backoff
+	for i := 1; i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
@@ -0,0 +1,75 @@
+// Package textmatch compares the loose phrasing of callers with reference
+// names, such as "sunlife" with "Sun Life Financial".
+package textmatch
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize lowercases a text and strips its accents and punctuation, so
+// "Financière Sun-Life" becomes "financiere sun life".
+func Normalize(text string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		stripped = text
+	}
+	return strings.Join(strings.FieldsFunc(strings.ToLower(stripped), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Tokens returns the normalized words of a text, without the given stop words.
+func Tokens(text string, stopWords map[string]bool) []string {
+	var tokens []string
+	for _, token := range strings.Fields(Normalize(text)) {
+		if !stopWords[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// Similarity returns the edit similarity of two strings, from 0 for
+// completely different strings to 1 for equal ones. Swapping two adjacent
+// letters, a common typo, counts as a single edit.
+func Similarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance is the optimal string alignment distance of two strings.
+func editDistance(a []rune, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
@@ -0,0 +1,138 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/script"
+	"github.com/google/uuid"
+)
+
+// AgentProfileRequest creates or updates an agent profile. Without location,
+// the profile is the organization default. Language is the default language;
+// the agent also speaks the languages of the translations, whose scripts may
+// stay incomplete until the profile is published.
+type AgentProfileRequest struct {
+	LocationId     *uuid.UUID           `json:"location_id"`
+	Language       string               `json:"language" validate:"required,oneof=english french spanish" example:"english"`
+	VoiceType      string               `json:"voice_type" validate:"required,oneof=professional friendly casual" example:"friendly"`
+	InitialScript  string               `json:"initial_script" validate:"required,max=5000,script" example:"Thank you for calling {{organization_name}}, how can I help you?"`
+	ClosingScript  string               `json:"closing_script" validate:"required,max=5000,script"`
+	DetectionHints []string             `json:"detection_hints" validate:"max=20,dive,min=1,max=50"`
+	Translations   []AgentScriptRequest `json:"translations" validate:"max=2,dive"`
+}
+
+// AgentScriptRequest holds the scripts of an agent profile in one more
+// language, and words telling that a caller speaks it.
+type AgentScriptRequest struct {
+	Language       string   `json:"language" validate:"required,oneof=english french spanish" example:"french"`
+	InitialScript  string   `json:"initial_script" validate:"max=5000,script" example:"Merci d'avoir appelé {{organization_name}}, comment puis-je vous aider?"`
+	ClosingScript  string   `json:"closing_script" validate:"max=5000,script"`
+	DetectionHints []string `json:"detection_hints" validate:"max=20,dive,min=1,max=50"`
+}
+
+type AgentScriptResponse struct {
+	Language       string   `json:"language"`
+	InitialScript  string   `json:"initial_script"`
+	ClosingScript  string   `json:"closing_script"`
+	DetectionHints []string `json:"detection_hints"`
+	Complete       bool     `json:"complete"`
+}
+
+type AgentProfileResponse struct {
+	Id             uuid.UUID             `json:"id"`
+	OrganizationId uuid.UUID             `json:"organization_id"`
+	LocationId     *uuid.UUID            `json:"location_id,omitempty"`
+	Language       string                `json:"language"`
+	Languages      []string              `json:"languages"`
+	VoiceType      string                `json:"voice_type"`
+	InitialScript  string                `json:"initial_script"`
+	ClosingScript  string                `json:"closing_script"`
+	DetectionHints []string              `json:"detection_hints"`
+	Translations   []AgentScriptResponse `json:"translations"`
+	// PublishedVersion is the version the agent uses, 0 while never published
+	PublishedVersion int       `json:"published_version"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// PublishRequest submits the draft of an agent profile for publication.
+type PublishRequest struct {
+	Note string `json:"note" validate:"max=500" example:"Holiday hours"`
+}
+
+// ReviewRequest approves or rejects a version awaiting approval.
+type ReviewRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
+
+type AgentProfileVersionResponse struct {
+	Version        int                   `json:"version"`
+	Status         string                `json:"status"`
+	Language       string                `json:"language"`
+	Languages      []string              `json:"languages"`
+	VoiceType      string                `json:"voice_type"`
+	InitialScript  string                `json:"initial_script"`
+	ClosingScript  string                `json:"closing_script"`
+	DetectionHints []string              `json:"detection_hints"`
+	Translations   []AgentScriptResponse `json:"translations"`
+	Note           string                `json:"note,omitempty"`
+	RolledBackFrom *int                  `json:"rolled_back_from,omitempty"`
+	SubmittedBy    *uuid.UUID            `json:"submitted_by,omitempty"`
+	ReviewedBy     *uuid.UUID            `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time            `json:"reviewed_at,omitempty"`
+	PublishedAt    *time.Time            `json:"published_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// FieldChange is a setting that differs between two versions.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// AgentProfileDiffResponse compares two versions of an agent profile. A
+// version of 0 stands for the draft. The scripts of the default language come
+// first in Languages.
+type AgentProfileDiffResponse struct {
+	From      int            `json:"from"`
+	To        int            `json:"to"`
+	Changed   bool           `json:"changed"`
+	Changes   []FieldChange  `json:"changes"`
+	Languages []LanguageDiff `json:"languages"`
+}
+
+// LanguageDiff compares the scripts of one language. A language only in the
+// newer version is compared with empty scripts, and the other way around.
+type LanguageDiff struct {
+	Language      string         `json:"language"`
+	InitialScript []script.Chunk `json:"initial_script"`
+	ClosingScript []script.Chunk `json:"closing_script"`
+}
+
+// ScriptPreviewRequest renders scripts as the agent would say them. Scripts
+// left empty are taken from the agent profile of the location.
+type ScriptPreviewRequest struct {
+	LocationId    *uuid.UUID `json:"location_id"`
+	Language      string     `json:"language" validate:"omitempty,oneof=english french spanish" example:"french"`
+	At            *time.Time `json:"at" example:"2025-03-03T14:30:00-05:00"`
+	InitialScript string     `json:"initial_script" validate:"max=5000"`
+	ClosingScript string     `json:"closing_script" validate:"max=5000"`
+}
+
+// ScriptPreviewResponse is the rendered scripts. Fallback is set when the
+// profile has no script in the requested language, so the scripts of its
+// default language were used.
+type ScriptPreviewResponse struct {
+	Language         string            `json:"language"`
+	Fallback         bool              `json:"fallback"`
+	At               time.Time         `json:"at"`
+	InitialScript    string            `json:"initial_script"`
+	ClosingScript    string            `json:"closing_script"`
+	Variables        map[string]string `json:"variables"`
+	UnknownVariables []string          `json:"unknown_variables"`
+}
+
+type ScriptVariableResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sync statuses reported besides the stored ones.
+const (
+	SyncStatusDisabled  = "disabled"
+	SyncStatusNotSynced = "not_synced"
+)
+
+// AgentSyncResponse reports whether the voice platform runs the latest agent
+// configuration of an organization.
+type AgentSyncResponse struct {
+	OrganizationId  uuid.UUID  `json:"organization_id"`
+	Platform        string     `json:"platform,omitempty"`
+	AssistantId     string     `json:"assistant_id,omitempty"`
+	Status          string     `json:"status" example:"synced"`
+	Checksum        string     `json:"checksum,omitempty"`
+	CurrentChecksum string     `json:"current_checksum,omitempty"`
+	UpToDate        bool       `json:"up_to_date"`
+	Attempts        int        `json:"attempts"`
+	LastError       string     `json:"last_error,omitempty"`
+	NextAttemptAt   *time.Time `json:"next_attempt_at,omitempty"`
+	RequestedAt     *time.Time `json:"requested_at,omitempty"`
+	SyncedAt        *time.Time `json:"synced_at,omitempty"`
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsFilter selects the calls of a range of local dates, both included,
+// in the time zone of the location or of the organization.
+type AnalyticsFilter struct {
+	From       string     `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string     `json:"to" validate:"omitempty,datetime=2006-01-02"`
+	LocationId *uuid.UUID `json:"location_id"`
+}
+
+// CallSummaryResponse sums up the calls of a range. Rates and shares are
+// fractions of the calls, from 0 to 1.
+type CallSummaryResponse struct {
+	TimeZone        string               `json:"time_zone" example:"America/Toronto"`
+	From            string               `json:"from" example:"2026-01-01"`
+	To              string               `json:"to" example:"2026-01-31"`
+	Calls           int                  `json:"calls"`
+	Completed       int                  `json:"completed"`
+	Missed          int                  `json:"missed"`
+	Forwarded       int                  `json:"forwarded"`
+	Voicemail       int                  `json:"voicemail"`
+	Transferred     int                  `json:"transferred"`
+	TransferRate    float64              `json:"transfer_rate"`
+	AfterHours      int                  `json:"after_hours"`
+	AfterHoursShare float64              `json:"after_hours_share"`
+	NewCallers      int                  `json:"new_callers"`
+	ReturningCalls  int                  `json:"returning_calls"`
+	Duration        CallDurationResponse `json:"duration"`
+}
+
+// CallDurationResponse describes the durations of calls in seconds.
+// Percentiles are estimated from a histogram.
+type CallDurationResponse struct {
+	Average float64 `json:"average"`
+	P50     int     `json:"p50"`
+	P90     int     `json:"p90"`
+	P95     int     `json:"p95"`
+	Max     int     `json:"max"`
+}
+
+type CallVolumeResponse struct {
+	TimeZone string             `json:"time_zone" example:"America/Toronto"`
+	Interval string             `json:"interval" example:"day"`
+	Buckets  []CallVolumeBucket `json:"buckets"`
+}
+
+// CallVolumeBucket counts the calls of a local hour, day or week.
+type CallVolumeBucket struct {
+	Start           time.Time `json:"start"`
+	Calls           int       `json:"calls"`
+	Completed       int       `json:"completed"`
+	Missed          int       `json:"missed"`
+	Forwarded       int       `json:"forwarded"`
+	Voicemail       int       `json:"voicemail"`
+	Transferred     int       `json:"transferred"`
+	AfterHours      int       `json:"after_hours"`
+	AverageDuration float64   `json:"average_duration"`
+}
+
+type TopTagsResponse struct {
+	TimeZone string             `json:"time_zone" example:"America/Toronto"`
+	Tags     []TagCountResponse `json:"tags"`
+}
+
+// TagCountResponse is the number of calls with a tag and their share of all
+// the calls of the range.
+type TagCountResponse struct {
+	Tag   string  `json:"tag"`
+	Calls int     `json:"calls"`
+	Share float64 `json:"share"`
+}
+
+// CallDispositionsResponse counts the calls of a range per disposition.
+// Undisposed counts the calls with none.
+type CallDispositionsResponse struct {
+	TimeZone     string                     `json:"time_zone" example:"America/Toronto"`
+	Dispositions []DispositionCountResponse `json:"dispositions"`
+	Undisposed   int                        `json:"undisposed"`
+}
+
+// DispositionCountResponse is the number of calls with a disposition and
+// their share of all the calls of the range.
+type DispositionCountResponse struct {
+	Code  string  `json:"code" example:"booked"`
+	Label string  `json:"label" example:"Booked"`
+	Calls int     `json:"calls"`
+	Share float64 `json:"share"`
+}
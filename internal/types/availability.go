@@ -0,0 +1,20 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvailabilityResponse tells whether a clinic (or a staff member) is open at
+// a given instant and when that changes next.
+type AvailabilityResponse struct {
+	At         time.Time  `json:"at"`
+	TimeZone   string     `json:"time_zone"`
+	LocalTime  string     `json:"local_time"`
+	Open       bool       `json:"open"`
+	NextOpen   *time.Time `json:"next_open,omitempty"`
+	NextClose  *time.Time `json:"next_close,omitempty"`
+	LocationId *uuid.UUID `json:"location_id,omitempty"`
+	UserId     *uuid.UUID `json:"user_id,omitempty"`
+}
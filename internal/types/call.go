@@ -0,0 +1,179 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of a call webhook.
+const (
+	WebhookAccepted  = "accepted"
+	WebhookDuplicate = "duplicate"
+	WebhookIgnored   = "ignored"
+)
+
+// VoiceWebhookRequest is a report of the voice platform, in the Vapi server
+// message format. Only end-of-call reports are processed.
+type VoiceWebhookRequest struct {
+	Message VoiceWebhookMessage `json:"message"`
+}
+
+type VoiceWebhookMessage struct {
+	Type string `json:"type" example:"end-of-call-report"`
+	// Timestamp is when the platform sent the report, in Unix milliseconds
+	Timestamp            int64                `json:"timestamp"`
+	Call                 VoiceWebhookCall     `json:"call"`
+	EndedReason          string               `json:"endedReason" example:"customer-ended-call"`
+	StartedAt            *time.Time           `json:"startedAt"`
+	EndedAt              *time.Time           `json:"endedAt"`
+	DurationSeconds      float64              `json:"durationSeconds"`
+	Summary              string               `json:"summary"`
+	Analysis             VoiceWebhookAnalysis `json:"analysis"`
+	Artifact             VoiceWebhookArtifact `json:"artifact"`
+	ForwardedPhoneNumber string               `json:"forwardedPhoneNumber"`
+	Customer             VoiceWebhookNumber   `json:"customer"`
+	PhoneNumber          VoiceWebhookNumber   `json:"phoneNumber"`
+}
+
+type VoiceWebhookCall struct {
+	Id          string             `json:"id"`
+	Customer    VoiceWebhookNumber `json:"customer"`
+	PhoneNumber VoiceWebhookNumber `json:"phoneNumber"`
+	StartedAt   *time.Time         `json:"startedAt"`
+	EndedAt     *time.Time         `json:"endedAt"`
+}
+
+type VoiceWebhookNumber struct {
+	Number string `json:"number" example:"+15145550123"`
+}
+
+type VoiceWebhookAnalysis struct {
+	Summary string `json:"summary"`
+}
+
+type VoiceWebhookArtifact struct {
+	Messages []VoiceWebhookTurn `json:"messages"`
+}
+
+// VoiceWebhookTurn is a message of the conversation. Roles other than "bot",
+// "assistant" and "user", such as system or tool messages, are not part of
+// the transcript.
+type VoiceWebhookTurn struct {
+	Role             string  `json:"role" example:"user"`
+	Message          string  `json:"message"`
+	SecondsFromStart float64 `json:"secondsFromStart"`
+}
+
+type VoiceWebhookResponse struct {
+	Status string `json:"status" example:"accepted"`
+}
+
+// WebhookSecretResponse is a new secret for signing call reports. It is only
+// shown once.
+type WebhookSecretResponse struct {
+	Secret          string `json:"secret"`
+	SignatureHeader string `json:"signature_header" example:"X-Comvoca-Signature"`
+	TimestampHeader string `json:"timestamp_header" example:"X-Comvoca-Timestamp"`
+}
+
+// CallCriteria selects calls. Tags must all be set on a call, while any of
+// Statuses and any of Dispositions match.
+type CallCriteria struct {
+	From         *time.Time `json:"from"`
+	To           *time.Time `json:"to"`
+	Caller       string     `json:"caller" validate:"max=30"`
+	MinDuration  *int       `json:"min_duration" validate:"omitempty,min=0"`
+	MaxDuration  *int       `json:"max_duration" validate:"omitempty,min=0"`
+	Transferred  *bool      `json:"transferred"`
+	Tags         []string   `json:"tags" validate:"max=10,dive,min=1,max=50"`
+	Statuses     []string   `json:"statuses" validate:"dive,oneof=completed missed forwarded voicemail"`
+	Dispositions []string   `json:"dispositions" validate:"max=20,dive,min=1,max=50"`
+	LocationId   *uuid.UUID `json:"location_id"`
+	ContactId    *uuid.UUID `json:"contact_id"`
+}
+
+// CallFilter selects and sorts calls. Cursor continues a previous page with
+// the same sort.
+type CallFilter struct {
+	CallCriteria
+	Sort   string `json:"sort" validate:"oneof=started_at duration"`
+	Order  string `json:"order" validate:"oneof=asc desc"`
+	Limit  int    `json:"limit" validate:"min=1,max=100"`
+	Cursor string `json:"cursor"`
+}
+
+// CallSearchFilter searches the transcripts of the calls matching criteria.
+// Query is in the web search syntax: words, "quoted phrases", or and -word.
+type CallSearchFilter struct {
+	CallCriteria
+	Query  string `json:"q" validate:"required,max=200"`
+	Limit  int    `json:"limit" validate:"min=1,max=50"`
+	Offset int    `json:"offset" validate:"min=0"`
+}
+
+type CallResponse struct {
+	Id              uuid.UUID  `json:"id"`
+	LocationId      *uuid.UUID `json:"location_id,omitempty"`
+	ExternalCallId  string     `json:"external_call_id"`
+	CallerNumber    string     `json:"caller_number"`
+	ContactId       *uuid.UUID `json:"contact_id,omitempty"`
+	ContactName     string     `json:"contact_name,omitempty"`
+	CalledNumber    string     `json:"called_number"`
+	Status          string     `json:"status"`
+	EndedReason     string     `json:"ended_reason"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds int        `json:"duration_seconds"`
+	Summary         string     `json:"summary"`
+	TransferredTo   string     `json:"transferred_to,omitempty"`
+	AfterHours      bool       `json:"after_hours"`
+	Disposition     string     `json:"disposition,omitempty" example:"booked"`
+	Tags            []string   `json:"tags"`
+}
+
+// CallDetailResponse is a call with its full transcript and the notes of the
+// staff, oldest first.
+type CallDetailResponse struct {
+	CallResponse
+	Transcript []TranscriptTurnResponse `json:"transcript"`
+	Notes      []CallNoteResponse       `json:"notes"`
+}
+
+type TranscriptTurnResponse struct {
+	Speaker string `json:"speaker" example:"caller"`
+	Text    string `json:"text"`
+	// OffsetMillis is the time elapsed since the call started
+	OffsetMillis int64 `json:"offset_millis"`
+}
+
+// CallPageResponse is a page of calls. NextCursor is empty on the last page.
+type CallPageResponse struct {
+	Calls      []CallResponse `json:"calls"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// CallSearchResponse is a page of the calls whose transcript matches a
+// search, most relevant first.
+type CallSearchResponse struct {
+	Results []CallSearchResult `json:"results"`
+	Total   int64              `json:"total"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+}
+
+type CallSearchResult struct {
+	Call    CallResponse              `json:"call"`
+	Rank    float64                   `json:"rank"`
+	Matches []TranscriptMatchResponse `json:"matches"`
+}
+
+// TranscriptMatchResponse is a turn matching a search. Snippet is an excerpt
+// of the turn with the matching words wrapped in <mark> tags.
+type TranscriptMatchResponse struct {
+	Position int    `json:"position"`
+	Speaker  string `json:"speaker" example:"caller"`
+	Snippet  string `json:"snippet" example:"Do you offer <mark>Invisalign</mark>?"`
+	// OffsetMillis is the time elapsed since the call started
+	OffsetMillis int64 `json:"offset_millis"`
+}
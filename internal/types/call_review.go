@@ -0,0 +1,70 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallDispositionRequest creates or updates a disposition. The code of a
+// disposition cannot change once created.
+type CallDispositionRequest struct {
+	Code     string `json:"code" validate:"required,max=50" example:"callback_needed"`
+	Label    string `json:"label" validate:"required,max=100" example:"Callback needed"`
+	Position int    `json:"position" validate:"min=0"`
+	Archived bool   `json:"archived"`
+}
+
+type CallDispositionResponse struct {
+	Id       uuid.UUID `json:"id"`
+	Code     string    `json:"code" example:"callback_needed"`
+	Label    string    `json:"label" example:"Callback needed"`
+	Position int       `json:"position"`
+	Archived bool      `json:"archived"`
+}
+
+type CallTagRequest struct {
+	Name string `json:"name" validate:"required,max=50" example:"invisalign"`
+}
+
+type CallTagResponse struct {
+	Id   uuid.UUID `json:"id"`
+	Name string    `json:"name" example:"invisalign"`
+}
+
+// SetCallDispositionRequest sets the disposition of a call, or clears it when
+// Code is empty.
+type SetCallDispositionRequest struct {
+	Code string `json:"code" validate:"max=50" example:"booked"`
+}
+
+// SetCallTagsRequest replaces the tags of a call.
+type SetCallTagsRequest struct {
+	Tags []string `json:"tags" validate:"max=10,dive,required,max=50"`
+}
+
+type CallNoteRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+type CallNoteResponse struct {
+	Id         uuid.UUID  `json:"id"`
+	AuthorId   uuid.UUID  `json:"author_id"`
+	AuthorName string     `json:"author_name"`
+	Body       string     `json:"body"`
+	CreatedAt  time.Time  `json:"created_at"`
+	EditedAt   *time.Time `json:"edited_at,omitempty"`
+}
+
+// CallChangeResponse is an edit of a call by a staff member. Tags are
+// comma-separated.
+type CallChangeResponse struct {
+	Id        uuid.UUID  `json:"id"`
+	UserId    uuid.UUID  `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Field     string     `json:"field" example:"disposition"`
+	NoteId    *uuid.UUID `json:"note_id,omitempty"`
+	Previous  string     `json:"previous"`
+	Current   string     `json:"current"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallbackRuleRequest creates or updates a callback rule. Empty lists match
+// any call.
+type CallbackRuleRequest struct {
+	Name        string     `json:"name" validate:"required,max=100" example:"Missed call"`
+	Position    int        `json:"position" validate:"min=0"`
+	Enabled     bool       `json:"enabled"`
+	Statuses    []string   `json:"statuses" validate:"max=4,dive,oneof=completed missed forwarded voicemail" example:"missed,voicemail"`
+	EndReasons  []string   `json:"end_reasons" validate:"max=20,dive,required,max=100"`
+	Keywords    []string   `json:"keywords" validate:"max=50,dive,required,max=100" example:"call me back"`
+	AfterHours  *bool      `json:"after_hours"`
+	Transferred *bool      `json:"transferred"`
+	Reason      string     `json:"reason" validate:"max=500" example:"The call could not be completed"`
+	DueMinutes  int        `json:"due_minutes" validate:"required,min=1,max=10080" example:"60"`
+	AssigneeId  *uuid.UUID `json:"assignee_id"`
+}
+
+type CallbackRuleResponse struct {
+	Id          uuid.UUID  `json:"id"`
+	Name        string     `json:"name" example:"Missed call"`
+	Position    int        `json:"position"`
+	Enabled     bool       `json:"enabled"`
+	Statuses    []string   `json:"statuses" example:"missed,voicemail"`
+	EndReasons  []string   `json:"end_reasons"`
+	Keywords    []string   `json:"keywords" example:"call me back"`
+	AfterHours  *bool      `json:"after_hours,omitempty"`
+	Transferred *bool      `json:"transferred,omitempty"`
+	Reason      string     `json:"reason"`
+	DueMinutes  int        `json:"due_minutes" example:"60"`
+	AssigneeId  *uuid.UUID `json:"assignee_id,omitempty"`
+}
+
+// CallbackFilter selects callback tasks. Assignee is "me", "unassigned" or
+// the id of a user.
+type CallbackFilter struct {
+	Statuses []string `json:"status" validate:"dive,oneof=open in_progress completed cancelled"`
+	Assignee string   `json:"assignee"`
+	Overdue  bool     `json:"overdue"`
+	Limit    int      `json:"limit" validate:"min=1,max=100"`
+	Offset   int      `json:"offset" validate:"min=0"`
+}
+
+// AssignCallbackRequest assigns a task to a user of its organization, or
+// unassigns it when AssigneeId is null.
+type AssignCallbackRequest struct {
+	AssigneeId *uuid.UUID `json:"assignee_id"`
+}
+
+// CallbackStatusRequest moves a task to another status. Resolution is kept
+// when the task is completed or cancelled.
+type CallbackStatusRequest struct {
+	Status     string `json:"status" validate:"required,oneof=open in_progress completed cancelled" example:"completed"`
+	Resolution string `json:"resolution" validate:"max=2000" example:"Booked a cleaning on Monday"`
+}
+
+// CallbackTaskResponse is a callback task. Overdue tells whether it is still
+// active past its due time, and Breached whether it ever was.
+type CallbackTaskResponse struct {
+	Id            uuid.UUID  `json:"id"`
+	CallId        uuid.UUID  `json:"call_id"`
+	LocationId    *uuid.UUID `json:"location_id,omitempty"`
+	RuleId        *uuid.UUID `json:"rule_id,omitempty"`
+	CallerNumber  string     `json:"caller_number" example:"+15145550123"`
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status" example:"open"`
+	AssigneeId    *uuid.UUID `json:"assignee_id,omitempty"`
+	AssigneeName  string     `json:"assignee_name,omitempty"`
+	DueAt         time.Time  `json:"due_at"`
+	Overdue       bool       `json:"overdue"`
+	Breached      bool       `json:"breached"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	CompletedById *uuid.UUID `json:"completed_by_id,omitempty"`
+	Resolution    string     `json:"resolution,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type CallbackPageResponse struct {
+	Items  []CallbackTaskResponse `json:"items"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AgentKeyResponse is a new API key of the voice agent of an organization.
+// It is only shown once.
+type AgentKeyResponse struct {
+	Key    string `json:"key"`
+	Header string `json:"header" example:"X-Api-Key"`
+}
+
+// CallerLookupResponse is what is known about a caller when a call starts.
+// Known tells whether the number called before. Complete is false when part
+// of it could not be loaded in time, in which case the missing parts are
+// empty.
+type CallerLookupResponse struct {
+	Known             bool                     `json:"known"`
+	Complete          bool                     `json:"complete"`
+	ContactId         *uuid.UUID               `json:"contact_id,omitempty"`
+	Name              string                   `json:"name,omitempty" example:"Jane Doe"`
+	PreferredLanguage string                   `json:"preferred_language,omitempty" example:"french"`
+	VIP               bool                     `json:"vip"`
+	Blocked           bool                     `json:"blocked"`
+	RecentCalls       []CallerCallResponse     `json:"recent_calls"`
+	OpenCallbacks     []CallerCallbackResponse `json:"open_callbacks"`
+}
+
+// CallerCallResponse is a past call of a caller.
+type CallerCallResponse struct {
+	StartedAt time.Time `json:"started_at"`
+	Status    string    `json:"status" example:"completed"`
+	Summary   string    `json:"summary"`
+}
+
+// CallerCallbackResponse is a callback the staff still owe a caller.
+type CallerCallbackResponse struct {
+	Reason  string    `json:"reason"`
+	Status  string    `json:"status" example:"open"`
+	DueAt   time.Time `json:"due_at"`
+	Overdue bool      `json:"overdue"`
+}
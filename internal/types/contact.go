@@ -0,0 +1,66 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContactFilter selects contacts whose name or one of whose numbers contains
+// Query.
+type ContactFilter struct {
+	Query  string `json:"q" validate:"max=100"`
+	Limit  int    `json:"limit" validate:"min=1,max=100"`
+	Offset int    `json:"offset" validate:"min=0"`
+}
+
+// ContactRequest updates what the staff know about a contact. An empty
+// PreferredLanguage means it is unknown.
+type ContactRequest struct {
+	Name              string `json:"name" validate:"max=100" example:"Jane Doe"`
+	Notes             string `json:"notes" validate:"max=5000"`
+	PreferredLanguage string `json:"preferred_language" validate:"omitempty,oneof=english french spanish" example:"french"`
+	VIP               bool   `json:"vip"`
+	Blocked           bool   `json:"blocked"`
+}
+
+// MergeContactsRequest merges contacts into another one.
+type MergeContactsRequest struct {
+	ContactIds []uuid.UUID `json:"contact_ids" validate:"required,min=1,max=20"`
+}
+
+// SplitContactRequest moves numbers of a contact, and their calls, to a new
+// contact.
+type SplitContactRequest struct {
+	PhoneNumbers []string `json:"phone_numbers" validate:"required,min=1,max=20,dive,required,max=30" example:"+15145550123"`
+	Name         string   `json:"name" validate:"max=100"`
+}
+
+// ContactResponse is a caller with how many times and when they called.
+type ContactResponse struct {
+	Id                uuid.UUID  `json:"id"`
+	Name              string     `json:"name" example:"Jane Doe"`
+	Notes             string     `json:"notes"`
+	PreferredLanguage string     `json:"preferred_language,omitempty" example:"french"`
+	VIP               bool       `json:"vip"`
+	Blocked           bool       `json:"blocked"`
+	PhoneNumbers      []string   `json:"phone_numbers" example:"+15145550123"`
+	Calls             int        `json:"calls"`
+	FirstCallAt       *time.Time `json:"first_call_at,omitempty"`
+	LastCallAt        *time.Time `json:"last_call_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// ContactDetailResponse is a contact with their latest calls, newest first.
+// Older calls are listed by the call history filtered by contact.
+type ContactDetailResponse struct {
+	ContactResponse
+	Timeline []CallResponse `json:"timeline"`
+}
+
+type ContactPageResponse struct {
+	Items  []ContactResponse `json:"items"`
+	Total  int64             `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
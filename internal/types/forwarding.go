@@ -0,0 +1,75 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ForwardingRuleRequest struct {
+	Name           string     `json:"name" validate:"required,max=255"`
+	LocationId     *uuid.UUID `json:"location_id"`
+	Enabled        *bool      `json:"enabled"`
+	Intents        []string   `json:"intents" validate:"dive,oneof=emergency billing new_patient existing_patient appointment general"`
+	Schedule       string     `json:"schedule" validate:"omitempty,oneof=any open closed"`
+	Weekdays       []Weekday  `json:"weekdays" validate:"dive,weekday" swaggertype:"array,string"`
+	FromTime       string     `json:"from_time" validate:"required_with=ToTime,omitempty,clock" example:"08:00"`
+	ToTime         string     `json:"to_time" validate:"required_with=FromTime,omitempty,clock,clockafter=FromTime" example:"12:00"`
+	Action         string     `json:"action" validate:"required,oneof=forward voicemail"`
+	TargetNumber   string     `json:"target_number" validate:"omitempty,e164" example:"+15145550123"`
+	TargetUserId   *uuid.UUID `json:"target_user_id"`
+	FallbackNumber string     `json:"fallback_number" validate:"omitempty,e164" example:"+15145550199"`
+}
+
+type ForwardingRuleResponse struct {
+	Id             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Priority       int        `json:"priority"`
+	LocationId     *uuid.UUID `json:"location_id,omitempty"`
+	Enabled        bool       `json:"enabled"`
+	Intents        []string   `json:"intents"`
+	Schedule       string     `json:"schedule"`
+	Weekdays       []string   `json:"weekdays"`
+	FromTime       string     `json:"from_time,omitempty"`
+	ToTime         string     `json:"to_time,omitempty"`
+	Action         string     `json:"action"`
+	TargetNumber   string     `json:"target_number,omitempty"`
+	TargetUserId   *uuid.UUID `json:"target_user_id,omitempty"`
+	FallbackNumber string     `json:"fallback_number,omitempty"`
+}
+
+// ForwardingOrderRequest lists every rule id of the organization in the new
+// evaluation order.
+type ForwardingOrderRequest struct {
+	RuleIds []uuid.UUID `json:"rule_ids" validate:"required"`
+}
+
+// ForwardingCall describes a call to route. At defaults to now.
+type ForwardingCall struct {
+	At         *time.Time `json:"at"`
+	Intent     string     `json:"intent" validate:"omitempty,oneof=emergency billing new_patient existing_patient appointment general"`
+	LocationId *uuid.UUID `json:"location_id"`
+}
+
+// ForwardingStep explains why a rule did or did not route the call.
+type ForwardingStep struct {
+	RuleId  *uuid.UUID `json:"rule_id,omitempty"`
+	Name    string     `json:"name"`
+	Matched bool       `json:"matched"`
+	Reason  string     `json:"reason"`
+}
+
+// ForwardingDecision tells the voice agent where to transfer a call. Number
+// is empty for voicemail. Source is "rule" or the fallback used when no rule
+// matched: "location", "organization" or "voicemail".
+type ForwardingDecision struct {
+	Action   string           `json:"action"`
+	Number   string           `json:"number,omitempty"`
+	UserId   *uuid.UUID       `json:"user_id,omitempty"`
+	RuleId   *uuid.UUID       `json:"rule_id,omitempty"`
+	RuleName string           `json:"rule_name,omitempty"`
+	Source   string           `json:"source"`
+	Reason   string           `json:"reason"`
+	At       time.Time        `json:"at"`
+	Steps    []ForwardingStep `json:"steps,omitempty"`
+}
@@ -0,0 +1,79 @@
+package types
+
+import "github.com/google/uuid"
+
+// Answers of an insurance match.
+const (
+	InsuranceAccepted    = "accepted"
+	InsuranceNotAccepted = "not_accepted"
+	InsuranceUnknown     = "unknown"
+	InsuranceNoMatch     = "no_match"
+)
+
+type InsuranceCarrierResponse struct {
+	Id        int      `json:"id"`
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	PlanTypes []string `json:"plan_types"`
+	Retired   bool     `json:"retired"`
+}
+
+// InsuranceCarrierRequest creates or updates a carrier of the catalog. Code
+// can only be set on creation and is generated from the name when omitted.
+type InsuranceCarrierRequest struct {
+	Code      string   `json:"code" validate:"omitempty,max=100"`
+	Name      string   `json:"name" validate:"required,max=255"`
+	Aliases   []string `json:"aliases" validate:"dive,required,max=255"`
+	PlanTypes []string `json:"plan_types" validate:"dive,required,max=50"`
+	Retired   bool     `json:"retired"`
+}
+
+// OrganizationInsuranceRequest references a carrier by id or by code.
+type OrganizationInsuranceRequest struct {
+	CarrierId   *int     `json:"carrier_id" validate:"required_without=CarrierCode"`
+	CarrierCode string   `json:"carrier_code" validate:"omitempty,max=100"`
+	Accepted    bool     `json:"accepted"`
+	PlanTypes   []string `json:"plan_types"`
+	Notes       string   `json:"notes" validate:"max=500"`
+}
+
+// OrganizationInsurancesRequest replaces the carriers of an organization, or
+// of one of its locations when LocationId is set.
+type OrganizationInsurancesRequest struct {
+	LocationId *uuid.UUID                     `json:"location_id"`
+	Insurances []OrganizationInsuranceRequest `json:"insurances" validate:"dive"`
+}
+
+// OrganizationInsuranceResponse is a carrier of an organization. Scope tells
+// whether it is set at the organization or the location level.
+type OrganizationInsuranceResponse struct {
+	Id         uuid.UUID                `json:"id"`
+	Carrier    InsuranceCarrierResponse `json:"carrier"`
+	LocationId *uuid.UUID               `json:"location_id,omitempty"`
+	Scope      string                   `json:"scope"`
+	Accepted   bool                     `json:"accepted"`
+	PlanTypes  []string                 `json:"plan_types"`
+	Notes      string                   `json:"notes"`
+}
+
+// InsuranceMatch is a carrier matching the caller's phrasing. Accepted is nil
+// when the organization did not say whether it takes the carrier.
+type InsuranceMatch struct {
+	Carrier   InsuranceCarrierResponse `json:"carrier"`
+	Score     float64                  `json:"score"`
+	Accepted  *bool                    `json:"accepted"`
+	Scope     string                   `json:"scope,omitempty"`
+	PlanTypes []string                 `json:"plan_types"`
+	Notes     string                   `json:"notes,omitempty"`
+}
+
+// InsuranceMatchResponse answers "do you take X?". Answer comes from the best
+// match; Ambiguous is set when other matches score as well but answer
+// differently, e.g. for "Blue Cross" with several provincial plans.
+type InsuranceMatchResponse struct {
+	Query     string           `json:"query"`
+	Answer    string           `json:"answer"`
+	Ambiguous bool             `json:"ambiguous"`
+	Matches   []InsuranceMatch `json:"matches"`
+}
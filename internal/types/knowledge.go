@@ -0,0 +1,69 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeEntryRequest creates or updates a knowledge base entry, with its
+// text in one or more languages.
+type KnowledgeEntryRequest struct {
+	Kind     string                    `json:"kind" validate:"required,oneof=faq article" example:"faq"`
+	Category string                    `json:"category" validate:"max=100" example:"Parking"`
+	Enabled  *bool                     `json:"enabled"`
+	Variants []KnowledgeVariantRequest `json:"variants" validate:"required,min=1,max=3,dive"`
+}
+
+// KnowledgeVariantRequest is the text of an entry in one language. For a
+// question and answer, Title is the question and Body the answer.
+type KnowledgeVariantRequest struct {
+	Language string   `json:"language" validate:"required,oneof=english french spanish" example:"english"`
+	Title    string   `json:"title" validate:"required,max=500" example:"Where can I park?"`
+	Body     string   `json:"body" validate:"required,max=20000" example:"Free parking is available behind the building."`
+	Keywords []string `json:"keywords" validate:"max=20,dive,min=1,max=50"`
+}
+
+type KnowledgeEntryResponse struct {
+	Id        uuid.UUID                  `json:"id"`
+	Kind      string                     `json:"kind"`
+	Category  string                     `json:"category"`
+	Enabled   bool                       `json:"enabled"`
+	Position  int                        `json:"position"`
+	Variants  []KnowledgeVariantResponse `json:"variants"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+type KnowledgeVariantResponse struct {
+	Language string   `json:"language"`
+	Title    string   `json:"title"`
+	Body     string   `json:"body"`
+	Keywords []string `json:"keywords"`
+}
+
+// KnowledgeOrderRequest lists every entry id of the organization in the new
+// order.
+type KnowledgeOrderRequest struct {
+	EntryIds []uuid.UUID `json:"entry_ids" validate:"required"`
+}
+
+// KnowledgeSearchResponse lists the entries matching a query, most relevant
+// first.
+type KnowledgeSearchResponse struct {
+	Query    string                  `json:"query"`
+	Language string                  `json:"language,omitempty"`
+	Results  []KnowledgeSearchResult `json:"results"`
+}
+
+// KnowledgeSearchResult is an entry in the requested language. Fallback is
+// set when the entry has no text in that language, so another one was used.
+type KnowledgeSearchResult struct {
+	EntryId  uuid.UUID `json:"entry_id"`
+	Kind     string    `json:"kind"`
+	Category string    `json:"category"`
+	Language string    `json:"language"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body"`
+	Score    float64   `json:"score"`
+	Fallback bool      `json:"fallback"`
+}
@@ -0,0 +1,44 @@
+package types
+
+import "github.com/google/uuid"
+
+type LocationRequest struct {
+	Name                 string          `json:"name" validate:"required"`
+	IsDefault            bool            `json:"is_default"`
+	AddressLine1         string          `json:"address_line1"`
+	AddressLine2         string          `json:"address_line2"`
+	City                 string          `json:"city"`
+	Province             string          `json:"province"`
+	PostalCode           string          `json:"postal_code"`
+	Country              string          `json:"country"`
+	PhoneNumber          string          `json:"phone_number"`
+	CallForwardingNumber string          `json:"call_forwarding_number"`
+	TimeZone             string          `json:"time_zone" validate:"omitempty,timezone"`
+	Specialities         []int           `json:"specialities"`
+	SpecialityCodes      []string        `json:"speciality_codes"`
+	DailySchedules       []DailySchedule `json:"daily_schedules" validate:"nooverlap,dive"`
+}
+
+type LocationResponse struct {
+	Id                   uuid.UUID       `json:"id"`
+	OrganizationId       uuid.UUID       `json:"organization_id"`
+	Name                 string          `json:"name"`
+	IsDefault            bool            `json:"is_default"`
+	AddressLine1         string          `json:"address_line1"`
+	AddressLine2         string          `json:"address_line2"`
+	City                 string          `json:"city"`
+	Province             string          `json:"province"`
+	PostalCode           string          `json:"postal_code"`
+	Country              string          `json:"country"`
+	PhoneNumber          string          `json:"phone_number"`
+	CallForwardingNumber string          `json:"call_forwarding_number"`
+	TimeZone             string          `json:"time_zone"`
+	Specialities         []int           `json:"specialities"`
+	SpecialityCodes      []string        `json:"speciality_codes"`
+	DailySchedules       []DailySchedule `json:"daily_schedules"`
+	UserIds              []uuid.UUID     `json:"user_ids"`
+}
+
+type LocationUsersRequest struct {
+	UserIds []uuid.UUID `json:"user_ids" validate:"required"`
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannels tells which channels are enabled. In preferences, a
+// nil channel inherits the organization default.
+type NotificationChannels struct {
+	Email *bool `json:"email"`
+	SMS   *bool `json:"sms"`
+	InApp *bool `json:"in_app"`
+}
+
+// NotificationPreference overrides the channels of one event, or of every
+// event when Event is empty.
+type NotificationPreference struct {
+	Event string `json:"event" validate:"max=100"`
+	NotificationChannels
+}
+
+type NotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences" validate:"dive"`
+}
+
+// NotificationPreferencesResponse lists the organization defaults and the
+// overrides of a user.
+type NotificationPreferencesResponse struct {
+	OrganizationDefaults NotificationChannels     `json:"organization_defaults"`
+	Preferences          []NotificationPreference `json:"preferences"`
+}
+
+type NotificationTestRequest struct {
+	UserId *uuid.UUID `json:"user_id"`
+}
+
+type NotificationDeliveryResponse struct {
+	Id            uuid.UUID  `json:"id"`
+	UserId        *uuid.UUID `json:"user_id,omitempty"`
+	Event         string     `json:"event"`
+	Channel       string     `json:"channel"`
+	Recipient     string     `json:"recipient"`
+	Subject       string     `json:"subject"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type NotificationResponse struct {
+	Id        uuid.UUID  `json:"id"`
+	Event     string     `json:"event"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotificationPageResponse struct {
+	Items       []NotificationResponse `json:"items"`
+	Total       int64                  `json:"total"`
+	UnreadCount int64                  `json:"unread_count"`
+	Limit       int                    `json:"limit"`
+	Offset      int                    `json:"offset"`
+}
+
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
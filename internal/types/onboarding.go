@@ -0,0 +1,19 @@
+package types
+
+import "github.com/google/uuid"
+
+// OnboardingStep is an item of the onboarding checklist.
+type OnboardingStep struct {
+	Key    string `json:"key"`
+	Title  string `json:"title"`
+	Done   bool   `json:"done"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type OnboardingChecklistResponse struct {
+	OrganizationId uuid.UUID        `json:"organization_id"`
+	Completed      int              `json:"completed"`
+	Total          int              `json:"total"`
+	Done           bool             `json:"done"`
+	Steps          []OnboardingStep `json:"steps"`
+}
@@ -0,0 +1,62 @@
+package types
+
+import "github.com/google/uuid"
+
+type ScheduleExceptionRequest struct {
+	Date       string     `json:"date" validate:"required,datetime=2006-01-02" example:"2025-12-24"`
+	Closed     bool       `json:"closed"`
+	FromTime   string     `json:"from_time" validate:"required_if=Closed false,omitempty,clock" example:"09:00"`
+	ToTime     string     `json:"to_time" validate:"required_if=Closed false,omitempty,clock,clockafter=FromTime" example:"12:00"`
+	Reason     string     `json:"reason"`
+	LocationId *uuid.UUID `json:"location_id"`
+	UserId     *uuid.UUID `json:"user_id"`
+}
+
+type ScheduleExceptionResponse struct {
+	Id          uuid.UUID  `json:"id"`
+	Date        string     `json:"date"`
+	Closed      bool       `json:"closed"`
+	FromTime    string     `json:"from_time,omitempty"`
+	ToTime      string     `json:"to_time,omitempty"`
+	Reason      string     `json:"reason"`
+	HolidayCode string     `json:"holiday_code,omitempty"`
+	LocationId  *uuid.UUID `json:"location_id,omitempty"`
+	UserId      *uuid.UUID `json:"user_id,omitempty"`
+}
+
+type HolidayImportRequest struct {
+	Year       int        `json:"year" validate:"required,min=2000,max=2100"`
+	Province   string     `json:"province" validate:"required,len=2"`
+	LocationId *uuid.UUID `json:"location_id"`
+}
+
+type HolidayImportResponse struct {
+	Created     []ScheduleExceptionResponse `json:"created"`
+	Skipped     int                         `json:"skipped"`
+	Province    string                      `json:"province"`
+	Year        int                         `json:"year"`
+	DataVersion int                         `json:"data_version"`
+}
+
+// TimeRange is an opening range in local wall-clock time.
+type TimeRange struct {
+	FromTime string `json:"from_time"`
+	ToTime   string `json:"to_time"`
+}
+
+// CalendarDay is the effective schedule of a date after applying exceptions.
+type CalendarDay struct {
+	Date    string      `json:"date"`
+	Weekday string      `json:"weekday"`
+	Closed  bool        `json:"closed"`
+	Hours   []TimeRange `json:"hours"`
+	Source  string      `json:"source"`
+	Reason  string      `json:"reason,omitempty"`
+}
+
+type CalendarResponse struct {
+	TimeZone   string        `json:"time_zone"`
+	LocationId *uuid.UUID    `json:"location_id,omitempty"`
+	UserId     *uuid.UUID    `json:"user_id,omitempty"`
+	Days       []CalendarDay `json:"days"`
+}
@@ -0,0 +1,25 @@
+package types
+
+// SpecialityResponse is a speciality of the taxonomy. Children are only
+// filled in the tree format, Path only in the flat one.
+type SpecialityResponse struct {
+	Id       int                  `json:"id"`
+	Code     string               `json:"code"`
+	Name     string               `json:"name"`
+	ParentId *int                 `json:"parent_id,omitempty"`
+	Retired  bool                 `json:"retired"`
+	Path     string               `json:"path,omitempty"`
+	Children []SpecialityResponse `json:"children,omitempty"`
+}
+
+// SpecialityRequest creates or updates a speciality. Code can only be set on
+// creation and is generated from the name when omitted. Retired is only read
+// on update, and an omitted Retired leaves the speciality as it is. On update,
+// an omitted ParentId keeps the parent too, and a ParentId of 0 makes the
+// speciality a root.
+type SpecialityRequest struct {
+	Code     string `json:"code" validate:"omitempty,max=100"`
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentId *int   `json:"parent_id"`
+	Retired  *bool  `json:"retired"`
+}
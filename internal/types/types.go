@@ -1,8 +1,6 @@
 package types
 
 import (
-	"time"
-
 	"github.com/Comvoca-AI/comvoca-admin-back/internal/entity"
 	"github.com/google/uuid"
 )
@@ -14,7 +12,7 @@ type UserRequest struct {
 	Family         string          `json:"family"`
 	PhoneNumber    string          `json:"phone_number"`
 	Role           string          `json:"role"`
-	DailySchedules []DailySchedule `json:"daily_schedules"`
+	DailySchedules []DailySchedule `json:"daily_schedules" validate:"nooverlap,dive"`
 }
 
 type UserResponse struct {
@@ -27,22 +25,33 @@ type UserResponse struct {
 	DailySchedules []DailySchedule `json:"daily_schedules"`
 }
 
+// DailySchedule is an opening range on a day of the week, with FromTime and
+// ToTime given as local wall-clock times such as "09:00" and "17:30". Several
+// ranges on the same day describe a split shift.
 type DailySchedule struct {
-	DayOfWeek int       `json:"day_of_week"`
-	FromTime  time.Time `json:"from_time"`
-	ToTime    time.Time `json:"to_time"`
+	DayOfWeek Weekday `json:"day_of_week" validate:"weekday" swaggertype:"string" example:"Monday"`
+	FromTime  string  `json:"from_time" validate:"required,clock" example:"09:00"`
+	ToTime    string  `json:"to_time" validate:"required,clock,clockafter=FromTime" example:"17:00"`
+}
+
+// TimeRange lets the "nooverlap" validator compare schedules of the same day.
+func (s DailySchedule) TimeRange() (int, string, string) {
+	return int(s.DayOfWeek), s.FromTime, s.ToTime
 }
 
 type OrganizationRequest struct {
-	Email                string                    `json:"email"`
-	Name                 string                    `json:"name"`
-	Website              string                    `json:"website"`
-	PhoneNumber          string                    `json:"phone_number"`
-	InsuranceCompany     string                    `json:"insuranceCompany"`
-	Specialities         []int                     `json:"specialities"`
-	DailySchedules       []DailySchedule           `json:"dailySchedules"`
-	Notifications        []entity.NotificationType `json:"notifications"`
-	CallForwardingNumber string                    `json:"callForwadingNumber"`
+	Email                 string                    `json:"email"`
+	Name                  string                    `json:"name"`
+	Website               string                    `json:"website"`
+	PhoneNumber           string                    `json:"phone_number"`
+	TimeZone              string                    `json:"time_zone" validate:"omitempty,timezone"`
+	InsuranceCompany      string                    `json:"insuranceCompany"`
+	Specialities          []int                     `json:"specialities"`
+	SpecialityCodes       []string                  `json:"speciality_codes"`
+	DailySchedules        []DailySchedule           `json:"dailySchedules" validate:"nooverlap,dive"`
+	Notifications         []entity.NotificationType `json:"notifications"`
+	CallForwardingNumber  string                    `json:"callForwadingNumber"`
+	AgentApprovalRequired *bool                     `json:"agent_approval_required"`
 }
 
 type OrganizationResponse struct {
@@ -51,12 +60,17 @@ type OrganizationResponse struct {
 	Name                 *string                    `json:"name"`
 	Website              *string                    `json:"website"`
 	PhoneNumber          *string                    `json:"phone_number"`
+	TimeZone             *string                    `json:"time_zone"`
 	Specialities         *[]int                     `json:"specialities"`
 	DailySchedules       *[]DailySchedule           `json:"daily_schedules"`
 	Notifications        *[]entity.NotificationType `json:"notifications"`
 	CallForwardingNumber *string                    `json:"call_forwarding_number"`
 }
 
+type UserScheduleRequest struct {
+	DailySchedules []DailySchedule `json:"daily_schedules" validate:"nooverlap,dive"`
+}
+
 type AuthRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseWeekday parses a weekday name, either in full ("Monday") or
+// abbreviated ("Mon"), ignoring case.
+func ParseWeekday(name string) (Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := Sunday; day <= Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", name)
+}
+
+// Valid reports whether the weekday is between Sunday and Saturday.
+func (i Weekday) Valid() bool {
+	return i >= Sunday && i <= Saturday
+}
+
+// MarshalJSON writes the weekday name, e.g. "Monday".
+func (i Weekday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON accepts a weekday number (0 is Sunday) or a weekday name.
+func (i *Weekday) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		*i = Weekday(number)
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("weekday must be a number or a name")
+	}
+	day, err := ParseWeekday(name)
+	if err != nil {
+		return err
+	}
+	*i = day
+	return nil
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWeekdayJSON(t *testing.T) {
+	for day := Sunday; day <= Saturday; day++ {
+		data, err := json.Marshal(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `"` + day.String() + `"`; string(data) != want {
+			t.Errorf("Marshal(%d) = %s, want %s", day, data, want)
+		}
+		var got Weekday
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != day {
+			t.Errorf("round trip of %s = %s", day, got)
+		}
+	}
+
+	schedule := DailySchedule{DayOfWeek: Monday, FromTime: "09:00", ToTime: "17:00"}
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got DailySchedule
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != schedule {
+		t.Errorf("round trip of %s = %+v", data, got)
+	}
+}
+
+func TestWeekdayUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Weekday
+		wantErr bool
+	}{
+		{`1`, Monday, false},
+		{`"Monday"`, Monday, false},
+		{`"sat"`, Saturday, false},
+		{`" SUNDAY "`, Sunday, false},
+		{`"Someday"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, tt := range tests {
+		var got Weekday
+		err := json.Unmarshal([]byte(tt.data), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, want error %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Unmarshal(%s) = %s, want %s", tt.data, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TimeRange is implemented by schedule entries validated with "nooverlap".
+// It returns the day the range belongs to and its "15:04" bounds.
+type TimeRange interface {
+	TimeRange() (int, string, string)
+}
+
+// ClockMinutes converts a "15:04" wall-clock time into minutes since
+// midnight. "24:00" is accepted as the end of the day.
+func ClockMinutes(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if value[i] < '0' || value[i] > '9' {
+			return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+		}
+	}
+	hour := int(value[0]-'0')*10 + int(value[1]-'0')
+	minute := int(value[3]-'0')*10 + int(value[4]-'0')
+	if hour > 24 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return hour*60 + minute, nil
+}
+
+// validateWeekday accepts 0 (Sunday) to 6 (Saturday).
+func validateWeekday(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		day := fl.Field().Int()
+		return day >= 0 && day <= 6
+	}
+	return false
+}
+
+// validateClock accepts "15:04" wall-clock times.
+func validateClock(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	_, err := ClockMinutes(fl.Field().String())
+	return err == nil
+}
+
+// validateClockAfter checks that the time is strictly after the one held by
+// the sibling field named in the tag parameter, e.g. "clockafter=FromTime".
+func validateClockAfter(fl validator.FieldLevel) bool {
+	other, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found || kind != reflect.String {
+		return false
+	}
+	to, err := ClockMinutes(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	from, err := ClockMinutes(other.String())
+	if err != nil {
+		// the sibling field reports its own format error
+		return true
+	}
+	return to > from
+}
+
+// validateNoOverlap checks that the ranges of a slice do not overlap on the
+// same day. Ranges that only touch, such as 09:00-12:00 and 12:00-17:00, are
+// allowed and merged when saved.
+func validateNoOverlap(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return false
+	}
+
+	type span struct{ from, to int }
+	days := map[int][]span{}
+	for i := 0; i < field.Len(); i++ {
+		item, ok := field.Index(i).Interface().(TimeRange)
+		if !ok {
+			return false
+		}
+		day, fromValue, toValue := item.TimeRange()
+		from, err := ClockMinutes(fromValue)
+		if err != nil {
+			continue
+		}
+		to, err := ClockMinutes(toValue)
+		if err != nil || to <= from {
+			// invalid ranges are reported by the element validation
+			continue
+		}
+		days[day] = append(days[day], span{from, to})
+	}
+
+	for _, spans := range days {
+		sort.Slice(spans, func(i, j int) bool { return spans[i].from < spans[j].from })
+		for i := 1; i < len(spans); i++ {
+			if spans[i].from < spans[i-1].to {
+				return false
+			}
+		}
+	}
+	return true
+}
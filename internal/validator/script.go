@@ -0,0 +1,16 @@
+package validator
+
+import (
+	"reflect"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/internal/script"
+	"github.com/go-playground/validator/v10"
+)
+
+// validateScript accepts agent scripts only using known {{variables}}.
+func validateScript(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	return script.Validate(fl.Field().String()) == nil
+}
@@ -1,11 +1,34 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
 
 var validate *validator.Validate
 
 func init() {
 	validate = validator.New(validator.WithRequiredStructEnabled())
+
+	// Report fields by their JSON name so error details match the request body
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	_ = validate.RegisterValidation("weekday", validateWeekday)
+	_ = validate.RegisterValidation("clock", validateClock)
+	_ = validate.RegisterValidation("clockafter", validateClockAfter)
+	_ = validate.RegisterValidation("nooverlap", validateNoOverlap)
+	_ = validate.RegisterValidation("script", validateScript)
 }
 
 func GetValidator() *validator.Validate {
@@ -0,0 +1,107 @@
+package voice
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeVapiServer implements the assistant endpoints of the Vapi API in
+// memory, so syncing can be exercised offline. POST /_fake/fail?count=N makes
+// the next N requests fail with a 500 to exercise retries.
+type FakeVapiServer struct {
+	apiKey     string
+	mu         sync.Mutex
+	assistants map[string]VapiAssistant
+	failures   int
+}
+
+// NewFakeVapiServer creates a fake server accepting the given API key, or
+// any key when empty.
+func NewFakeVapiServer(apiKey string) *FakeVapiServer {
+	return &FakeVapiServer{apiKey: apiKey, assistants: map[string]VapiAssistant{}}
+}
+
+func (s *FakeVapiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/_fake/fail" && r.Method == http.MethodPost {
+		count, err := strconv.Atoi(r.URL.Query().Get("count"))
+		if err != nil || count < 0 {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": "count must be a positive number"})
+			return
+		}
+		s.failures = count
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if s.apiKey != "" && r.Header.Get("Authorization") != "Bearer "+s.apiKey {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Invalid API key"})
+		return
+	}
+	if s.failures > 0 {
+		s.failures--
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"message": "Injected failure"})
+		return
+	}
+
+	id, hasID := strings.CutPrefix(r.URL.Path, "/assistant/")
+	switch {
+	case r.URL.Path == "/assistant" && r.Method == http.MethodGet:
+		list := make([]VapiAssistant, 0, len(s.assistants))
+		for _, assistant := range s.assistants {
+			list = append(list, assistant)
+		}
+		writeJSON(w, http.StatusOK, list)
+	case r.URL.Path == "/assistant" && r.Method == http.MethodPost:
+		var assistant VapiAssistant
+		if err := json.NewDecoder(r.Body).Decode(&assistant); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		assistant.ID = uuid.NewString()
+		s.assistants[assistant.ID] = assistant
+		writeJSON(w, http.StatusCreated, assistant)
+	case hasID && r.Method == http.MethodGet:
+		assistant, ok := s.assistants[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Assistant not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, assistant)
+	case hasID && r.Method == http.MethodPatch:
+		if _, ok := s.assistants[id]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Assistant not found"})
+			return
+		}
+		var assistant VapiAssistant
+		if err := json.NewDecoder(r.Body).Decode(&assistant); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		assistant.ID = id
+		s.assistants[id] = assistant
+		writeJSON(w, http.StatusOK, assistant)
+	case hasID && r.Method == http.MethodDelete:
+		assistant, ok := s.assistants[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Assistant not found"})
+			return
+		}
+		delete(s.assistants, id)
+		writeJSON(w, http.StatusOK, assistant)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not found"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,148 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// VapiBaseURL is the address of the Vapi API.
+const VapiBaseURL = "https://api.vapi.ai"
+
+// Languages of the transcriber, by agent language.
+var vapiLanguages = map[string]string{
+	"english": "en",
+	"french":  "fr",
+	"spanish": "es",
+}
+
+// Vapi voices, by agent voice type.
+var vapiVoices = map[string]string{
+	"professional": "Elliot",
+	"friendly":     "Kylie",
+	"casual":       "Rohan",
+}
+
+// VapiClient manages assistants through the Vapi REST API.
+type VapiClient struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func (c *VapiClient) Name() string {
+	return "vapi"
+}
+
+// VapiAssistant is the subset of the Vapi assistant resource the agent
+// configuration fills in.
+type VapiAssistant struct {
+	ID             string            `json:"id,omitempty"`
+	Name           string            `json:"name"`
+	FirstMessage   string            `json:"firstMessage"`
+	EndCallMessage string            `json:"endCallMessage"`
+	Model          VapiModel         `json:"model"`
+	Voice          VapiVoice         `json:"voice"`
+	Transcriber    VapiTranscriber   `json:"transcriber"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+type VapiModel struct {
+	Provider string        `json:"provider"`
+	Model    string        `json:"model"`
+	Messages []VapiMessage `json:"messages"`
+}
+
+type VapiMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type VapiVoice struct {
+	Provider string `json:"provider"`
+	VoiceID  string `json:"voiceId"`
+}
+
+type VapiTranscriber struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Language string `json:"language"`
+}
+
+func (c *VapiClient) CreateAssistant(ctx context.Context, assistant Assistant) (string, error) {
+	var created VapiAssistant
+	if err := c.do(ctx, http.MethodPost, "/assistant", toVapiAssistant(assistant), &created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("vapi returned no assistant id")
+	}
+	return created.ID, nil
+}
+
+func (c *VapiClient) UpdateAssistant(ctx context.Context, id string, assistant Assistant) error {
+	return c.do(ctx, http.MethodPatch, "/assistant/"+url.PathEscape(id), toVapiAssistant(assistant), nil)
+}
+
+func (c *VapiClient) do(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAssistantNotFound
+	}
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vapi returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func toVapiAssistant(assistant Assistant) VapiAssistant {
+	language, ok := vapiLanguages[assistant.Language]
+	if !ok {
+		language = "en"
+	}
+	if len(assistant.Languages) > 1 {
+		// the transcriber detects the language the caller speaks
+		language = "multi"
+	}
+	voice, ok := vapiVoices[assistant.VoiceType]
+	if !ok {
+		voice = vapiVoices["professional"]
+	}
+	return VapiAssistant{
+		Name:           assistant.Name,
+		FirstMessage:   assistant.FirstMessage,
+		EndCallMessage: assistant.EndCallMessage,
+		Model: VapiModel{
+			Provider: "openai",
+			Model:    "gpt-4o",
+			Messages: []VapiMessage{{Role: "system", Content: assistant.SystemPrompt}},
+		},
+		Voice:       VapiVoice{Provider: "vapi", VoiceID: voice},
+		Transcriber: VapiTranscriber{Provider: "deepgram", Model: "nova-2", Language: language},
+		Metadata:    assistant.Metadata,
+	}
+}
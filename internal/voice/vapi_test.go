@@ -0,0 +1,117 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+)
+
+const fakeAPIKey = "test-key"
+
+func newFakePlatform(t *testing.T, apiKey string) (Platform, *FakeVapiServer, *httptest.Server) {
+	t.Helper()
+	fake := NewFakeVapiServer(fakeAPIKey)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	platform, err := NewPlatform(config.VoicePlatformConfig{
+		Driver: "vapi",
+		Vapi:   config.VapiConfig{BaseURL: server.URL, APIKey: apiKey},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return platform, fake, server
+}
+
+func (s *FakeVapiServer) assistant(id string) (VapiAssistant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assistant, ok := s.assistants[id]
+	return assistant, ok
+}
+
+func TestVapiSync(t *testing.T) {
+	platform, fake, _ := newFakePlatform(t, fakeAPIKey)
+	ctx := context.Background()
+
+	assistant := Assistant{
+		Name:           "Maple Dental",
+		Language:       "english",
+		Languages:      []string{"english"},
+		VoiceType:      "friendly",
+		FirstMessage:   "Hi, you reached Maple Dental.",
+		EndCallMessage: "Goodbye.",
+		SystemPrompt:   "You are the phone receptionist of Maple Dental.",
+		Metadata:       map[string]string{"organization_id": "org", "checksum": "sha256:1"},
+	}
+	id, err := platform.CreateAssistant(ctx, assistant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, ok := fake.assistant(id)
+	if !ok {
+		t.Fatalf("assistant %s was not created", id)
+	}
+	if created.FirstMessage != assistant.FirstMessage || created.EndCallMessage != assistant.EndCallMessage {
+		t.Errorf("messages = %q, %q", created.FirstMessage, created.EndCallMessage)
+	}
+	if len(created.Model.Messages) != 1 || created.Model.Messages[0].Content != assistant.SystemPrompt {
+		t.Errorf("model messages = %+v", created.Model.Messages)
+	}
+	if created.Voice.VoiceID != "Kylie" || created.Transcriber.Language != "en" {
+		t.Errorf("voice = %q, transcriber language = %q", created.Voice.VoiceID, created.Transcriber.Language)
+	}
+	if created.Metadata["checksum"] != "sha256:1" {
+		t.Errorf("metadata = %v", created.Metadata)
+	}
+
+	assistant.Languages = []string{"english", "french"}
+	assistant.FirstMessage = "Hi, you reached Maple Dental. Bonjour !"
+	assistant.Metadata = map[string]string{"organization_id": "org", "checksum": "sha256:2"}
+	if err := platform.UpdateAssistant(ctx, id, assistant); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := fake.assistant(id)
+	if updated.FirstMessage != assistant.FirstMessage || updated.Metadata["checksum"] != "sha256:2" {
+		t.Errorf("assistant was not updated: %+v", updated)
+	}
+	if updated.Transcriber.Language != "multi" {
+		t.Errorf("transcriber language = %q, want multi for a multilingual agent", updated.Transcriber.Language)
+	}
+}
+
+func TestVapiUpdateUnknownAssistant(t *testing.T) {
+	platform, _, _ := newFakePlatform(t, fakeAPIKey)
+	err := platform.UpdateAssistant(context.Background(), "missing", Assistant{Name: "Maple Dental"})
+	if !errors.Is(err, ErrAssistantNotFound) {
+		t.Errorf("UpdateAssistant() = %v, want ErrAssistantNotFound", err)
+	}
+}
+
+func TestVapiInvalidKey(t *testing.T) {
+	platform, _, _ := newFakePlatform(t, "wrong-key")
+	if _, err := platform.CreateAssistant(context.Background(), Assistant{Name: "Maple Dental"}); err == nil {
+		t.Error("CreateAssistant() succeeded with an invalid API key")
+	}
+}
+
+func TestVapiInjectedFailures(t *testing.T) {
+	platform, _, server := newFakePlatform(t, fakeAPIKey)
+	resp, err := http.Post(server.URL+"/_fake/fail?count=1", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	ctx := context.Background()
+	if _, err := platform.CreateAssistant(ctx, Assistant{Name: "Maple Dental"}); err == nil {
+		t.Fatal("CreateAssistant() succeeded while failing")
+	}
+	if _, err := platform.CreateAssistant(ctx, Assistant{Name: "Maple Dental"}); err != nil {
+		t.Errorf("CreateAssistant() after the failures = %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package voice talks to the platform running the voice agent. The platform
+// hosts an assistant per organization, created and updated from the compiled
+// agent configuration.
+package voice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Comvoca-AI/comvoca-admin-back/config"
+)
+
+// ErrAssistantNotFound is returned when updating an assistant the platform
+// does not know, e.g. because it was deleted there.
+var ErrAssistantNotFound = errors.New("assistant not found")
+
+// Assistant is what the platform needs to run the agent of an organization.
+type Assistant struct {
+	Name     string
+	Language string
+	// Languages lists every language the agent speaks, the default one first
+	Languages      []string
+	VoiceType      string
+	FirstMessage   string
+	EndCallMessage string
+	SystemPrompt   string
+	// Metadata is stored with the assistant, e.g. the organization ID and the
+	// checksum of the configuration
+	Metadata map[string]string
+}
+
+// Platform creates and updates assistants on a voice platform.
+type Platform interface {
+	Name() string
+	CreateAssistant(ctx context.Context, assistant Assistant) (string, error)
+	UpdateAssistant(ctx context.Context, id string, assistant Assistant) error
+}
+
+// NewPlatform returns the platform selected by the configuration, or nil
+// when syncing is disabled.
+func NewPlatform(cfg config.VoicePlatformConfig) (Platform, error) {
+	switch cfg.Driver {
+	case "vapi":
+		if cfg.Vapi.APIKey == "" {
+			return nil, fmt.Errorf("vapi api key is not configured")
+		}
+		baseURL := cfg.Vapi.BaseURL
+		if baseURL == "" {
+			baseURL = VapiBaseURL
+		}
+		return &VapiClient{baseURL: baseURL, apiKey: cfg.Vapi.APIKey, client: &http.Client{Timeout: 15 * time.Second}}, nil
+	case "none", "":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown voice platform driver %q", cfg.Driver)
+}
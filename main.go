@@ -27,6 +27,8 @@ func init() {
 	rootCmd.AddCommand(cmd.RunCmd)
 	rootCmd.AddCommand(cmd.TestCmd)
 	rootCmd.AddCommand(cmd.InsertTestDataCmd)
+	rootCmd.AddCommand(cmd.SyncSpecialitiesCmd)
+	rootCmd.AddCommand(cmd.FakeVapiCmd)
 }
 
 // @title Comvoca Admin API